@@ -32,6 +32,9 @@ func main() {
 	if err := database.AutoMigrate(deps.DB); err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	}
+	if err := database.SeedNotificationRules(deps.DB); err != nil {
+		log.Fatal("Failed to seed notification rules:", err)
+	}
 
 	r := setupRouter(deps)
 
@@ -46,6 +49,13 @@ func main() {
 		}
 	}()
 
+	deps.ExpirySweeper.Start()
+	deps.StorageVerifier.Start()
+	deps.ExpirationChecker.Start()
+	deps.OutboxWorker.Start()
+	deps.DigestBuilder.Start()
+	deps.ThumbnailWorker.Start()
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
@@ -58,6 +68,25 @@ func main() {
 		log.Fatal("Server forced to shutdown:", err)
 	}
 
+	if err := deps.ExpirySweeper.Stop(ctx); err != nil {
+		log.Println("Expiry sweeper did not stop in time:", err)
+	}
+	if err := deps.StorageVerifier.Stop(ctx); err != nil {
+		log.Println("Storage verifier did not stop in time:", err)
+	}
+	if err := deps.ExpirationChecker.Stop(ctx); err != nil {
+		log.Println("Expiration checker did not stop in time:", err)
+	}
+	if err := deps.OutboxWorker.Stop(ctx); err != nil {
+		log.Println("Outbox worker did not stop in time:", err)
+	}
+	if err := deps.DigestBuilder.Stop(ctx); err != nil {
+		log.Println("Digest builder did not stop in time:", err)
+	}
+	if err := deps.ThumbnailWorker.Stop(ctx); err != nil {
+		log.Println("Thumbnail worker did not stop in time:", err)
+	}
+
 	log.Println("Server exiting")
 }
 
@@ -78,8 +107,24 @@ func setupRouter(deps *di.ServerDependencies) *gin.Engine {
 
 func setupRoutes(r *gin.Engine, deps *di.ServerDependencies) {
 	routerDeps := &router.RouterDeps{
-		AuthHandler: deps.AuthHandler,
-		Middleware:  deps.Middleware,
+		AuthHandler:                     deps.AuthHandler,
+		PeopleHandler:                   deps.PeopleHandler,
+		EquipmentHandler:                deps.EquipmentHandler,
+		EquipmentClassHandler:           deps.EquipmentClassHandler,
+		CertificationTypeHandler:        deps.CertificationTypeHandler,
+		CertificationHandler:            deps.CertificationHandler,
+		DocumentHandler:                 deps.DocumentHandler,
+		DocumentLinkHandler:             deps.DocumentLinkHandler,
+		DocumentAccessHandler:           deps.DocumentAccessHandler,
+		CertificationRequirementHandler: deps.CertificationRequirementHandler,
+		ComplianceHandler:               deps.ComplianceHandler,
+		OperatorAuthorizationHandler:    deps.OperatorAuthorizationHandler,
+		NotificationRuleHandler:         deps.NotificationRuleHandler,
+		NotificationHandler:             deps.NotificationHandler,
+		NotificationDigestHandler:       deps.NotificationDigestHandler,
+		NotificationEscalationHandler:   deps.NotificationEscalationHandler,
+		JobsHandler:                     deps.JobsHandler,
+		Middleware:                      deps.Middleware,
 	}
 
 	router.SetupRouter(routerDeps, r)
@@ -0,0 +1,49 @@
+package storage_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"certitrack/internal/storage"
+	"certitrack/testutils/testcontainer"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3Store_MinioIntegration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test in short mode")
+	}
+
+	ctx := context.Background()
+	minio, err := testcontainer.SetupMinio(ctx, "certitrack-documents")
+	require.NoError(t, err, "Failed to setup MinIO container")
+	defer minio.Teardown(ctx)
+
+	store, err := storage.NewS3Store(minio.Config)
+	require.NoError(t, err)
+	require.NoError(t, store.CreateBucket())
+	require.NoError(t, store.CreateBucket(), "creating an owned bucket twice should succeed")
+
+	key := "certifications/0b3f/7c1e.pdf"
+	size, err := store.Save(key, strings.NewReader("%PDF-1.4 certificate"), 1<<20)
+	require.NoError(t, err)
+	require.Equal(t, int64(20), size)
+
+	r, err := store.Open(key)
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, "%PDF-1.4 certificate", string(content))
+
+	_, err = store.Save("too-big.pdf", strings.NewReader("0123456789"), 5)
+	require.ErrorIs(t, err, storage.ErrFileTooLarge)
+
+	require.NoError(t, store.Delete(key))
+	require.NoError(t, store.Delete(key), "deleting a missing object should succeed")
+	_, err = store.Open(key)
+	require.ErrorIs(t, err, storage.ErrObjectNotFound)
+}
@@ -53,3 +53,6 @@ func (c *Client) Set(ctx context.Context, key string, value interface{}, expirat
 func (c *Client) Exists(ctx context.Context, keys ...string) *redis.IntCmd {
 	return c.client.Exists(ctx, keys...)
 }
+func (c *Client) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+	return c.client.SetNX(ctx, key, value, expiration)
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"certitrack/internal/mailer"
@@ -16,19 +17,27 @@ import (
 )
 
 type Config struct {
-	App      AppConfig
-	Database DatabaseConfig
-	Redis    RedisConfig
-	JWT      JWTConfig
-	SMTP     SMTPConfig
-	Storage  StorageConfig
-	Logger   LoggerConfig
+	App        AppConfig
+	Database   DatabaseConfig
+	Redis      RedisConfig
+	JWT        JWTConfig
+	SMTP       SMTPConfig
+	Storage    StorageConfig
+	Logger     LoggerConfig
+	Jobs       JobsConfig
+	Scanner    ScannerConfig
+	Thumbnails ThumbnailConfig
+	Encryption EncryptionConfig
+	Links      DocumentLinkConfig
+	Outbox     OutboxConfig
+	Escalation EscalationConfig
 }
 
 type AppConfig struct {
-	Env  string
-	Port string
-	URL  string
+	Env    string
+	Port   string
+	URL    string
+	APIURL string // public base URL of this API, used in generated links
 }
 
 type DatabaseConfig struct {
@@ -72,9 +81,75 @@ func (s SMTPConfig) ToMailerConfig() mailer.Config {
 }
 
 type StorageConfig struct {
+	Backend     string // "local" or "s3"
 	Root        string
 	MaxSizeMB   int
-	AllowedExts []string
+	AllowedExts []string // content must also match; see storage.Sniff
+	S3          S3Config
+}
+
+type S3Config struct {
+	Endpoint        string // empty means AWS (https://s3.<region>.amazonaws.com)
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	ForcePathStyle  bool // required by MinIO and most S3-compatible servers
+}
+
+type ScannerConfig struct {
+	Enabled bool
+	Network string // "tcp" or "unix"
+	Address string // host:port, or the socket path for unix
+	Timeout time.Duration
+}
+
+// EncryptionConfig holds the master keys that wrap the per-object data keys
+// of stored documents. Keys are base64-encoded 32-byte AES keys. Objects are
+// wrapped with Key; RetiredKeys ("id:key" pairs) are only used to unwrap
+// data keys until the rotation job has rewrapped them.
+type EncryptionConfig struct {
+	KeyID       string
+	Key         string
+	RetiredKeys []string
+}
+
+// DocumentLinkConfig controls signed download links. Secret falls back to
+// JWT.Secret when unset.
+type DocumentLinkConfig struct {
+	Secret     string
+	DefaultTTL time.Duration
+	MaxTTL     time.Duration
+}
+
+type ThumbnailConfig struct {
+	Size    int // longest edge in pixels
+	Workers int
+}
+
+type JobsConfig struct {
+	ExpirySweepInterval     time.Duration
+	StorageVerifyInterval   time.Duration
+	ExpirationCheckInterval time.Duration
+	OutboxInterval          time.Duration
+	DigestInterval          time.Duration
+}
+
+// OutboxConfig controls delivery of scheduled notification emails. A failed
+// delivery is retried after RetryBaseDelay, doubling on every further
+// failure up to RetryMaxDelay, and dead-lettered after MaxAttempts.
+type OutboxConfig struct {
+	BatchSize      int
+	MaxAttempts    int
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+}
+
+// EscalationConfig controls escalation of certifications that are not
+// renewed after their first reminder: every AfterDays days the next person
+// in the chain is notified. Zero disables escalation.
+type EscalationConfig struct {
+	AfterDays int
 }
 
 type LoggerConfig struct {
@@ -130,9 +205,10 @@ func Load() (*Config, error) {
 
 	config := &Config{
 		App: AppConfig{
-			Env:  GetEnv("APP_ENV", "development"),
-			Port: GetEnv("PORT", "8080"),
-			URL:  GetEnv("APP_URL", "http://localhost:3000"),
+			Env:    GetEnv("APP_ENV", "development"),
+			Port:   GetEnv("PORT", "8080"),
+			URL:    GetEnv("APP_URL", "http://localhost:3000"),
+			APIURL: GetEnv("API_URL", "http://localhost:8080"),
 		},
 		Database: DatabaseConfig{
 			Host:     GetEnv("DB_HOST", "localhost"),
@@ -161,14 +237,59 @@ func Load() (*Config, error) {
 			From:     GetEnv("SMTP_FROM", "CertiTrack <noreply@certitrack.local>"),
 		},
 		Storage: StorageConfig{
+			Backend:     GetEnv("STORAGE_BACKEND", "local"),
 			Root:        GetEnv("STORAGE_ROOT", "./storage"),
 			MaxSizeMB:   parseInt(GetEnv("MAX_FILE_SIZE_MB", "10")),
-			AllowedExts: []string{".pdf", ".doc", ".docx", ".jpg", ".jpeg", ".png", ".gif"},
+			AllowedExts: []string{".pdf", ".docx", ".jpg", ".jpeg", ".png", ".gif"},
+			S3: S3Config{
+				Endpoint:        GetEnv("S3_ENDPOINT", ""),
+				Region:          GetEnv("S3_REGION", "us-east-1"),
+				Bucket:          GetEnv("S3_BUCKET", ""),
+				AccessKeyID:     GetEnv("S3_ACCESS_KEY_ID", ""),
+				SecretAccessKey: GetEnv("S3_SECRET_ACCESS_KEY", ""),
+				ForcePathStyle:  parseBool(GetEnv("S3_FORCE_PATH_STYLE", "false")),
+			},
 		},
 		Logger: LoggerConfig{
 			Level:         GetEnv("LOG_LEVEL", "info"),
 			EnableMetrics: parseBool(GetEnv("ENABLE_METRICS", "false")),
 		},
+		Jobs: JobsConfig{
+			ExpirySweepInterval:     parseDuration(GetEnv("EXPIRY_SWEEP_INTERVAL", "1h")),
+			StorageVerifyInterval:   parseDuration(GetEnv("STORAGE_VERIFY_INTERVAL", "24h")),
+			ExpirationCheckInterval: parseDuration(GetEnv("EXPIRATION_CHECK_INTERVAL", "24h")),
+			OutboxInterval:          parseDuration(GetEnv("OUTBOX_INTERVAL", "1m")),
+			DigestInterval:          parseDuration(GetEnv("DIGEST_INTERVAL", "1h")),
+		},
+		Outbox: OutboxConfig{
+			BatchSize:      parseInt(GetEnv("OUTBOX_BATCH_SIZE", "50")),
+			MaxAttempts:    parseInt(GetEnv("OUTBOX_MAX_ATTEMPTS", "8")),
+			RetryBaseDelay: parseDuration(GetEnv("OUTBOX_RETRY_BASE_DELAY", "1m")),
+			RetryMaxDelay:  parseDuration(GetEnv("OUTBOX_RETRY_MAX_DELAY", "6h")),
+		},
+		Escalation: EscalationConfig{
+			AfterDays: parseInt(GetEnv("ESCALATION_AFTER_DAYS", "7")),
+		},
+		Thumbnails: ThumbnailConfig{
+			Size:    parseInt(GetEnv("THUMBNAIL_SIZE", "256")),
+			Workers: parseInt(GetEnv("THUMBNAIL_WORKERS", "2")),
+		},
+		Encryption: EncryptionConfig{
+			KeyID:       GetEnv("ENCRYPTION_KEY_ID", ""),
+			Key:         GetEnv("ENCRYPTION_KEY", ""),
+			RetiredKeys: parseList(GetEnv("ENCRYPTION_RETIRED_KEYS", "")),
+		},
+		Links: DocumentLinkConfig{
+			Secret:     GetEnv("DOCUMENT_LINK_SECRET", ""),
+			DefaultTTL: parseDuration(GetEnv("DOCUMENT_LINK_TTL", "15m")),
+			MaxTTL:     parseDuration(GetEnv("DOCUMENT_LINK_MAX_TTL", "168h")),
+		},
+		Scanner: ScannerConfig{
+			Enabled: parseBool(GetEnv("ENABLE_VIRUS_SCANNING", "false")),
+			Network: GetEnv("CLAMAV_NETWORK", "tcp"),
+			Address: GetEnv("CLAMAV_ADDRESS", "localhost:3310"),
+			Timeout: parseDuration(GetEnv("CLAMAV_TIMEOUT", "30s")),
+		},
 	}
 
 	if err := config.Validate(); err != nil {
@@ -195,6 +316,47 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("DB_USER is required")
 	}
 
+	switch c.Storage.Backend {
+	case "local":
+	case "s3":
+		if c.Storage.S3.Bucket == "" {
+			return fmt.Errorf("S3_BUCKET is required when STORAGE_BACKEND is s3")
+		}
+		if c.Storage.S3.AccessKeyID == "" || c.Storage.S3.SecretAccessKey == "" {
+			return fmt.Errorf("S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY are required when STORAGE_BACKEND is s3")
+		}
+	default:
+		return fmt.Errorf("STORAGE_BACKEND must be local or s3, got %q", c.Storage.Backend)
+	}
+
+	if c.Scanner.Enabled && c.Scanner.Network != "tcp" && c.Scanner.Network != "unix" {
+		return fmt.Errorf("CLAMAV_NETWORK must be tcp or unix, got %q", c.Scanner.Network)
+	}
+
+	if c.Links.Secret != "" && len(c.Links.Secret) < 32 {
+		return fmt.Errorf("DOCUMENT_LINK_SECRET must be at least 32 characters long")
+	}
+	if c.Links.DefaultTTL <= 0 || c.Links.MaxTTL < c.Links.DefaultTTL {
+		return fmt.Errorf("DOCUMENT_LINK_TTL must be positive and not exceed DOCUMENT_LINK_MAX_TTL")
+	}
+
+	if c.Outbox.BatchSize <= 0 || c.Outbox.MaxAttempts <= 0 {
+		return fmt.Errorf("OUTBOX_BATCH_SIZE and OUTBOX_MAX_ATTEMPTS must be positive")
+	}
+	if c.Outbox.RetryBaseDelay <= 0 || c.Outbox.RetryMaxDelay < c.Outbox.RetryBaseDelay {
+		return fmt.Errorf("OUTBOX_RETRY_BASE_DELAY must be positive and not exceed OUTBOX_RETRY_MAX_DELAY")
+	}
+	if c.Escalation.AfterDays < 0 {
+		return fmt.Errorf("ESCALATION_AFTER_DAYS must not be negative")
+	}
+
+	if c.Encryption.Key != "" && c.Encryption.KeyID == "" {
+		return fmt.Errorf("ENCRYPTION_KEY_ID is required when ENCRYPTION_KEY is set")
+	}
+	if c.Encryption.Key == "" && !c.AllowsPlaintextStorage() {
+		return fmt.Errorf("ENCRYPTION_KEY is required outside development")
+	}
+
 	return nil
 }
 
@@ -218,6 +380,12 @@ func (c *Config) IsProduction() bool {
 	return c.App.Env == "production"
 }
 
+// AllowsPlaintextStorage reports whether documents may be stored without
+// encryption at rest, which only local development and tests permit.
+func (c *Config) AllowsPlaintextStorage() bool {
+	return c.IsDevelopment() || c.App.Env == "test"
+}
+
 func GetEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
@@ -239,6 +407,17 @@ func parseBool(s string) bool {
 	return false
 }
 
+// parseList splits a comma-separated value, dropping empty entries.
+func parseList(s string) []string {
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
 func parseDuration(s string) time.Duration {
 	if d, err := time.ParseDuration(s); err == nil {
 		return d
@@ -24,7 +24,8 @@ func Connect(cfg *config.Config) (*gorm.DB, error) {
 	}
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: gormLogger,
+		Logger:         gormLogger,
+		TranslateError: true,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
@@ -51,10 +52,24 @@ func AutoMigrate(db *gorm.DB) error {
 	err := db.AutoMigrate(
 		&models.User{},
 		&models.PasswordResetToken{},
+		&models.Person{},
+		&models.EquipmentClass{},
+		&models.Equipment{},
+		&models.CertificationType{},
+		&models.Certification{},
+		&models.CertificationStatusHistory{},
+		&models.CertificationRequirement{},
+		&models.CertificationDocument{},
+		&models.CertificationDocumentVersion{},
+		&models.AuditLog{},
+		&models.EncryptionKey{},
+		&models.DocumentAccessLog{},
+		&models.NotificationRule{},
+		&models.Notification{},
+		&models.NotificationEscalation{},
+		&models.NotificationDigest{},
+		&models.NotificationDigestItem{},
 		// Add other models here as they are created
-		// &models.Person{},
-		// &models.Equipment{},
-		// &models.Certification{},
 	)
 
 	if err != nil {
@@ -98,3 +113,45 @@ func CreateDefaultAdmin(db *gorm.DB) error {
 	}
 	return nil
 }
+
+// defaultNotificationDays are the reminder schedules seeded on first start:
+// global rules for every certification and a longer schedule for safety.
+var defaultNotificationDays = map[string][]int{
+	"":                                 {30, 15, 7, 1},
+	models.CertificationCategorySafety: {60, 30, 14, 7, 1},
+}
+
+// SeedNotificationRules creates the default notification rules when none
+// exist. Rules are only seeded into an empty table, so admins should
+// deactivate unwanted defaults rather than delete every rule.
+func SeedNotificationRules(db *gorm.DB) error {
+	var count int64
+	if err := db.Model(&models.NotificationRule{}).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check for notification rules: %w", err)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	var rules []models.NotificationRule
+	for _, category := range []string{"", models.CertificationCategorySafety} {
+		for _, days := range defaultNotificationDays[category] {
+			rules = append(rules, models.NotificationRule{
+				Category:             category,
+				DaysBeforeExpiration: days,
+				IsActive:             true,
+				EmailTemplate:        models.DefaultEmailTemplate,
+				RecipientRoles:       models.StringList{models.RecipientHolder},
+			})
+		}
+	}
+
+	if err := db.Create(&rules).Error; err != nil {
+		return fmt.Errorf("failed to seed notification rules: %w", err)
+	}
+
+	if os.Getenv("GO_ENV") != "test" {
+		log.Println("✅ Default notification rules created")
+	}
+	return nil
+}
@@ -11,9 +11,14 @@ import (
 	"certitrack/internal/config"
 	"certitrack/internal/database"
 	"certitrack/internal/handlers"
+	"certitrack/internal/jobs"
+	"certitrack/internal/mailer"
 	"certitrack/internal/middleware"
 	"certitrack/internal/repositories"
+	"certitrack/internal/scanner"
 	"certitrack/internal/services"
+	"certitrack/internal/storage"
+	"certitrack/internal/thumbnail"
 
 	"github.com/google/wire"
 	"gorm.io/gorm"
@@ -23,6 +28,10 @@ func provideRedisConfig(cfg *config.Config) *config.RedisConfig {
 	return &cfg.Redis
 }
 
+func provideMailer(cfg *config.Config) (mailer.Mailer, error) {
+	return mailer.NewSMTPMailer(cfg.SMTP.ToMailerConfig())
+}
+
 var (
 	redisClientSet = wire.NewSet(
 		provideRedisConfig,
@@ -32,31 +41,138 @@ var (
 
 	tokenRepositorySet = wire.NewSet(
 		repositories.NewTokenRepository,
+		repositories.NewLinkNonceRepository,
 	)
 
 	repositorySet = wire.NewSet(
 		repositories.NewUserRepositoryImpl,
+		repositories.NewPersonRepositoryImpl,
+		repositories.NewEquipmentRepositoryImpl,
+		repositories.NewEquipmentClassRepositoryImpl,
+		repositories.NewCertificationTypeRepositoryImpl,
+		repositories.NewCertificationRepositoryImpl,
+		repositories.NewCertificationDocumentRepositoryImpl,
+		repositories.NewAuditLogRepositoryImpl,
+		repositories.NewEncryptionKeyRepositoryImpl,
+		repositories.NewDocumentAccessLogRepositoryImpl,
+		repositories.NewCertificationRequirementRepositoryImpl,
+		repositories.NewNotificationRuleRepositoryImpl,
+		repositories.NewNotificationRepositoryImpl,
+		repositories.NewNotificationDigestRepositoryImpl,
 	)
 
 	serviceSet = wire.NewSet(
 		services.NewAuthService,
 		wire.Bind(new(services.AuthService), new(*services.AuthServiceImpl)),
+		services.NewPersonService,
+		wire.Bind(new(services.PersonService), new(*services.PersonServiceImpl)),
+		services.NewEquipmentService,
+		wire.Bind(new(services.EquipmentService), new(*services.EquipmentServiceImpl)),
+		services.NewEquipmentClassService,
+		wire.Bind(new(services.EquipmentClassService), new(*services.EquipmentClassServiceImpl)),
+		services.NewCertificationTypeService,
+		wire.Bind(new(services.CertificationTypeService), new(*services.CertificationTypeServiceImpl)),
+		services.NewCertificationService,
+		wire.Bind(new(services.CertificationService), new(*services.CertificationServiceImpl)),
+		services.NewDocumentService,
+		wire.Bind(new(services.DocumentService), new(*services.DocumentServiceImpl)),
+		services.NewDocumentLinkService,
+		wire.Bind(new(services.DocumentLinkService), new(*services.DocumentLinkServiceImpl)),
+		services.NewDocumentAccessService,
+		wire.Bind(new(services.DocumentAccessService), new(*services.DocumentAccessServiceImpl)),
+		services.NewCertificationRequirementService,
+		wire.Bind(new(services.CertificationRequirementService), new(*services.CertificationRequirementServiceImpl)),
+		services.NewComplianceService,
+		wire.Bind(new(services.ComplianceService), new(*services.ComplianceServiceImpl)),
+		services.NewOperatorAuthorizationService,
+		wire.Bind(new(services.OperatorAuthorizationService), new(*services.OperatorAuthorizationServiceImpl)),
+		services.NewNotificationRuleService,
+		wire.Bind(new(services.NotificationRuleService), new(*services.NotificationRuleServiceImpl)),
+		services.NewNotificationService,
+		wire.Bind(new(services.NotificationService), new(*services.NotificationServiceImpl)),
+		services.NewNotificationOutboxService,
+		wire.Bind(new(services.NotificationOutboxService), new(*services.NotificationOutboxServiceImpl)),
+		services.NewNotificationDigestService,
+		wire.Bind(new(services.NotificationDigestService), new(*services.NotificationDigestServiceImpl)),
+		services.NewNotificationEscalationService,
+		wire.Bind(new(services.NotificationEscalationService), new(*services.NotificationEscalationServiceImpl)),
 	)
 
 	handlerSet = wire.NewSet(
 		handlers.NewAuthHandler,
+		handlers.NewPeopleHandler,
+		handlers.NewEquipmentHandler,
+		handlers.NewEquipmentClassHandler,
+		handlers.NewCertificationTypeHandler,
+		handlers.NewCertificationHandler,
+		handlers.NewDocumentHandler,
+		handlers.NewDocumentLinkHandler,
+		handlers.NewDocumentAccessHandler,
+		handlers.NewCertificationRequirementHandler,
+		handlers.NewComplianceHandler,
+		handlers.NewOperatorAuthorizationHandler,
+		handlers.NewNotificationRuleHandler,
+		handlers.NewNotificationHandler,
+		handlers.NewNotificationDigestHandler,
+		handlers.NewNotificationEscalationHandler,
+		handlers.NewJobsHandler,
+	)
+
+	storageSet = wire.NewSet(
+		storage.NewKeyring,
+		wire.Bind(new(storage.KeyStore), new(repositories.EncryptionKeyRepository)),
+		storage.NewBlobStore,
+		storage.NewLimits,
+		scanner.NewScanner,
+		thumbnail.NewWorker,
+		wire.Bind(new(services.ThumbnailQueue), new(*thumbnail.Worker)),
+	)
+
+	jobSet = wire.NewSet(
+		jobs.NewExpirySweeper,
+		jobs.NewStorageVerifier,
+		jobs.NewKeyRotator,
+		jobs.NewExpirationChecker,
+		jobs.NewOutboxWorker,
+		jobs.NewDigestBuilder,
 	)
 
 	middlewareSet = wire.NewSet(
 		middleware.NewMiddleware,
 	)
+
+	mailerSet = wire.NewSet(
+		provideMailer,
+	)
 )
 
 type ServerDependencies struct {
-	Config      *config.Config
-	DB          *gorm.DB
-	AuthHandler *handlers.AuthHandler
-	Middleware  *middleware.Middleware
+	Config                          *config.Config
+	DB                              *gorm.DB
+	AuthHandler                     *handlers.AuthHandler
+	PeopleHandler                   *handlers.PeopleHandler
+	EquipmentHandler                *handlers.EquipmentHandler
+	EquipmentClassHandler           *handlers.EquipmentClassHandler
+	CertificationTypeHandler        *handlers.CertificationTypeHandler
+	CertificationHandler            *handlers.CertificationHandler
+	DocumentHandler                 *handlers.DocumentHandler
+	DocumentLinkHandler             *handlers.DocumentLinkHandler
+	DocumentAccessHandler           *handlers.DocumentAccessHandler
+	CertificationRequirementHandler *handlers.CertificationRequirementHandler
+	ComplianceHandler               *handlers.ComplianceHandler
+	OperatorAuthorizationHandler    *handlers.OperatorAuthorizationHandler
+	NotificationRuleHandler         *handlers.NotificationRuleHandler
+	NotificationHandler             *handlers.NotificationHandler
+	NotificationDigestHandler       *handlers.NotificationDigestHandler
+	NotificationEscalationHandler   *handlers.NotificationEscalationHandler
+	JobsHandler                     *handlers.JobsHandler
+	ExpirySweeper                   *jobs.ExpirySweeper
+	StorageVerifier                 *jobs.StorageVerifier
+	ExpirationChecker               *jobs.ExpirationChecker
+	OutboxWorker                    *jobs.OutboxWorker
+	DigestBuilder                   *jobs.DigestBuilder
+	ThumbnailWorker                 *thumbnail.Worker
+	Middleware                      *middleware.Middleware
 }
 
 func InitializeServer() (*ServerDependencies, error) {
@@ -66,7 +182,10 @@ func InitializeServer() (*ServerDependencies, error) {
 		database.Connect,
 		tokenRepositorySet,
 		repositorySet,
+		storageSet,
+		mailerSet,
 		serviceSet,
+		jobSet,
 		handlerSet,
 		middlewareSet,
 
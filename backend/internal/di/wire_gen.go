@@ -11,9 +11,14 @@ import (
 	"certitrack/internal/config"
 	"certitrack/internal/database"
 	"certitrack/internal/handlers"
+	"certitrack/internal/jobs"
+	"certitrack/internal/mailer"
 	"certitrack/internal/middleware"
 	"certitrack/internal/repositories"
+	"certitrack/internal/scanner"
 	"certitrack/internal/services"
+	"certitrack/internal/storage"
+	"certitrack/internal/thumbnail"
 	"github.com/google/wire"
 	"gorm.io/gorm"
 )
@@ -38,12 +43,101 @@ func InitializeServer() (*ServerDependencies, error) {
 	tokenRepository := repositories.NewTokenRepository(client)
 	authServiceImpl := services.NewAuthService(configConfig, userRepository, tokenRepository)
 	authHandler := handlers.NewAuthHandler(authServiceImpl)
+	personRepository := repositories.NewPersonRepositoryImpl(db)
+	personServiceImpl := services.NewPersonService(personRepository)
+	peopleHandler := handlers.NewPeopleHandler(personServiceImpl)
+	equipmentRepository := repositories.NewEquipmentRepositoryImpl(db)
+	equipmentClassRepository := repositories.NewEquipmentClassRepositoryImpl(db)
+	certificationRepository := repositories.NewCertificationRepositoryImpl(db)
+	equipmentServiceImpl := services.NewEquipmentService(equipmentRepository, equipmentClassRepository, certificationRepository)
+	equipmentHandler := handlers.NewEquipmentHandler(equipmentServiceImpl)
+	certificationTypeRepository := repositories.NewCertificationTypeRepositoryImpl(db)
+	equipmentClassServiceImpl := services.NewEquipmentClassService(equipmentClassRepository, certificationTypeRepository)
+	equipmentClassHandler := handlers.NewEquipmentClassHandler(equipmentClassServiceImpl)
+	certificationTypeServiceImpl := services.NewCertificationTypeService(certificationTypeRepository)
+	certificationTypeHandler := handlers.NewCertificationTypeHandler(certificationTypeServiceImpl)
+	certificationServiceImpl := services.NewCertificationService(certificationRepository, certificationTypeRepository, personRepository, equipmentRepository)
+	certificationHandler := handlers.NewCertificationHandler(certificationServiceImpl)
+	certificationDocumentRepository := repositories.NewCertificationDocumentRepositoryImpl(db)
+	encryptionKeyRepository := repositories.NewEncryptionKeyRepositoryImpl(db)
+	keyring, err := storage.NewKeyring(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	blobStore, err := storage.NewBlobStore(configConfig, encryptionKeyRepository, keyring)
+	if err != nil {
+		return nil, err
+	}
+	auditLogRepository := repositories.NewAuditLogRepositoryImpl(db)
+	documentAccessLogRepository := repositories.NewDocumentAccessLogRepositoryImpl(db)
+	limits := storage.NewLimits(configConfig)
+	scannerScanner := scanner.NewScanner(configConfig)
+	worker := thumbnail.NewWorker(blobStore, configConfig)
+	documentServiceImpl := services.NewDocumentService(certificationDocumentRepository, certificationRepository, auditLogRepository, documentAccessLogRepository, blobStore, limits, scannerScanner, worker)
+	documentHandler := handlers.NewDocumentHandler(documentServiceImpl)
+	linkNonceRepository := repositories.NewLinkNonceRepository(client)
+	documentLinkServiceImpl := services.NewDocumentLinkService(certificationDocumentRepository, documentServiceImpl, linkNonceRepository, userRepository, tokenRepository, configConfig)
+	documentLinkHandler := handlers.NewDocumentLinkHandler(documentLinkServiceImpl)
+	documentAccessServiceImpl := services.NewDocumentAccessService(documentAccessLogRepository)
+	documentAccessHandler := handlers.NewDocumentAccessHandler(documentAccessServiceImpl)
+	certificationRequirementRepository := repositories.NewCertificationRequirementRepositoryImpl(db)
+	certificationRequirementServiceImpl := services.NewCertificationRequirementService(certificationRequirementRepository, certificationTypeRepository)
+	certificationRequirementHandler := handlers.NewCertificationRequirementHandler(certificationRequirementServiceImpl)
+	complianceServiceImpl := services.NewComplianceService(certificationRequirementRepository, personRepository, certificationRepository)
+	complianceHandler := handlers.NewComplianceHandler(complianceServiceImpl)
+	operatorAuthorizationServiceImpl := services.NewOperatorAuthorizationService(equipmentRepository, equipmentClassRepository, personRepository, certificationRepository)
+	operatorAuthorizationHandler := handlers.NewOperatorAuthorizationHandler(operatorAuthorizationServiceImpl)
+	notificationRuleRepository := repositories.NewNotificationRuleRepositoryImpl(db)
+	notificationRuleServiceImpl := services.NewNotificationRuleService(notificationRuleRepository, certificationTypeRepository, certificationRepository)
+	notificationRuleHandler := handlers.NewNotificationRuleHandler(notificationRuleServiceImpl)
+	expirySweeper := jobs.NewExpirySweeper(certificationServiceImpl, configConfig)
+	storageVerifier := jobs.NewStorageVerifier(documentServiceImpl, configConfig)
+	keyRotator := jobs.NewKeyRotator(encryptionKeyRepository, keyring)
+	notificationRepository := repositories.NewNotificationRepositoryImpl(db)
+	mailerMailer, err := provideMailer(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	notificationDigestRepository := repositories.NewNotificationDigestRepositoryImpl(db)
+	notificationServiceImpl := services.NewNotificationService(notificationRepository, certificationRepository, certificationTypeRepository, userRepository, notificationRuleServiceImpl)
+	notificationEscalationServiceImpl := services.NewNotificationEscalationService(notificationRepository, personRepository, userRepository, mailerMailer, configConfig)
+	expirationChecker := jobs.NewExpirationChecker(notificationServiceImpl, notificationEscalationServiceImpl, configConfig)
+	notificationDigestServiceImpl := services.NewNotificationDigestService(notificationDigestRepository, userRepository, mailerMailer, configConfig)
+	notificationOutboxServiceImpl := services.NewNotificationOutboxService(notificationRepository, mailerMailer, configConfig)
+	outboxWorker := jobs.NewOutboxWorker(notificationOutboxServiceImpl, notificationDigestServiceImpl, notificationEscalationServiceImpl, configConfig)
+	digestBuilder := jobs.NewDigestBuilder(notificationDigestServiceImpl, configConfig)
+	jobsHandler := handlers.NewJobsHandler(expirySweeper, storageVerifier, keyRotator, expirationChecker, outboxWorker, digestBuilder)
+	notificationHandler := handlers.NewNotificationHandler(notificationOutboxServiceImpl)
+	notificationDigestHandler := handlers.NewNotificationDigestHandler(notificationDigestServiceImpl)
+	notificationEscalationHandler := handlers.NewNotificationEscalationHandler(notificationEscalationServiceImpl)
 	middlewareMiddleware := middleware.NewMiddleware(authServiceImpl)
 	serverDependencies := &ServerDependencies{
-		Config:      configConfig,
-		DB:          db,
-		AuthHandler: authHandler,
-		Middleware:  middlewareMiddleware,
+		Config:                          configConfig,
+		DB:                              db,
+		AuthHandler:                     authHandler,
+		PeopleHandler:                   peopleHandler,
+		EquipmentHandler:                equipmentHandler,
+		EquipmentClassHandler:           equipmentClassHandler,
+		CertificationTypeHandler:        certificationTypeHandler,
+		CertificationHandler:            certificationHandler,
+		DocumentHandler:                 documentHandler,
+		DocumentLinkHandler:             documentLinkHandler,
+		DocumentAccessHandler:           documentAccessHandler,
+		CertificationRequirementHandler: certificationRequirementHandler,
+		ComplianceHandler:               complianceHandler,
+		OperatorAuthorizationHandler:    operatorAuthorizationHandler,
+		NotificationRuleHandler:         notificationRuleHandler,
+		NotificationHandler:             notificationHandler,
+		NotificationDigestHandler:       notificationDigestHandler,
+		NotificationEscalationHandler:   notificationEscalationHandler,
+		JobsHandler:                     jobsHandler,
+		ExpirySweeper:                   expirySweeper,
+		StorageVerifier:                 storageVerifier,
+		ExpirationChecker:               expirationChecker,
+		OutboxWorker:                    outboxWorker,
+		DigestBuilder:                   digestBuilder,
+		ThumbnailWorker:                 worker,
+		Middleware:                      middlewareMiddleware,
 	}
 	return serverDependencies, nil
 }
@@ -54,25 +148,57 @@ func provideRedisConfig(cfg *config.Config) *config.RedisConfig {
 	return &cfg.Redis
 }
 
+func provideMailer(cfg *config.Config) (mailer.Mailer, error) {
+	return mailer.NewSMTPMailer(cfg.SMTP.ToMailerConfig())
+}
+
 var (
 	redisClientSet = wire.NewSet(
 		provideRedisConfig, redis.NewClient, wire.Bind(new(repositories.RedisClient), new(*redis.Client)),
 	)
 
-	tokenRepositorySet = wire.NewSet(repositories.NewTokenRepository)
+	tokenRepositorySet = wire.NewSet(repositories.NewTokenRepository, repositories.NewLinkNonceRepository)
 
-	repositorySet = wire.NewSet(repositories.NewUserRepositoryImpl)
+	repositorySet = wire.NewSet(repositories.NewUserRepositoryImpl, repositories.NewPersonRepositoryImpl, repositories.NewEquipmentRepositoryImpl, repositories.NewEquipmentClassRepositoryImpl, repositories.NewCertificationTypeRepositoryImpl, repositories.NewCertificationRepositoryImpl, repositories.NewCertificationDocumentRepositoryImpl, repositories.NewAuditLogRepositoryImpl, repositories.NewEncryptionKeyRepositoryImpl, repositories.NewDocumentAccessLogRepositoryImpl, repositories.NewCertificationRequirementRepositoryImpl, repositories.NewNotificationRuleRepositoryImpl, repositories.NewNotificationRepositoryImpl, repositories.NewNotificationDigestRepositoryImpl)
 
-	serviceSet = wire.NewSet(services.NewAuthService, wire.Bind(new(services.AuthService), new(*services.AuthServiceImpl)))
+	serviceSet = wire.NewSet(services.NewAuthService, wire.Bind(new(services.AuthService), new(*services.AuthServiceImpl)), services.NewPersonService, wire.Bind(new(services.PersonService), new(*services.PersonServiceImpl)), services.NewEquipmentService, wire.Bind(new(services.EquipmentService), new(*services.EquipmentServiceImpl)), services.NewEquipmentClassService, wire.Bind(new(services.EquipmentClassService), new(*services.EquipmentClassServiceImpl)), services.NewCertificationTypeService, wire.Bind(new(services.CertificationTypeService), new(*services.CertificationTypeServiceImpl)), services.NewCertificationService, wire.Bind(new(services.CertificationService), new(*services.CertificationServiceImpl)), services.NewDocumentService, wire.Bind(new(services.DocumentService), new(*services.DocumentServiceImpl)), services.NewDocumentLinkService, wire.Bind(new(services.DocumentLinkService), new(*services.DocumentLinkServiceImpl)), services.NewDocumentAccessService, wire.Bind(new(services.DocumentAccessService), new(*services.DocumentAccessServiceImpl)), services.NewCertificationRequirementService, wire.Bind(new(services.CertificationRequirementService), new(*services.CertificationRequirementServiceImpl)), services.NewComplianceService, wire.Bind(new(services.ComplianceService), new(*services.ComplianceServiceImpl)), services.NewOperatorAuthorizationService, wire.Bind(new(services.OperatorAuthorizationService), new(*services.OperatorAuthorizationServiceImpl)), services.NewNotificationRuleService, wire.Bind(new(services.NotificationRuleService), new(*services.NotificationRuleServiceImpl)), services.NewNotificationService, wire.Bind(new(services.NotificationService), new(*services.NotificationServiceImpl)), services.NewNotificationOutboxService, wire.Bind(new(services.NotificationOutboxService), new(*services.NotificationOutboxServiceImpl)), services.NewNotificationDigestService, wire.Bind(new(services.NotificationDigestService), new(*services.NotificationDigestServiceImpl)), services.NewNotificationEscalationService, wire.Bind(new(services.NotificationEscalationService), new(*services.NotificationEscalationServiceImpl)))
 
-	handlerSet = wire.NewSet(handlers.NewAuthHandler)
+	handlerSet = wire.NewSet(handlers.NewAuthHandler, handlers.NewPeopleHandler, handlers.NewEquipmentHandler, handlers.NewEquipmentClassHandler, handlers.NewCertificationTypeHandler, handlers.NewCertificationHandler, handlers.NewDocumentHandler, handlers.NewDocumentLinkHandler, handlers.NewDocumentAccessHandler, handlers.NewCertificationRequirementHandler, handlers.NewComplianceHandler, handlers.NewOperatorAuthorizationHandler, handlers.NewNotificationRuleHandler, handlers.NewNotificationHandler, handlers.NewNotificationDigestHandler, handlers.NewNotificationEscalationHandler, handlers.NewJobsHandler)
+
+	storageSet = wire.NewSet(storage.NewKeyring, wire.Bind(new(storage.KeyStore), new(repositories.EncryptionKeyRepository)), storage.NewBlobStore, storage.NewLimits, scanner.NewScanner, thumbnail.NewWorker, wire.Bind(new(services.ThumbnailQueue), new(*thumbnail.Worker)))
+
+	jobSet = wire.NewSet(jobs.NewExpirySweeper, jobs.NewStorageVerifier, jobs.NewKeyRotator, jobs.NewExpirationChecker, jobs.NewOutboxWorker, jobs.NewDigestBuilder)
 
 	middlewareSet = wire.NewSet(middleware.NewMiddleware)
+
+	mailerSet = wire.NewSet(provideMailer)
 )
 
 type ServerDependencies struct {
-	Config      *config.Config
-	DB          *gorm.DB
-	AuthHandler *handlers.AuthHandler
-	Middleware  *middleware.Middleware
+	Config                          *config.Config
+	DB                              *gorm.DB
+	AuthHandler                     *handlers.AuthHandler
+	PeopleHandler                   *handlers.PeopleHandler
+	EquipmentHandler                *handlers.EquipmentHandler
+	EquipmentClassHandler           *handlers.EquipmentClassHandler
+	CertificationTypeHandler        *handlers.CertificationTypeHandler
+	CertificationHandler            *handlers.CertificationHandler
+	DocumentHandler                 *handlers.DocumentHandler
+	DocumentLinkHandler             *handlers.DocumentLinkHandler
+	DocumentAccessHandler           *handlers.DocumentAccessHandler
+	CertificationRequirementHandler *handlers.CertificationRequirementHandler
+	ComplianceHandler               *handlers.ComplianceHandler
+	OperatorAuthorizationHandler    *handlers.OperatorAuthorizationHandler
+	NotificationRuleHandler         *handlers.NotificationRuleHandler
+	NotificationHandler             *handlers.NotificationHandler
+	NotificationDigestHandler       *handlers.NotificationDigestHandler
+	NotificationEscalationHandler   *handlers.NotificationEscalationHandler
+	JobsHandler                     *handlers.JobsHandler
+	ExpirySweeper                   *jobs.ExpirySweeper
+	StorageVerifier                 *jobs.StorageVerifier
+	ExpirationChecker               *jobs.ExpirationChecker
+	OutboxWorker                    *jobs.OutboxWorker
+	DigestBuilder                   *jobs.DigestBuilder
+	ThumbnailWorker                 *thumbnail.Worker
+	Middleware                      *middleware.Middleware
 }
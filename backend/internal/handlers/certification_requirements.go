@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"net/http"
+
+	"certitrack/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CertificationRequirementHandler struct {
+	requirementService services.CertificationRequirementService
+}
+
+func NewCertificationRequirementHandler(requirementService services.CertificationRequirementService) *CertificationRequirementHandler {
+	return &CertificationRequirementHandler{
+		requirementService: requirementService,
+	}
+}
+
+func (h *CertificationRequirementHandler) List(c *gin.Context) {
+	var req services.ListCertificationRequirementsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	response, err := h.requirementService.ListRequirements(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list certification requirements",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Certification requirements retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *CertificationRequirementHandler) Create(c *gin.Context) {
+	var req services.CreateCertificationRequirementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	requirement, err := h.requirementService.CreateRequirement(&req, currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Failed to create certification requirement")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Certification requirement created successfully",
+		"data":    requirement,
+	})
+}
+
+func (h *CertificationRequirementHandler) Delete(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.requirementService.DeleteRequirement(id); err != nil {
+		h.handleError(c, err, "Failed to delete certification requirement")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Certification requirement deleted successfully",
+	})
+}
+
+func (h *CertificationRequirementHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch err {
+	case services.ErrRequirementNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Certification requirement not found",
+		})
+	case services.ErrRequirementExists:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Certification requirement already exists",
+		})
+	case services.ErrInvalidRequirementScope:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "A position or a department is required",
+		})
+	case services.ErrCertificationTypeNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Certification type not found",
+		})
+	case services.ErrInactiveCertificationType:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Certification type is inactive",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"net/http"
+
+	"certitrack/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CertificationTypeHandler struct {
+	certTypeService services.CertificationTypeService
+}
+
+func NewCertificationTypeHandler(certTypeService services.CertificationTypeService) *CertificationTypeHandler {
+	return &CertificationTypeHandler{
+		certTypeService: certTypeService,
+	}
+}
+
+func (h *CertificationTypeHandler) List(c *gin.Context) {
+	var req services.ListCertificationTypesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	response, err := h.certTypeService.ListTypes(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list certification types",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Certification types retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *CertificationTypeHandler) Create(c *gin.Context) {
+	var req services.CreateCertificationTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	certType, err := h.certTypeService.CreateType(&req, currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Failed to create certification type")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Certification type created successfully",
+		"data":    certType,
+	})
+}
+
+func (h *CertificationTypeHandler) Get(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	certType, err := h.certTypeService.GetType(id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get certification type")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Certification type retrieved successfully",
+		"data":    certType,
+	})
+}
+
+func (h *CertificationTypeHandler) Update(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req services.UpdateCertificationTypeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	certType, err := h.certTypeService.UpdateType(id, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update certification type")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Certification type updated successfully",
+		"data":    certType,
+	})
+}
+
+func (h *CertificationTypeHandler) Delete(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.certTypeService.DeleteType(id); err != nil {
+		h.handleError(c, err, "Failed to delete certification type")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Certification type deleted successfully",
+	})
+}
+
+func (h *CertificationTypeHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch err {
+	case services.ErrCertificationTypeNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Certification type not found",
+		})
+	case services.ErrCertificationTypeInUse:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Certification type is in use; deactivate it instead",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fallback,
+		})
+	}
+}
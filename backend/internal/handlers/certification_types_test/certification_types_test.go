@@ -0,0 +1,108 @@
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"certitrack/internal/handlers"
+	"certitrack/internal/middleware"
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+	"certitrack/testutils/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const certTypesPath = "/api/v1/certification-types"
+
+// setupCertTypeRouter mirrors the production layout: reads for every
+// authenticated user, writes behind AdminMiddleware.
+func setupCertTypeRouter(t *testing.T, role string) (*gin.Engine, *mocks.MockCertificationTypeService) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	svc := new(mocks.MockCertificationTypeService)
+	handler := handlers.NewCertificationTypeHandler(svc)
+	mw := middleware.NewMiddleware(new(mocks.MockAuthService))
+
+	r := gin.New()
+	protected := r.Group("/api/v1")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user", &models.User{ID: uuid.New(), Role: role})
+		c.Set("userRole", role)
+		c.Next()
+	})
+	{
+		protected.GET("/certification-types", handler.List)
+		protected.GET("/certification-types/:id", handler.Get)
+
+		admin := protected.Group("")
+		admin.Use(mw.AdminMiddleware())
+		{
+			admin.POST("/certification-types", handler.Create)
+			admin.PUT("/certification-types/:id", handler.Update)
+			admin.DELETE("/certification-types/:id", handler.Delete)
+		}
+	}
+
+	t.Cleanup(func() { svc.AssertExpectations(t) })
+	return r, svc
+}
+
+func performRequest(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestCertificationTypeHandler_Create_RequiresAdmin(t *testing.T) {
+	r, _ := setupCertTypeRouter(t, "user")
+
+	w := performRequest(r, http.MethodPost, certTypesPath, `{"name":"First Aid","category":"safety"}`)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestCertificationTypeHandler_Create_AsAdmin(t *testing.T) {
+	r, svc := setupCertTypeRouter(t, "admin")
+	svc.On("CreateType", mock.AnythingOfType("*services.CreateCertificationTypeRequest"), mock.Anything).
+		Return(&models.CertificationType{ID: uuid.New(), Name: "First Aid"}, nil)
+
+	w := performRequest(r, http.MethodPost, certTypesPath, `{"name":"First Aid","category":"safety","default_validity_period":730}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestCertificationTypeHandler_Create_InvalidCategory(t *testing.T) {
+	r, _ := setupCertTypeRouter(t, "admin")
+
+	w := performRequest(r, http.MethodPost, certTypesPath, `{"name":"First Aid","category":"misc"}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCertificationTypeHandler_List_AllowedForUsers(t *testing.T) {
+	r, svc := setupCertTypeRouter(t, "user")
+	svc.On("ListTypes", mock.Anything).Return(&services.CertificationTypeListResponse{}, nil)
+
+	w := performRequest(r, http.MethodGet, certTypesPath, "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestCertificationTypeHandler_Delete_InUse(t *testing.T) {
+	r, svc := setupCertTypeRouter(t, "admin")
+	id := uuid.New()
+	svc.On("DeleteType", id).Return(services.ErrCertificationTypeInUse)
+
+	w := performRequest(r, http.MethodDelete, certTypesPath+"/"+id.String(), "")
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
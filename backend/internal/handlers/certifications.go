@@ -0,0 +1,323 @@
+package handlers
+
+import (
+	"net/http"
+
+	"certitrack/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CertificationHandler struct {
+	certService services.CertificationService
+}
+
+func NewCertificationHandler(certService services.CertificationService) *CertificationHandler {
+	return &CertificationHandler{
+		certService: certService,
+	}
+}
+
+func (h *CertificationHandler) List(c *gin.Context) {
+	var req services.ListCertificationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	response, err := h.certService.ListCertifications(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list certifications",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Certifications retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *CertificationHandler) ListForPerson(c *gin.Context) {
+	personID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req services.ListCertificationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	response, err := h.certService.ListPersonCertifications(personID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to list certifications")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Certifications retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *CertificationHandler) ListForEquipment(c *gin.Context) {
+	equipmentID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req services.ListCertificationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	response, err := h.certService.ListEquipmentCertifications(equipmentID, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to list certifications")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Certifications retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *CertificationHandler) Create(c *gin.Context) {
+	var req services.CreateCertificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	cert, err := h.certService.CreateCertification(&req, currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Failed to create certification")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Certification created successfully",
+		"data":    cert,
+	})
+}
+
+func (h *CertificationHandler) Get(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	cert, err := h.certService.GetCertification(id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get certification")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Certification retrieved successfully",
+		"data":    cert,
+	})
+}
+
+func (h *CertificationHandler) Update(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req services.UpdateCertificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	cert, err := h.certService.UpdateCertification(id, &req, currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Failed to update certification")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Certification updated successfully",
+		"data":    cert,
+	})
+}
+
+func (h *CertificationHandler) Delete(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.certService.DeleteCertification(id); err != nil {
+		h.handleError(c, err, "Failed to delete certification")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Certification deleted successfully",
+	})
+}
+
+func (h *CertificationHandler) Revoke(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req services.RevokeCertificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	cert, err := h.certService.RevokeCertification(id, &req, currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Failed to revoke certification")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Certification revoked successfully",
+		"data":    cert,
+	})
+}
+
+func (h *CertificationHandler) Activate(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	// The body is optional for activation.
+	var req services.ActivateCertificationRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			invalidRequest(c, err)
+			return
+		}
+	}
+
+	cert, err := h.certService.ActivateCertification(id, &req, currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Failed to activate certification")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Certification activated successfully",
+		"data":    cert,
+	})
+}
+
+func (h *CertificationHandler) StatusHistory(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	history, err := h.certService.GetStatusHistory(id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get certification status history")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Certification status history retrieved successfully",
+		"data":    history,
+	})
+}
+
+func (h *CertificationHandler) Renew(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req services.RenewCertificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	cert, err := h.certService.RenewCertification(id, &req, currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Failed to renew certification")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Certification renewed successfully",
+		"data":    cert,
+	})
+}
+
+func (h *CertificationHandler) RenewalHistory(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	chain, err := h.certService.GetRenewalHistory(id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get certification history")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Certification history retrieved successfully",
+		"data":    chain,
+	})
+}
+
+func (h *CertificationHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch err {
+	case services.ErrCertificationNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Certification not found",
+		})
+	case services.ErrPersonNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Person not found",
+		})
+	case services.ErrEquipmentNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Equipment not found",
+		})
+	case services.ErrCertificationTypeNotFound:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Certification type not found",
+		})
+	case services.ErrInactiveCertificationType:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Certification type is inactive",
+		})
+	case services.ErrInvalidCertificationTarget,
+		services.ErrInvalidCertificationDates,
+		services.ErrExpirationDateRequired:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	case services.ErrInvalidStatusTransition:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Status transition is not allowed from the current status",
+		})
+	case services.ErrCertificationAlreadyRenewed:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Certification has already been renewed",
+		})
+	case services.ErrCertificationExpired:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Certification has expired; update its dates or renew it first",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"certitrack/internal/handlers"
+	"certitrack/internal/middleware"
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+	"certitrack/testutils/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const certificationsPath = "/api/v1/certifications"
+
+func setupCertificationRouter(t *testing.T, role string) (*gin.Engine, *mocks.MockCertificationService) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	svc := new(mocks.MockCertificationService)
+	handler := handlers.NewCertificationHandler(svc)
+	mw := middleware.NewMiddleware(new(mocks.MockAuthService))
+
+	r := gin.New()
+	protected := r.Group("/api/v1")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user", &models.User{ID: uuid.New(), Role: role})
+		c.Set("userRole", role)
+		c.Next()
+	})
+	{
+		protected.GET("/certifications", handler.List)
+		protected.POST("/certifications", handler.Create)
+		protected.GET("/certifications/:id", handler.Get)
+		protected.PUT("/certifications/:id", handler.Update)
+		protected.DELETE("/certifications/:id", mw.AdminMiddleware(), handler.Delete)
+		protected.POST("/certifications/:id/revoke", handler.Revoke)
+		protected.POST("/certifications/:id/activate", handler.Activate)
+		protected.GET("/certifications/:id/status-history", handler.StatusHistory)
+		protected.POST("/certifications/:id/renew", handler.Renew)
+		protected.GET("/certifications/:id/history", handler.RenewalHistory)
+		protected.GET("/people/:id/certifications", handler.ListForPerson)
+		protected.GET("/equipment/:id/certifications", handler.ListForEquipment)
+	}
+
+	t.Cleanup(func() { svc.AssertExpectations(t) })
+	return r, svc
+}
+
+func performRequest(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestCertificationHandler_Create_Success(t *testing.T) {
+	r, svc := setupCertificationRouter(t, "user")
+	svc.On("CreateCertification", mock.AnythingOfType("*services.CreateCertificationRequest"), mock.Anything).
+		Return(&models.Certification{ID: uuid.New()}, nil)
+
+	body := `{"certification_type_id":"` + uuid.NewString() + `","person_id":"` + uuid.NewString() + `","issue_date":"2024-01-01"}`
+	w := performRequest(r, http.MethodPost, certificationsPath, body)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestCertificationHandler_Create_InvalidTarget(t *testing.T) {
+	r, svc := setupCertificationRouter(t, "user")
+	svc.On("CreateCertification", mock.Anything, mock.Anything).
+		Return(nil, services.ErrInvalidCertificationTarget)
+
+	body := `{"certification_type_id":"` + uuid.NewString() + `","issue_date":"2024-01-01"}`
+	w := performRequest(r, http.MethodPost, certificationsPath, body)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "exactly one person")
+}
+
+func TestCertificationHandler_Create_InvalidDateFormat(t *testing.T) {
+	r, _ := setupCertificationRouter(t, "user")
+
+	body := `{"certification_type_id":"` + uuid.NewString() + `","issue_date":"01/01/2024"}`
+	w := performRequest(r, http.MethodPost, certificationsPath, body)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCertificationHandler_Get_NotFound(t *testing.T) {
+	r, svc := setupCertificationRouter(t, "user")
+	id := uuid.New()
+	svc.On("GetCertification", id).Return(nil, services.ErrCertificationNotFound)
+
+	w := performRequest(r, http.MethodGet, certificationsPath+"/"+id.String(), "")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCertificationHandler_Delete_RequiresAdmin(t *testing.T) {
+	r, _ := setupCertificationRouter(t, "user")
+
+	w := performRequest(r, http.MethodDelete, certificationsPath+"/"+uuid.NewString(), "")
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestCertificationHandler_List_ByIDs(t *testing.T) {
+	r, svc := setupCertificationRouter(t, "user")
+	personID, typeID := uuid.New(), uuid.New()
+	svc.On("ListCertifications", &services.ListCertificationsRequest{
+		PersonID:            personID.String(),
+		CertificationTypeID: typeID.String(),
+	}).Return(&services.CertificationListResponse{}, nil)
+
+	w := performRequest(r, http.MethodGet, certificationsPath+"?personId="+personID.String()+"&certificationTypeId="+typeID.String(), "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = performRequest(r, http.MethodGet, certificationsPath+"?equipmentId=crane-7", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCertificationHandler_ListForPerson(t *testing.T) {
+	r, svc := setupCertificationRouter(t, "user")
+	personID := uuid.New()
+	svc.On("ListPersonCertifications", personID, mock.Anything).
+		Return(&services.CertificationListResponse{}, nil)
+
+	w := performRequest(r, http.MethodGet, "/api/v1/people/"+personID.String()+"/certifications", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestCertificationHandler_ListForEquipment_NotFound(t *testing.T) {
+	r, svc := setupCertificationRouter(t, "user")
+	equipmentID := uuid.New()
+	svc.On("ListEquipmentCertifications", equipmentID, mock.Anything).
+		Return(nil, services.ErrEquipmentNotFound)
+
+	w := performRequest(r, http.MethodGet, "/api/v1/equipment/"+equipmentID.String()+"/certifications", "")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCertificationHandler_Revoke_RequiresReason(t *testing.T) {
+	r, _ := setupCertificationRouter(t, "user")
+
+	w := performRequest(r, http.MethodPost, certificationsPath+"/"+uuid.NewString()+"/revoke", `{}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCertificationHandler_Revoke_IllegalTransition(t *testing.T) {
+	r, svc := setupCertificationRouter(t, "user")
+	id := uuid.New()
+	svc.On("RevokeCertification", id, &services.RevokeCertificationRequest{Reason: "Lost"}, mock.Anything).
+		Return(nil, services.ErrInvalidStatusTransition)
+
+	w := performRequest(r, http.MethodPost, certificationsPath+"/"+id.String()+"/revoke", `{"reason":"Lost"}`)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestCertificationHandler_Activate_WithoutBody(t *testing.T) {
+	r, svc := setupCertificationRouter(t, "user")
+	id := uuid.New()
+	svc.On("ActivateCertification", id, &services.ActivateCertificationRequest{}, mock.Anything).
+		Return(&models.Certification{ID: id, Status: models.CertificationStatusActive}, nil)
+
+	w := performRequest(r, http.MethodPost, certificationsPath+"/"+id.String()+"/activate", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestCertificationHandler_Renew_Created(t *testing.T) {
+	r, svc := setupCertificationRouter(t, "user")
+	id := uuid.New()
+	svc.On("RenewCertification", id, mock.AnythingOfType("*services.RenewCertificationRequest"), mock.Anything).
+		Return(&models.Certification{ID: uuid.New(), PredecessorID: &id}, nil)
+
+	w := performRequest(r, http.MethodPost, certificationsPath+"/"+id.String()+"/renew", `{"certificate_number":"FL-2","issue_date":"2025-01-01"}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestCertificationHandler_Renew_RequiresCertificateNumber(t *testing.T) {
+	r, _ := setupCertificationRouter(t, "user")
+
+	w := performRequest(r, http.MethodPost, certificationsPath+"/"+uuid.NewString()+"/renew", `{"issue_date":"2025-01-01"}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCertificationHandler_RenewalHistory(t *testing.T) {
+	r, svc := setupCertificationRouter(t, "user")
+	id := uuid.New()
+	svc.On("GetRenewalHistory", id).Return([]models.Certification{{ID: id}}, nil)
+
+	w := performRequest(r, http.MethodGet, certificationsPath+"/"+id.String()+"/history", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
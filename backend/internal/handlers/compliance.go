@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+
+	"certitrack/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ComplianceHandler struct {
+	complianceService services.ComplianceService
+}
+
+func NewComplianceHandler(complianceService services.ComplianceService) *ComplianceHandler {
+	return &ComplianceHandler{
+		complianceService: complianceService,
+	}
+}
+
+// ForPerson reports which required certifications a person holds, is missing
+// or has let expire.
+func (h *ComplianceHandler) ForPerson(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	compliance, err := h.complianceService.GetPersonCompliance(id)
+	if err != nil {
+		switch err {
+		case services.ErrPersonNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Person not found",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to get compliance",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Compliance retrieved successfully",
+		"data":    compliance,
+	})
+}
+
+// Gaps lists missing and expired required certifications across people.
+func (h *ComplianceHandler) Gaps(c *gin.Context) {
+	var req services.ListComplianceGapsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	response, err := h.complianceService.ListGaps(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list compliance gaps",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Compliance gaps retrieved successfully",
+		"data":    response,
+	})
+}
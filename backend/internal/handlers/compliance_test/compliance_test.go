@@ -0,0 +1,171 @@
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"certitrack/internal/handlers"
+	"certitrack/internal/middleware"
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+	"certitrack/testutils/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// setupComplianceRouter mirrors the real routing: reads need an authenticated
+// user, managing requirements needs an admin.
+func setupComplianceRouter(t *testing.T, role string) (*gin.Engine, *mocks.MockCertificationRequirementService, *mocks.MockComplianceService) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	requirementSvc := new(mocks.MockCertificationRequirementService)
+	complianceSvc := new(mocks.MockComplianceService)
+	requirements := handlers.NewCertificationRequirementHandler(requirementSvc)
+	compliance := handlers.NewComplianceHandler(complianceSvc)
+	mw := middleware.NewMiddleware(new(mocks.MockAuthService))
+
+	r := gin.New()
+	protected := r.Group("/api/v1")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user", &models.User{ID: uuid.New(), Role: role})
+		c.Set("userRole", role)
+		c.Next()
+	})
+	{
+		protected.GET("/people/:id/compliance", compliance.ForPerson)
+		protected.GET("/compliance/gaps", compliance.Gaps)
+		protected.GET("/certification-requirements", requirements.List)
+		protected.POST("/certification-requirements", mw.AdminMiddleware(), requirements.Create)
+		protected.DELETE("/certification-requirements/:id", mw.AdminMiddleware(), requirements.Delete)
+	}
+
+	t.Cleanup(func() {
+		requirementSvc.AssertExpectations(t)
+		complianceSvc.AssertExpectations(t)
+	})
+	return r, requirementSvc, complianceSvc
+}
+
+func performRequest(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestComplianceHandler_ForPerson(t *testing.T) {
+	r, _, svc := setupComplianceRouter(t, "user")
+	personID := uuid.New()
+	svc.On("GetPersonCompliance", personID).Return(&services.PersonCompliance{
+		Person: &models.Person{ID: personID},
+		Requirements: []services.RequirementCompliance{{
+			CertificationType: &models.CertificationType{Name: "First Aid"},
+			Status:            services.ComplianceStatusMissing,
+		}},
+	}, nil)
+
+	w := performRequest(r, http.MethodGet, "/api/v1/people/"+personID.String()+"/compliance", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"missing"`)
+}
+
+func TestComplianceHandler_ForPerson_NotFound(t *testing.T) {
+	r, _, svc := setupComplianceRouter(t, "user")
+	personID := uuid.New()
+	svc.On("GetPersonCompliance", personID).Return(nil, services.ErrPersonNotFound)
+
+	w := performRequest(r, http.MethodGet, "/api/v1/people/"+personID.String()+"/compliance", "")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestComplianceHandler_Gaps_ByType(t *testing.T) {
+	r, _, svc := setupComplianceRouter(t, "user")
+	typeID := uuid.New()
+	svc.On("ListGaps", &services.ListComplianceGapsRequest{CertificationTypeID: typeID.String()}).
+		Return(&services.ComplianceGapListResponse{Items: []services.ComplianceGap{}}, nil)
+
+	w := performRequest(r, http.MethodGet, "/api/v1/compliance/gaps?certificationTypeId="+typeID.String(), "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestComplianceHandler_Gaps(t *testing.T) {
+	r, _, svc := setupComplianceRouter(t, "user")
+	svc.On("ListGaps", &services.ListComplianceGapsRequest{Department: "Maintenance", Status: "expired"}).
+		Return(&services.ComplianceGapListResponse{Items: []services.ComplianceGap{}}, nil)
+
+	w := performRequest(r, http.MethodGet, "/api/v1/compliance/gaps?department=Maintenance&status=expired", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = performRequest(r, http.MethodGet, "/api/v1/compliance/gaps?status=compliant", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = performRequest(r, http.MethodGet, "/api/v1/compliance/gaps?certificationTypeId=first-aid", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCertificationRequirementHandler_Create(t *testing.T) {
+	r, svc, _ := setupComplianceRouter(t, "admin")
+	typeID := uuid.New()
+	svc.On("CreateRequirement", &services.CreateCertificationRequirementRequest{CertificationTypeID: typeID, Position: "Electrician"}, mock.Anything).
+		Return(&models.CertificationRequirement{ID: uuid.New(), CertificationTypeID: typeID, Position: "Electrician"}, nil)
+
+	w := performRequest(r, http.MethodPost, "/api/v1/certification-requirements",
+		`{"certification_type_id":"`+typeID.String()+`","position":"Electrician"}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestCertificationRequirementHandler_CreateErrors(t *testing.T) {
+	for err, status := range map[error]int{
+		services.ErrRequirementExists:         http.StatusConflict,
+		services.ErrInvalidRequirementScope:   http.StatusBadRequest,
+		services.ErrCertificationTypeNotFound: http.StatusNotFound,
+		services.ErrInactiveCertificationType: http.StatusBadRequest,
+	} {
+		r, svc, _ := setupComplianceRouter(t, "admin")
+		svc.On("CreateRequirement", mock.Anything, mock.Anything).Return(nil, err)
+
+		w := performRequest(r, http.MethodPost, "/api/v1/certification-requirements",
+			`{"certification_type_id":"`+uuid.NewString()+`","department":"Warehouse"}`)
+
+		assert.Equal(t, status, w.Code, err.Error())
+	}
+}
+
+func TestCertificationRequirementHandler_AdminOnly(t *testing.T) {
+	r, svc, _ := setupComplianceRouter(t, "user")
+	svc.On("ListRequirements", mock.Anything).
+		Return(&services.CertificationRequirementListResponse{Items: []models.CertificationRequirement{}}, nil)
+
+	w := performRequest(r, http.MethodGet, "/api/v1/certification-requirements", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = performRequest(r, http.MethodPost, "/api/v1/certification-requirements", `{}`)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = performRequest(r, http.MethodDelete, "/api/v1/certification-requirements/"+uuid.NewString(), "")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestCertificationRequirementHandler_Delete(t *testing.T) {
+	r, svc, _ := setupComplianceRouter(t, "admin")
+	id := uuid.New()
+	svc.On("DeleteRequirement", id).Return(nil).Once()
+	svc.On("DeleteRequirement", id).Return(services.ErrRequirementNotFound).Once()
+
+	w := performRequest(r, http.MethodDelete, "/api/v1/certification-requirements/"+id.String(), "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = performRequest(r, http.MethodDelete, "/api/v1/certification-requirements/"+id.String(), "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
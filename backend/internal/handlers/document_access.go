@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"net/http"
+
+	"certitrack/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DocumentAccessHandler struct {
+	accessService services.DocumentAccessService
+}
+
+func NewDocumentAccessHandler(accessService services.DocumentAccessService) *DocumentAccessHandler {
+	return &DocumentAccessHandler{
+		accessService: accessService,
+	}
+}
+
+// ListForDocument returns who read a document, most recent first.
+func (h *DocumentAccessHandler) ListForDocument(c *gin.Context) {
+	docID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req services.PageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	response, err := h.accessService.ListDocumentAccess(docID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list document access",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Document access retrieved successfully",
+		"data":    response,
+	})
+}
+
+// ListForUser returns the documents a user read, most recent first.
+func (h *DocumentAccessHandler) ListForUser(c *gin.Context) {
+	userID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req services.PageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	response, err := h.accessService.ListUserAccess(userID, &req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list document access",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Document access retrieved successfully",
+		"data":    response,
+	})
+}
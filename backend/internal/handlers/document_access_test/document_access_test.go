@@ -0,0 +1,95 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"certitrack/internal/handlers"
+	"certitrack/internal/middleware"
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+	"certitrack/testutils/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupDocumentAccessRouter mirrors the real routing: the access log is
+// admin-only.
+func setupDocumentAccessRouter(t *testing.T, role string) (*gin.Engine, *mocks.MockDocumentAccessService) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	svc := new(mocks.MockDocumentAccessService)
+	handler := handlers.NewDocumentAccessHandler(svc)
+	mw := middleware.NewMiddleware(new(mocks.MockAuthService))
+
+	r := gin.New()
+	protected := r.Group("/api/v1")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user", &models.User{ID: uuid.New(), Role: role})
+		c.Set("userRole", role)
+		c.Next()
+	})
+	protected.GET("/documents/:id/access-log", mw.AdminMiddleware(), handler.ListForDocument)
+	protected.GET("/users/:id/document-access", mw.AdminMiddleware(), handler.ListForUser)
+
+	t.Cleanup(func() { svc.AssertExpectations(t) })
+	return r, svc
+}
+
+func get(r *gin.Engine, path string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestDocumentAccessHandler_ListForDocument(t *testing.T) {
+	r, svc := setupDocumentAccessRouter(t, "admin")
+	docID := uuid.New()
+	svc.On("ListDocumentAccess", docID, &services.PageRequest{Page: 2, Limit: 10}).
+		Return(&services.DocumentAccessListResponse{
+			Items:      []models.DocumentAccessLog{{DocumentID: docID, Via: models.DocumentAccessViaLink}},
+			Pagination: services.Pagination{Page: 2, Limit: 10, Total: 11, TotalPages: 2},
+		}, nil)
+
+	w := get(r, "/api/v1/documents/"+docID.String()+"/access-log?page=2&limit=10")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"LINK"`)
+	assert.Contains(t, w.Body.String(), `"total":11`)
+}
+
+func TestDocumentAccessHandler_ListForUser(t *testing.T) {
+	r, svc := setupDocumentAccessRouter(t, "admin")
+	userID := uuid.New()
+	svc.On("ListUserAccess", userID, &services.PageRequest{}).
+		Return(&services.DocumentAccessListResponse{Items: []models.DocumentAccessLog{}}, nil)
+
+	w := get(r, "/api/v1/users/"+userID.String()+"/document-access")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestDocumentAccessHandler_AdminOnly(t *testing.T) {
+	r, _ := setupDocumentAccessRouter(t, "user")
+
+	w := get(r, "/api/v1/documents/"+uuid.NewString()+"/access-log")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = get(r, "/api/v1/users/"+uuid.NewString()+"/document-access")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestDocumentAccessHandler_InvalidInput(t *testing.T) {
+	r, _ := setupDocumentAccessRouter(t, "admin")
+
+	w := get(r, "/api/v1/documents/not-a-uuid/access-log")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = get(r, "/api/v1/users/"+uuid.NewString()+"/document-access?limit=1000")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"mime"
+	"net/http"
+
+	"certitrack/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DocumentLinkHandler struct {
+	linkService services.DocumentLinkService
+}
+
+func NewDocumentLinkHandler(linkService services.DocumentLinkService) *DocumentLinkHandler {
+	return &DocumentLinkHandler{
+		linkService: linkService,
+	}
+}
+
+func (h *DocumentLinkHandler) Create(c *gin.Context) {
+	docID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	// The body is optional; the defaults give a multi-use link.
+	var req services.CreateDocumentLinkRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			invalidRequest(c, err)
+			return
+		}
+	}
+
+	link, err := h.linkService.CreateLink(docID, &req, currentUserID(c), currentSessionID(c))
+	if err != nil {
+		h.handleError(c, err, "Failed to create document link")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Document link created successfully",
+		"data":    link,
+	})
+}
+
+// Content serves a document through a signed link. It is registered outside
+// the authenticated routes; the signature is the credential. The content is
+// served inline so links work in <img> and <iframe> tags.
+func (h *DocumentLinkHandler) Content(c *gin.Context) {
+	docID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	doc, content, err := h.linkService.OpenLink(docID, c.Request.URL.Query(), &services.AccessInfo{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+	if err != nil {
+		h.handleError(c, err, "Failed to download document")
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, doc.FileSize, doc.MimeType, content, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("inline", map[string]string{"filename": doc.FileName}),
+		"Cache-Control":          "private, no-store",
+		"Referrer-Policy":        "no-referrer",
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+func (h *DocumentLinkHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch err {
+	case services.ErrDocumentNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Document not found",
+		})
+	case services.ErrDocumentLinkTTLTooLong:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Requested link lifetime exceeds the maximum allowed",
+		})
+	case services.ErrDocumentLinkInvalid:
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Invalid document link",
+		})
+	case services.ErrDocumentLinkRevoked:
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Document link is no longer valid",
+		})
+	case services.ErrDocumentLinkExpired:
+		c.JSON(http.StatusGone, gin.H{
+			"error": "Document link has expired",
+		})
+	case services.ErrDocumentLinkUsed:
+		c.JSON(http.StatusGone, gin.H{
+			"error": "Document link has already been used",
+		})
+	case services.ErrDocumentAccessNotLogged:
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Document access could not be recorded, please try again later",
+		})
+	case services.ErrDocumentCorrupted:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Document failed its integrity check",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fallback,
+		})
+	}
+}
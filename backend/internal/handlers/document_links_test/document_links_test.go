@@ -0,0 +1,127 @@
+package handlers_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"certitrack/internal/handlers"
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+	"certitrack/testutils/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// creatorID is the authenticated user who creates links in these tests, and
+// sessionID the session of their access token.
+var (
+	creatorID = uuid.New()
+	sessionID = "c2Vzc2lvbi1pZC1mb3ItdGVzdHM"
+)
+
+// setupDocumentLinkRouter mirrors the real routing: creating a link needs an
+// authenticated user, following one does not.
+func setupDocumentLinkRouter(t *testing.T) (*gin.Engine, *mocks.MockDocumentLinkService) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	svc := new(mocks.MockDocumentLinkService)
+	handler := handlers.NewDocumentLinkHandler(svc)
+
+	r := gin.New()
+	v1 := r.Group("/api/v1")
+	v1.GET("/documents/:id/content", handler.Content)
+
+	protected := v1.Group("")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user", &models.User{ID: creatorID, Role: "user"})
+		c.Set("userRole", "user")
+		c.Set("sessionID", sessionID)
+		c.Next()
+	})
+	protected.POST("/documents/:id/link", handler.Create)
+
+	t.Cleanup(func() { svc.AssertExpectations(t) })
+	return r, svc
+}
+
+func perform(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestDocumentLinkHandler_Create_WithoutBody(t *testing.T) {
+	r, svc := setupDocumentLinkRouter(t)
+	docID := uuid.New()
+	svc.On("CreateLink", docID, &services.CreateDocumentLinkRequest{}, creatorID, sessionID).
+		Return(&services.DocumentLink{URL: "https://api.example.com/x"}, nil)
+
+	w := perform(r, http.MethodPost, "/api/v1/documents/"+docID.String()+"/link", "")
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), "https://api.example.com/x")
+}
+
+func TestDocumentLinkHandler_Create_TTLTooLong(t *testing.T) {
+	r, svc := setupDocumentLinkRouter(t)
+	docID := uuid.New()
+	svc.On("CreateLink", docID, &services.CreateDocumentLinkRequest{ExpiresIn: 999999, SingleUse: true}, creatorID, sessionID).
+		Return(nil, services.ErrDocumentLinkTTLTooLong)
+
+	w := perform(r, http.MethodPost, "/api/v1/documents/"+docID.String()+"/link", `{"expiresIn":999999,"singleUse":true}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestDocumentLinkHandler_Content_ServesInline(t *testing.T) {
+	r, svc := setupDocumentLinkRouter(t)
+	docID := uuid.New()
+	svc.On("OpenLink", docID, mock.MatchedBy(func(q url.Values) bool {
+		return q.Get("sig") == "abc"
+	}), mock.MatchedBy(func(a *services.AccessInfo) bool {
+		return a.UserID == uuid.Nil && a.UserAgent == "mail-client"
+	})).Return(&models.CertificationDocument{ID: docID, FileName: "badge.png", MimeType: "image/png", FileSize: 3},
+		io.NopCloser(strings.NewReader("png")), nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/documents/"+docID.String()+"/content?sig=abc", nil)
+	req.Header.Set("User-Agent", "mail-client")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "png", w.Body.String())
+	assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+	assert.Equal(t, `inline; filename=badge.png`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "no-referrer", w.Header().Get("Referrer-Policy"))
+}
+
+func TestDocumentLinkHandler_Content_Errors(t *testing.T) {
+	for err, status := range map[error]int{
+		services.ErrDocumentLinkInvalid:     http.StatusForbidden,
+		services.ErrDocumentLinkExpired:     http.StatusGone,
+		services.ErrDocumentLinkUsed:        http.StatusGone,
+		services.ErrDocumentLinkRevoked:     http.StatusForbidden,
+		services.ErrDocumentNotFound:        http.StatusNotFound,
+		services.ErrDocumentAccessNotLogged: http.StatusServiceUnavailable,
+	} {
+		r, svc := setupDocumentLinkRouter(t)
+		docID := uuid.New()
+		svc.On("OpenLink", docID, mock.Anything, mock.Anything).Return(nil, nil, err)
+
+		w := perform(r, http.MethodGet, "/api/v1/documents/"+docID.String()+"/content", "")
+
+		assert.Equal(t, status, w.Code, err.Error())
+	}
+}
@@ -0,0 +1,333 @@
+package handlers
+
+import (
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"certitrack/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type DocumentHandler struct {
+	documentService services.DocumentService
+}
+
+func NewDocumentHandler(documentService services.DocumentService) *DocumentHandler {
+	return &DocumentHandler{
+		documentService: documentService,
+	}
+}
+
+func (h *DocumentHandler) List(c *gin.Context) {
+	certID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	docs, err := h.documentService.ListDocuments(certID)
+	if err != nil {
+		h.handleError(c, err, "Failed to list documents")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Documents retrieved successfully",
+		"data":    docs,
+	})
+}
+
+func (h *DocumentHandler) Upload(c *gin.Context) {
+	certID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	input, file, ok := readUpload(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	doc, err := h.documentService.UploadDocument(certID, input, currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Failed to upload document")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Document uploaded successfully",
+		"data":    doc,
+	})
+}
+
+func (h *DocumentHandler) Replace(c *gin.Context) {
+	certID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+	docID, ok := parseUUIDParam(c, "docId")
+	if !ok {
+		return
+	}
+
+	input, file, ok := readUpload(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	doc, err := h.documentService.ReplaceDocument(certID, docID, input, currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Failed to replace document")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Document version uploaded successfully",
+		"data":    doc,
+	})
+}
+
+func (h *DocumentHandler) Download(c *gin.Context) {
+	certID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+	docID, ok := parseUUIDParam(c, "docId")
+	if !ok {
+		return
+	}
+
+	doc, content, err := h.documentService.OpenDocument(certID, docID, accessInfo(c))
+	if err != nil {
+		h.handleError(c, err, "Failed to download document")
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, doc.FileSize, doc.MimeType, content, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": doc.FileName}),
+	})
+}
+
+func (h *DocumentHandler) Delete(c *gin.Context) {
+	certID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+	docID, ok := parseUUIDParam(c, "docId")
+	if !ok {
+		return
+	}
+
+	if err := h.documentService.DeleteDocument(certID, docID); err != nil {
+		h.handleError(c, err, "Failed to delete document")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Document deleted successfully",
+	})
+}
+
+func (h *DocumentHandler) Versions(c *gin.Context) {
+	certID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+	docID, ok := parseUUIDParam(c, "docId")
+	if !ok {
+		return
+	}
+
+	versions, err := h.documentService.ListVersions(certID, docID)
+	if err != nil {
+		h.handleError(c, err, "Failed to list document versions")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Document versions retrieved successfully",
+		"data":    versions,
+	})
+}
+
+func (h *DocumentHandler) DownloadVersion(c *gin.Context) {
+	certID, docID, version, ok := parseVersionParams(c)
+	if !ok {
+		return
+	}
+
+	v, content, err := h.documentService.OpenVersion(certID, docID, version, accessInfo(c))
+	if err != nil {
+		h.handleError(c, err, "Failed to download document version")
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, v.FileSize, v.MimeType, content, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": v.FileName}),
+	})
+}
+
+func (h *DocumentHandler) DeleteVersion(c *gin.Context) {
+	certID, docID, version, ok := parseVersionParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.documentService.DeleteVersion(certID, docID, version); err != nil {
+		h.handleError(c, err, "Failed to delete document version")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Document version deleted successfully",
+	})
+}
+
+// Thumbnail serves a preview of the document's current version. Real
+// thumbnails are cacheable and revalidated by ETag; placeholders for pending
+// thumbnails are not cached so the client picks up the real one later.
+func (h *DocumentHandler) Thumbnail(c *gin.Context) {
+	docID, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	thumb, err := h.documentService.GetThumbnail(docID, accessInfo(c))
+	if err != nil {
+		h.handleError(c, err, "Failed to load document thumbnail")
+		return
+	}
+
+	if thumb.Pending {
+		c.Header("Cache-Control", "no-store")
+	} else {
+		c.Header("Cache-Control", "private, max-age=86400")
+		c.Header("ETag", thumb.ETag)
+		if c.GetHeader("If-None-Match") == thumb.ETag {
+			c.Status(http.StatusNotModified)
+			return
+		}
+	}
+
+	c.Data(http.StatusOK, thumb.ContentType, thumb.Data)
+}
+
+// accessInfo describes the authenticated request for the document access log.
+func accessInfo(c *gin.Context) *services.AccessInfo {
+	return &services.AccessInfo{
+		UserID:    currentUserID(c),
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
+
+// readUpload extracts the multipart file and description. The caller must
+// close the returned file.
+func readUpload(c *gin.Context) (*services.UploadDocumentInput, multipart.File, bool) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		invalidRequest(c, err)
+		return nil, nil, false
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Failed to read uploaded file",
+		})
+		return nil, nil, false
+	}
+
+	return &services.UploadDocumentInput{
+		FileName:    fileHeader.Filename,
+		Size:        fileHeader.Size,
+		Description: c.PostForm("description"),
+		Content:     file,
+	}, file, true
+}
+
+func parseVersionParams(c *gin.Context) (certID, docID uuid.UUID, version int, ok bool) {
+	if certID, ok = parseUUIDParam(c, "id"); !ok {
+		return
+	}
+	if docID, ok = parseUUIDParam(c, "docId"); !ok {
+		return
+	}
+
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid version",
+		})
+		return certID, docID, 0, false
+	}
+	return certID, docID, version, true
+}
+
+func (h *DocumentHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch err {
+	case services.ErrCertificationNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Certification not found",
+		})
+	case services.ErrDocumentNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Document not found",
+		})
+	case services.ErrDocumentVersionNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Document version not found",
+		})
+	case services.ErrCurrentDocumentVersion:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "The current version cannot be deleted; delete the document instead",
+		})
+	case services.ErrDocumentVersionConflict:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Document was replaced concurrently, please retry",
+		})
+	case services.ErrDocumentCorrupted:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Document failed its integrity check",
+		})
+	case services.ErrDocumentAccessNotLogged:
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Document access could not be recorded, please try again later",
+		})
+	case services.ErrDocumentTooLarge:
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"error": "Document exceeds the maximum allowed size",
+		})
+	case services.ErrDocumentTypeNotAllowed:
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{
+			"error": "Document file type is not allowed",
+		})
+	case services.ErrDocumentContentInvalid:
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{
+			"error": "Document content does not match its file type",
+		})
+	case services.ErrDocumentInfected:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{
+			"error": "Document was rejected by the malware scan",
+		})
+	case services.ErrDocumentScanFailed:
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Document could not be scanned, please try again later",
+		})
+	case services.ErrDocumentEmpty:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Document is empty",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fallback,
+		})
+	}
+}
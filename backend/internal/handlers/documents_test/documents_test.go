@@ -0,0 +1,298 @@
+package handlers_test
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"certitrack/internal/handlers"
+	"certitrack/internal/middleware"
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+	"certitrack/testutils/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupDocumentRouter(t *testing.T, role string) (*gin.Engine, *mocks.MockDocumentService) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	svc := new(mocks.MockDocumentService)
+	handler := handlers.NewDocumentHandler(svc)
+	mw := middleware.NewMiddleware(new(mocks.MockAuthService))
+
+	r := gin.New()
+	protected := r.Group("/api/v1")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user", &models.User{ID: uuid.New(), Role: role})
+		c.Set("userRole", role)
+		c.Next()
+	})
+	{
+		protected.GET("/certifications/:id/documents", handler.List)
+		protected.POST("/certifications/:id/documents", handler.Upload)
+		protected.GET("/certifications/:id/documents/:docId", handler.Download)
+		protected.DELETE("/certifications/:id/documents/:docId", mw.AdminMiddleware(), handler.Delete)
+		protected.GET("/certifications/:id/documents/:docId/versions", handler.Versions)
+		protected.POST("/certifications/:id/documents/:docId/versions", handler.Replace)
+		protected.GET("/certifications/:id/documents/:docId/versions/:version", handler.DownloadVersion)
+		protected.DELETE("/certifications/:id/documents/:docId/versions/:version", mw.AdminMiddleware(), handler.DeleteVersion)
+		protected.GET("/documents/:id/thumbnail", handler.Thumbnail)
+	}
+
+	t.Cleanup(func() { svc.AssertExpectations(t) })
+	return r, svc
+}
+
+func documentsPath(certID uuid.UUID) string {
+	return "/api/v1/certifications/" + certID.String() + "/documents"
+}
+
+func multipartUpload(t *testing.T, r *gin.Engine, path, fileName, content string) *httptest.ResponseRecorder {
+	t.Helper()
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", fileName)
+	require.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, writer.WriteField("description", "Front page"))
+	require.NoError(t, writer.Close())
+
+	req, _ := http.NewRequest(http.MethodPost, path, body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestDocumentHandler_Upload_Success(t *testing.T) {
+	r, svc := setupDocumentRouter(t, "user")
+	certID := uuid.New()
+	svc.On("UploadDocument", certID, mock.MatchedBy(func(in *services.UploadDocumentInput) bool {
+		return in.FileName == "certificate.pdf" && in.Size == 8 && in.Description == "Front page"
+	}), mock.Anything).Return(&models.CertificationDocument{ID: uuid.New(), FileName: "certificate.pdf"}, nil)
+
+	w := multipartUpload(t, r, documentsPath(certID), "certificate.pdf", "%PDF-1.4")
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestDocumentHandler_Upload_MissingFile(t *testing.T) {
+	r, _ := setupDocumentRouter(t, "user")
+
+	req, _ := http.NewRequest(http.MethodPost, documentsPath(uuid.New()), strings.NewReader(""))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestDocumentHandler_Upload_TooLarge(t *testing.T) {
+	r, svc := setupDocumentRouter(t, "user")
+	svc.On("UploadDocument", mock.Anything, mock.Anything, mock.Anything).Return(nil, services.ErrDocumentTooLarge)
+
+	w := multipartUpload(t, r, documentsPath(uuid.New()), "certificate.pdf", "%PDF-1.4")
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestDocumentHandler_Upload_ContentMismatch(t *testing.T) {
+	r, svc := setupDocumentRouter(t, "user")
+	svc.On("UploadDocument", mock.Anything, mock.Anything, mock.Anything).Return(nil, services.ErrDocumentContentInvalid)
+
+	w := multipartUpload(t, r, documentsPath(uuid.New()), "certificate.pdf", "MZ")
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+	assert.Contains(t, w.Body.String(), "does not match")
+}
+
+func TestDocumentHandler_Upload_Infected(t *testing.T) {
+	r, svc := setupDocumentRouter(t, "user")
+	svc.On("UploadDocument", mock.Anything, mock.Anything, mock.Anything).Return(nil, services.ErrDocumentInfected)
+
+	w := multipartUpload(t, r, documentsPath(uuid.New()), "certificate.pdf", "%PDF-1.4")
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestDocumentHandler_Upload_ScanUnavailable(t *testing.T) {
+	r, svc := setupDocumentRouter(t, "user")
+	svc.On("UploadDocument", mock.Anything, mock.Anything, mock.Anything).Return(nil, services.ErrDocumentScanFailed)
+
+	w := multipartUpload(t, r, documentsPath(uuid.New()), "certificate.pdf", "%PDF-1.4")
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestDocumentHandler_Download(t *testing.T) {
+	r, svc := setupDocumentRouter(t, "user")
+	certID, docID := uuid.New(), uuid.New()
+	svc.On("OpenDocument", certID, docID, mock.Anything).Return(
+		&models.CertificationDocument{ID: docID, FileName: "certificate.pdf", FileSize: 8, MimeType: "application/pdf"},
+		io.NopCloser(strings.NewReader("%PDF-1.4")),
+		nil,
+	)
+
+	req, _ := http.NewRequest(http.MethodGet, documentsPath(certID)+"/"+docID.String(), nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename=certificate.pdf`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "%PDF-1.4", w.Body.String())
+}
+
+func TestDocumentHandler_Delete_RequiresAdmin(t *testing.T) {
+	r, _ := setupDocumentRouter(t, "user")
+
+	req, _ := http.NewRequest(http.MethodDelete, documentsPath(uuid.New())+"/"+uuid.NewString(), nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestDocumentHandler_Replace(t *testing.T) {
+	r, svc := setupDocumentRouter(t, "user")
+	certID, docID := uuid.New(), uuid.New()
+	svc.On("ReplaceDocument", certID, docID, mock.MatchedBy(func(in *services.UploadDocumentInput) bool {
+		return in.FileName == "rescan.pdf"
+	}), mock.Anything).Return(&models.CertificationDocument{ID: docID, Version: 2}, nil)
+
+	w := multipartUpload(t, r, documentsPath(certID)+"/"+docID.String()+"/versions", "rescan.pdf", "%PDF-1.4")
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"version":2`)
+}
+
+func TestDocumentHandler_Versions(t *testing.T) {
+	r, svc := setupDocumentRouter(t, "user")
+	certID, docID := uuid.New(), uuid.New()
+	svc.On("ListVersions", certID, docID).Return([]models.CertificationDocumentVersion{
+		{Version: 1, FileName: "scan.pdf"},
+		{Version: 2, FileName: "rescan.pdf", Current: true},
+	}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, documentsPath(certID)+"/"+docID.String()+"/versions", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"current":true`)
+}
+
+func TestDocumentHandler_DownloadVersion(t *testing.T) {
+	r, svc := setupDocumentRouter(t, "user")
+	certID, docID := uuid.New(), uuid.New()
+	svc.On("OpenVersion", certID, docID, 1, mock.Anything).Return(
+		&models.CertificationDocumentVersion{Version: 1, FileName: "scan.pdf", FileSize: 8, MimeType: "application/pdf"},
+		io.NopCloser(strings.NewReader("%PDF-1.4")), nil)
+
+	req, _ := http.NewRequest(http.MethodGet, documentsPath(certID)+"/"+docID.String()+"/versions/1", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "%PDF-1.4", w.Body.String())
+	assert.Equal(t, `attachment; filename=scan.pdf`, w.Header().Get("Content-Disposition"))
+}
+
+func TestDocumentHandler_DownloadVersion_InvalidVersion(t *testing.T) {
+	r, _ := setupDocumentRouter(t, "user")
+
+	req, _ := http.NewRequest(http.MethodGet, documentsPath(uuid.New())+"/"+uuid.NewString()+"/versions/0", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestDocumentHandler_DeleteVersion_RequiresAdmin(t *testing.T) {
+	r, _ := setupDocumentRouter(t, "user")
+
+	req, _ := http.NewRequest(http.MethodDelete, documentsPath(uuid.New())+"/"+uuid.NewString()+"/versions/1", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestDocumentHandler_DeleteVersion_Current(t *testing.T) {
+	r, svc := setupDocumentRouter(t, "admin")
+	certID, docID := uuid.New(), uuid.New()
+	svc.On("DeleteVersion", certID, docID, 2).Return(services.ErrCurrentDocumentVersion)
+
+	req, _ := http.NewRequest(http.MethodDelete, documentsPath(certID)+"/"+docID.String()+"/versions/2", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestDocumentHandler_Download_Corrupted(t *testing.T) {
+	r, svc := setupDocumentRouter(t, "user")
+	certID, docID := uuid.New(), uuid.New()
+	svc.On("OpenDocument", certID, docID, mock.Anything).Return(nil, nil, services.ErrDocumentCorrupted)
+
+	req, _ := http.NewRequest(http.MethodGet, documentsPath(certID)+"/"+docID.String(), nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "integrity check")
+}
+
+func TestDocumentHandler_Thumbnail_CachedAndRevalidated(t *testing.T) {
+	r, svc := setupDocumentRouter(t, "user")
+	docID := uuid.New()
+	svc.On("GetThumbnail", docID, mock.Anything).Return(&services.DocumentThumbnail{
+		Data:        []byte("jpeg"),
+		ContentType: "image/jpeg",
+		ETag:        `"abc"`,
+	}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/documents/"+docID.String()+"/thumbnail", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image/jpeg", w.Header().Get("Content-Type"))
+	assert.Equal(t, "private, max-age=86400", w.Header().Get("Cache-Control"))
+	assert.Equal(t, `"abc"`, w.Header().Get("ETag"))
+
+	req.Header.Set("If-None-Match", `"abc"`)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestDocumentHandler_Thumbnail_PendingIsNotCached(t *testing.T) {
+	r, svc := setupDocumentRouter(t, "user")
+	docID := uuid.New()
+	svc.On("GetThumbnail", docID, mock.Anything).Return(&services.DocumentThumbnail{
+		Data:        []byte("png"),
+		ContentType: "image/png",
+		Pending:     true,
+	}, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/documents/"+docID.String()+"/thumbnail", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	assert.Empty(t, w.Header().Get("ETag"))
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"net/http"
+
+	"certitrack/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EquipmentHandler struct {
+	equipmentService services.EquipmentService
+}
+
+func NewEquipmentHandler(equipmentService services.EquipmentService) *EquipmentHandler {
+	return &EquipmentHandler{
+		equipmentService: equipmentService,
+	}
+}
+
+func (h *EquipmentHandler) List(c *gin.Context) {
+	var req services.ListEquipmentRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	response, err := h.equipmentService.ListEquipment(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list equipment",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Equipment retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *EquipmentHandler) Create(c *gin.Context) {
+	var req services.CreateEquipmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	equipment, err := h.equipmentService.CreateEquipment(&req, currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Failed to create equipment")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Equipment created successfully",
+		"data":    equipment,
+	})
+}
+
+func (h *EquipmentHandler) Get(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	equipment, err := h.equipmentService.GetEquipment(id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get equipment")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Equipment retrieved successfully",
+		"data":    equipment,
+	})
+}
+
+func (h *EquipmentHandler) Update(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req services.UpdateEquipmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	equipment, err := h.equipmentService.UpdateEquipment(id, &req, currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Failed to update equipment")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Equipment updated successfully",
+		"data":    equipment,
+	})
+}
+
+func (h *EquipmentHandler) Delete(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.equipmentService.DeactivateEquipment(id, currentUserID(c)); err != nil {
+		h.handleError(c, err, "Failed to delete equipment")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Equipment deactivated successfully",
+	})
+}
+
+func (h *EquipmentHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch err {
+	case services.ErrEquipmentNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Equipment not found",
+		})
+	case services.ErrAssetNumberExists:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Equipment with this asset number already exists",
+		})
+	case services.ErrInvalidEquipmentData:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid equipment data",
+		})
+	case services.ErrEquipmentClassNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Equipment class not found",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"net/http"
+
+	"certitrack/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type EquipmentClassHandler struct {
+	classService services.EquipmentClassService
+}
+
+func NewEquipmentClassHandler(classService services.EquipmentClassService) *EquipmentClassHandler {
+	return &EquipmentClassHandler{
+		classService: classService,
+	}
+}
+
+func (h *EquipmentClassHandler) List(c *gin.Context) {
+	var req services.PageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	response, err := h.classService.ListClasses(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list equipment classes",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Equipment classes retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *EquipmentClassHandler) Create(c *gin.Context) {
+	var req services.CreateEquipmentClassRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	class, err := h.classService.CreateClass(&req, currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Failed to create equipment class")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Equipment class created successfully",
+		"data":    class,
+	})
+}
+
+func (h *EquipmentClassHandler) Get(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	class, err := h.classService.GetClass(id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get equipment class")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Equipment class retrieved successfully",
+		"data":    class,
+	})
+}
+
+func (h *EquipmentClassHandler) Update(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req services.UpdateEquipmentClassRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	class, err := h.classService.UpdateClass(id, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update equipment class")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Equipment class updated successfully",
+		"data":    class,
+	})
+}
+
+func (h *EquipmentClassHandler) Delete(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.classService.DeleteClass(id); err != nil {
+		h.handleError(c, err, "Failed to delete equipment class")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Equipment class deleted successfully",
+	})
+}
+
+func (h *EquipmentClassHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch err {
+	case services.ErrEquipmentClassNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Equipment class not found",
+		})
+	case services.ErrEquipmentClassExists:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Equipment class with this name already exists",
+		})
+	case services.ErrEquipmentClassInUse:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Equipment class is assigned to equipment",
+		})
+	case services.ErrCertificationTypeNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Certification type not found",
+		})
+	case services.ErrInactiveCertificationType:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Certification type is inactive",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"certitrack/internal/handlers"
+	"certitrack/internal/middleware"
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+	"certitrack/testutils/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// setupEquipmentClassRouter mirrors the real routing: reads need an
+// authenticated user, changes need an admin.
+func setupEquipmentClassRouter(t *testing.T, role string) (*gin.Engine, *mocks.MockEquipmentClassService) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	svc := new(mocks.MockEquipmentClassService)
+	h := handlers.NewEquipmentClassHandler(svc)
+	mw := middleware.NewMiddleware(new(mocks.MockAuthService))
+
+	r := gin.New()
+	protected := r.Group("/api/v1")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user", &models.User{ID: uuid.New(), Role: role})
+		c.Set("userRole", role)
+		c.Next()
+	})
+	{
+		protected.GET("/equipment-classes", h.List)
+		protected.GET("/equipment-classes/:id", h.Get)
+		protected.POST("/equipment-classes", mw.AdminMiddleware(), h.Create)
+		protected.PUT("/equipment-classes/:id", mw.AdminMiddleware(), h.Update)
+		protected.DELETE("/equipment-classes/:id", mw.AdminMiddleware(), h.Delete)
+	}
+
+	t.Cleanup(func() { svc.AssertExpectations(t) })
+	return r, svc
+}
+
+func performRequest(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestEquipmentClassHandler_List(t *testing.T) {
+	r, svc := setupEquipmentClassRouter(t, "user")
+	svc.On("ListClasses", &services.PageRequest{Page: 2}).
+		Return(&services.EquipmentClassListResponse{Items: []models.EquipmentClass{{Name: "Cranes"}}}, nil)
+
+	w := performRequest(r, http.MethodGet, "/api/v1/equipment-classes?page=2", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"Cranes"`)
+}
+
+func TestEquipmentClassHandler_Get_NotFound(t *testing.T) {
+	r, svc := setupEquipmentClassRouter(t, "user")
+	id := uuid.New()
+	svc.On("GetClass", id).Return(nil, services.ErrEquipmentClassNotFound)
+
+	w := performRequest(r, http.MethodGet, "/api/v1/equipment-classes/"+id.String(), "")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestEquipmentClassHandler_Create(t *testing.T) {
+	r, svc := setupEquipmentClassRouter(t, "admin")
+	typeID := uuid.New()
+	svc.On("CreateClass", &services.CreateEquipmentClassRequest{Name: "Cranes", RequiredTypeIDs: []uuid.UUID{typeID}}, mock.Anything).
+		Return(&models.EquipmentClass{ID: uuid.New(), Name: "Cranes"}, nil)
+
+	w := performRequest(r, http.MethodPost, "/api/v1/equipment-classes",
+		`{"name":"Cranes","required_type_ids":["`+typeID.String()+`"]}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestEquipmentClassHandler_Create_RequiresAdmin(t *testing.T) {
+	r, _ := setupEquipmentClassRouter(t, "user")
+
+	w := performRequest(r, http.MethodPost, "/api/v1/equipment-classes", `{"name":"Cranes"}`)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestEquipmentClassHandler_Create_Validation(t *testing.T) {
+	r, _ := setupEquipmentClassRouter(t, "admin")
+
+	w := performRequest(r, http.MethodPost, "/api/v1/equipment-classes", `{"name":"Cranes","at_risk_days":-1}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestEquipmentClassHandler_CreateErrors(t *testing.T) {
+	for err, status := range map[error]int{
+		services.ErrEquipmentClassExists:      http.StatusConflict,
+		services.ErrCertificationTypeNotFound: http.StatusNotFound,
+		services.ErrInactiveCertificationType: http.StatusBadRequest,
+	} {
+		r, svc := setupEquipmentClassRouter(t, "admin")
+		svc.On("CreateClass", mock.Anything, mock.Anything).Return(nil, err)
+
+		w := performRequest(r, http.MethodPost, "/api/v1/equipment-classes", `{"name":"Cranes"}`)
+
+		assert.Equal(t, status, w.Code, err.Error())
+	}
+}
+
+func TestEquipmentClassHandler_Update(t *testing.T) {
+	r, svc := setupEquipmentClassRouter(t, "admin")
+	id := uuid.New()
+	days := 60
+	svc.On("UpdateClass", id, &services.UpdateEquipmentClassRequest{AtRiskDays: &days}).
+		Return(&models.EquipmentClass{ID: id, AtRiskDays: days}, nil)
+
+	w := performRequest(r, http.MethodPut, "/api/v1/equipment-classes/"+id.String(), `{"at_risk_days":60}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"atRiskDays":60`)
+}
+
+func TestEquipmentClassHandler_Delete_InUse(t *testing.T) {
+	r, svc := setupEquipmentClassRouter(t, "admin")
+	id := uuid.New()
+	svc.On("DeleteClass", id).Return(services.ErrEquipmentClassInUse)
+
+	w := performRequest(r, http.MethodDelete, "/api/v1/equipment-classes/"+id.String(), "")
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
@@ -0,0 +1,91 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEquipmentHandler_Create_Success(t *testing.T) {
+	r, svc := setupEquipmentRouter(t)
+	equipment := &models.Equipment{ID: uuid.New(), AssetNumber: "EQ-002", Name: "Crane #2", IsActive: true}
+	svc.On("CreateEquipment", mock.MatchedBy(func(req *services.CreateEquipmentRequest) bool {
+		return req.AssetNumber == "EQ-002"
+	}), testUser.ID).Return(equipment, nil)
+
+	w := performRequest(r, http.MethodPost, equipmentPath, `{"asset_number":"EQ-002","name":"Crane #2"}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "Equipment created successfully", decodeResponse(t, w)["message"])
+}
+
+func TestEquipmentHandler_Create_MissingAssetNumber(t *testing.T) {
+	r, _ := setupEquipmentRouter(t)
+
+	w := performRequest(r, http.MethodPost, equipmentPath, `{"name":"Crane #2"}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestEquipmentHandler_Create_DuplicateAssetNumber(t *testing.T) {
+	r, svc := setupEquipmentRouter(t)
+	svc.On("CreateEquipment", mock.Anything, testUser.ID).Return(nil, services.ErrAssetNumberExists)
+
+	w := performRequest(r, http.MethodPost, equipmentPath, `{"asset_number":"EQ-001","name":"Crane"}`)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "Equipment with this asset number already exists", decodeResponse(t, w)["error"])
+}
+
+func TestEquipmentHandler_List_PassesFilters(t *testing.T) {
+	r, svc := setupEquipmentRouter(t)
+	svc.On("ListEquipment", mock.MatchedBy(func(req *services.ListEquipmentRequest) bool {
+		return req.Location == "Warehouse A" && req.Manufacturer == "Toyota"
+	})).Return(&services.EquipmentListResponse{Items: []models.Equipment{}}, nil)
+
+	w := performRequest(r, http.MethodGet, equipmentPath+"?location=Warehouse%20A&manufacturer=Toyota", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestEquipmentHandler_List_ComplianceFilter(t *testing.T) {
+	r, svc := setupEquipmentRouter(t)
+	classID := uuid.New()
+	svc.On("ListEquipment", mock.MatchedBy(func(req *services.ListEquipmentRequest) bool {
+		return req.ComplianceStatus == models.EquipmentAtRisk && req.ClassID == classID.String()
+	})).Return(&services.EquipmentListResponse{Items: []models.Equipment{}}, nil)
+
+	w := performRequest(r, http.MethodGet, equipmentPath+"?complianceStatus=at_risk&classId="+classID.String(), "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = performRequest(r, http.MethodGet, equipmentPath+"?complianceStatus=expired", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = performRequest(r, http.MethodGet, equipmentPath+"?classId=cranes", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestEquipmentHandler_Create_UnknownClass(t *testing.T) {
+	r, svc := setupEquipmentRouter(t)
+	svc.On("CreateEquipment", mock.Anything, testUser.ID).Return(nil, services.ErrEquipmentClassNotFound)
+
+	w := performRequest(r, http.MethodPost, equipmentPath, `{"asset_number":"EQ-001","name":"Crane","class_id":"`+uuid.NewString()+`"}`)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestEquipmentHandler_Get_NotFound(t *testing.T) {
+	r, svc := setupEquipmentRouter(t)
+	id := uuid.New()
+	svc.On("GetEquipment", id).Return(nil, services.ErrEquipmentNotFound)
+
+	w := performRequest(r, http.MethodGet, equipmentPath+"/"+id.String(), "")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
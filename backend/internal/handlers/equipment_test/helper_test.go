@@ -0,0 +1,64 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"certitrack/internal/handlers"
+	"certitrack/internal/models"
+	"certitrack/testutils/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+const equipmentPath = "/api/v1/equipment"
+
+var testUser = &models.User{ID: uuid.New(), Role: "user", IsActive: true}
+
+func setupEquipmentRouter(t *testing.T) (*gin.Engine, *mocks.MockEquipmentService) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	svc := new(mocks.MockEquipmentService)
+	handler := handlers.NewEquipmentHandler(svc)
+
+	r := gin.New()
+	v1 := r.Group("/api/v1")
+	v1.Use(func(c *gin.Context) {
+		c.Set("user", testUser)
+		c.Set("userID", testUser.ID.String())
+		c.Set("userRole", testUser.Role)
+		c.Next()
+	})
+	{
+		equipment := v1.Group("/equipment")
+		equipment.GET("", handler.List)
+		equipment.POST("", handler.Create)
+		equipment.GET("/:id", handler.Get)
+		equipment.PUT("/:id", handler.Update)
+		equipment.DELETE("/:id", handler.Delete)
+	}
+
+	t.Cleanup(func() { svc.AssertExpectations(t) })
+	return r, svc
+}
+
+func performRequest(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func decodeResponse(t *testing.T, w *httptest.ResponseRecorder) map[string]interface{} {
+	t.Helper()
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response
+}
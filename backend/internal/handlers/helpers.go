@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+
+	"certitrack/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// parseUUIDParam reads a UUID path parameter and writes a 400 response when
+// it is malformed. The boolean reports whether the handler may continue.
+func parseUUIDParam(c *gin.Context, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(name))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid " + name + " parameter",
+		})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// currentUserID returns the ID of the authenticated user set by AuthMiddleware,
+// or uuid.Nil when the request is anonymous.
+func currentUserID(c *gin.Context) uuid.UUID {
+	if user, ok := c.Get("user"); ok {
+		if u, ok := user.(*models.User); ok {
+			return u.ID
+		}
+	}
+	if id, err := uuid.Parse(c.GetString("userID")); err == nil {
+		return id
+	}
+	return uuid.Nil
+}
+
+// currentSessionID returns the session ID of the access token set by
+// AuthMiddleware, or "" when the request is anonymous.
+func currentSessionID(c *gin.Context) string {
+	return c.GetString("sessionID")
+}
+
+func invalidRequest(c *gin.Context, err error) {
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error":   "Invalid request data",
+		"details": err.Error(),
+	})
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"net/http"
+
+	"certitrack/internal/jobs"
+	"certitrack/internal/storage"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JobsHandler struct {
+	expirySweeper     *jobs.ExpirySweeper
+	storageVerifier   *jobs.StorageVerifier
+	keyRotator        *jobs.KeyRotator
+	expirationChecker *jobs.ExpirationChecker
+	outboxWorker      *jobs.OutboxWorker
+	digestBuilder     *jobs.DigestBuilder
+}
+
+func NewJobsHandler(expirySweeper *jobs.ExpirySweeper, storageVerifier *jobs.StorageVerifier, keyRotator *jobs.KeyRotator, expirationChecker *jobs.ExpirationChecker, outboxWorker *jobs.OutboxWorker, digestBuilder *jobs.DigestBuilder) *JobsHandler {
+	return &JobsHandler{
+		expirySweeper:     expirySweeper,
+		storageVerifier:   storageVerifier,
+		keyRotator:        keyRotator,
+		expirationChecker: expirationChecker,
+		outboxWorker:      outboxWorker,
+		digestBuilder:     digestBuilder,
+	}
+}
+
+// RunExpirySweep triggers the expiry sweep on demand.
+func (h *JobsHandler) RunExpirySweep(c *gin.Context) {
+	expired, err := h.expirySweeper.RunOnce(c.Request.Context())
+	if err != nil {
+		if err == jobs.ErrSweepInProgress {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Expiry sweep is already running",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to run expiry sweep",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Expiry sweep completed successfully",
+		"data": gin.H{
+			"expired": expired,
+		},
+	})
+}
+
+// RunStorageVerify re-reads every stored document file and reports the ones
+// that are missing or corrupted.
+func (h *JobsHandler) RunStorageVerify(c *gin.Context) {
+	report, err := h.storageVerifier.RunOnce(c.Request.Context())
+	if err != nil {
+		if err == jobs.ErrVerifyInProgress {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Storage verification is already running",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to verify storage",
+		})
+		return
+	}
+
+	message := "Storage verification completed successfully"
+	if !report.Healthy() {
+		message = "Storage verification found missing or corrupted files"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data":    report,
+	})
+}
+
+// RunKeyRotation rewraps document data keys with the current master key.
+func (h *JobsHandler) RunKeyRotation(c *gin.Context) {
+	report, err := h.keyRotator.RunOnce(c.Request.Context())
+	if err != nil {
+		switch err {
+		case jobs.ErrRotationInProgress:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Key rotation is already running",
+			})
+		case storage.ErrEncryptionDisabled:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Encryption at rest is not configured",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to rotate encryption keys",
+			})
+		}
+		return
+	}
+
+	message := "Key rotation completed successfully"
+	if len(report.Failed) > 0 {
+		message = "Key rotation could not rewrap some data keys"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data":    report,
+	})
+}
+
+// RunExpirationCheck schedules the expiration reminders that are due.
+func (h *JobsHandler) RunExpirationCheck(c *gin.Context) {
+	report, err := h.expirationChecker.RunOnce(c.Request.Context())
+	if err != nil {
+		if err == jobs.ErrCheckInProgress {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Expiration check is already running",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to run expiration check",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Expiration check completed successfully",
+		"data":    report,
+	})
+}
+
+// RunOutbox sends the notification emails that are due.
+func (h *JobsHandler) RunOutbox(c *gin.Context) {
+	report, err := h.outboxWorker.RunOnce(c.Request.Context())
+	if err != nil {
+		if err == jobs.ErrOutboxInProgress {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Outbox delivery is already running",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to deliver notifications",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Outbox delivery completed successfully",
+		"data":    report,
+	})
+}
+
+// RunDigests collects waiting reminders into this week's digests.
+func (h *JobsHandler) RunDigests(c *gin.Context) {
+	report, err := h.digestBuilder.RunOnce(c.Request.Context())
+	if err != nil {
+		if err == jobs.ErrDigestInProgress {
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Digest build is already running",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to build notification digests",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Digest build completed successfully",
+		"data":    report,
+	})
+}
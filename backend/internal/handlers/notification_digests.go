@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"net/http"
+
+	"certitrack/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationDigestHandler struct {
+	digestService services.NotificationDigestService
+}
+
+func NewNotificationDigestHandler(digestService services.NotificationDigestService) *NotificationDigestHandler {
+	return &NotificationDigestHandler{
+		digestService: digestService,
+	}
+}
+
+// List returns weekly digests; ?status=dead lists the dead letters.
+func (h *NotificationDigestHandler) List(c *gin.Context) {
+	var req services.ListNotificationDigestsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	response, err := h.digestService.ListDigests(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list notification digests",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notification digests retrieved successfully",
+		"data":    response,
+	})
+}
+
+// Requeue gives a dead-lettered digest a fresh set of delivery attempts.
+func (h *NotificationDigestHandler) Requeue(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	digest, err := h.digestService.RequeueDigest(id)
+	if err != nil {
+		switch err {
+		case services.ErrDigestNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Notification digest not found",
+			})
+		case services.ErrNotificationNotDead:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Only dead-lettered notification digests can be requeued",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to requeue notification digest",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notification digest requeued successfully",
+		"data":    digest,
+	})
+}
+
+// GetPreferences returns the current user's notification settings.
+func (h *NotificationDigestHandler) GetPreferences(c *gin.Context) {
+	preferences, err := h.digestService.GetPreferences(currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Failed to retrieve notification preferences")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notification preferences retrieved successfully",
+		"data":    preferences,
+	})
+}
+
+// UpdatePreferences sets whether the current user gets reminders one by one,
+// in a weekly digest, or as the notification rules say.
+func (h *NotificationDigestHandler) UpdatePreferences(c *gin.Context) {
+	var req services.UpdateNotificationPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	preferences, err := h.digestService.UpdatePreferences(currentUserID(c), &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update notification preferences")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notification preferences updated successfully",
+		"data":    preferences,
+	})
+}
+
+func (h *NotificationDigestHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch err {
+	case services.ErrUserNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "User not found",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"certitrack/internal/handlers"
+	"certitrack/internal/middleware"
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+	"certitrack/testutils/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// userID is the authenticated user of every request.
+var userID = uuid.New()
+
+// setupNotificationDigestRouter mirrors the real routing: digests are
+// admin-only, while every user manages their own preferences.
+func setupNotificationDigestRouter(t *testing.T, role string) (*gin.Engine, *mocks.MockNotificationDigestService) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	svc := new(mocks.MockNotificationDigestService)
+	handler := handlers.NewNotificationDigestHandler(svc)
+	mw := middleware.NewMiddleware(new(mocks.MockAuthService))
+
+	r := gin.New()
+	protected := r.Group("/api/v1")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user", &models.User{ID: userID, Role: role})
+		c.Set("userRole", role)
+		c.Next()
+	})
+	digests := protected.Group("/notification-digests", mw.AdminMiddleware())
+	digests.GET("", handler.List)
+	digests.POST("/:id/requeue", handler.Requeue)
+	protected.GET("/notification-preferences", handler.GetPreferences)
+	protected.PUT("/notification-preferences", handler.UpdatePreferences)
+
+	t.Cleanup(func() { svc.AssertExpectations(t) })
+	return r, svc
+}
+
+func performRequest(r *gin.Engine, method, path string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func performJSONRequest(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestNotificationDigestHandler_List(t *testing.T) {
+	r, svc := setupNotificationDigestRouter(t, "admin")
+	svc.On("ListDigests", &services.ListNotificationDigestsRequest{Status: "dead", Recipient: "dana@example.com"}).
+		Return(&services.NotificationDigestListResponse{
+			Items: []models.NotificationDigest{{Recipient: "dana@example.com", Status: models.NotificationStatusDead}},
+		}, nil)
+
+	w := performRequest(r, http.MethodGet, "/api/v1/notification-digests?status=dead&recipient=dana@example.com")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"recipient":"dana@example.com"`)
+	assert.Equal(t, http.StatusBadRequest, performRequest(r, http.MethodGet, "/api/v1/notification-digests?recipient=dana").Code)
+}
+
+func TestNotificationDigestHandler_RequeueErrors(t *testing.T) {
+	for err, status := range map[error]int{
+		services.ErrDigestNotFound:      http.StatusNotFound,
+		services.ErrNotificationNotDead: http.StatusConflict,
+	} {
+		r, svc := setupNotificationDigestRouter(t, "admin")
+		id := uuid.New()
+		svc.On("RequeueDigest", id).Return(nil, err)
+
+		w := performRequest(r, http.MethodPost, "/api/v1/notification-digests/"+id.String()+"/requeue")
+
+		assert.Equal(t, status, w.Code, err.Error())
+	}
+}
+
+func TestNotificationDigestHandler_Preferences(t *testing.T) {
+	r, svc := setupNotificationDigestRouter(t, "user")
+	svc.On("GetPreferences", userID).Return(&services.NotificationPreferences{}, nil)
+	svc.On("UpdatePreferences", userID, &services.UpdateNotificationPreferencesRequest{Delivery: "digest"}).
+		Return(&services.NotificationPreferences{Delivery: "digest"}, nil)
+
+	w := performRequest(r, http.MethodGet, "/api/v1/notification-preferences")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"delivery":""`)
+
+	w = performJSONRequest(r, http.MethodPut, "/api/v1/notification-preferences", `{"delivery":"digest"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"delivery":"digest"`)
+
+	w = performJSONRequest(r, http.MethodPut, "/api/v1/notification-preferences", `{"delivery":"weekly"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
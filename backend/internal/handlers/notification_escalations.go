@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+
+	"certitrack/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationEscalationHandler struct {
+	escalationService services.NotificationEscalationService
+}
+
+func NewNotificationEscalationHandler(escalationService services.NotificationEscalationService) *NotificationEscalationHandler {
+	return &NotificationEscalationHandler{
+		escalationService: escalationService,
+	}
+}
+
+// Requeue gives the dead-lettered escalation steps of a notification a fresh
+// set of delivery attempts.
+func (h *NotificationEscalationHandler) Requeue(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	escalations, err := h.escalationService.RequeueEscalations(id)
+	if err != nil {
+		switch err {
+		case services.ErrNotificationNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Notification not found",
+			})
+		case services.ErrNotificationNotDead:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Notification has no dead-lettered escalations to requeue",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to requeue escalations",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Escalations requeued successfully",
+		"data":    escalations,
+	})
+}
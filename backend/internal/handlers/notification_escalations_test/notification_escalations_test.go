@@ -0,0 +1,81 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"certitrack/internal/handlers"
+	"certitrack/internal/middleware"
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+	"certitrack/testutils/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupNotificationEscalationRouter(t *testing.T, role string) (*gin.Engine, *mocks.MockNotificationEscalationService) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	svc := new(mocks.MockNotificationEscalationService)
+	handler := handlers.NewNotificationEscalationHandler(svc)
+	mw := middleware.NewMiddleware(new(mocks.MockAuthService))
+
+	r := gin.New()
+	protected := r.Group("/api/v1")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user", &models.User{ID: uuid.New(), Role: role})
+		c.Set("userRole", role)
+		c.Next()
+	})
+	protected.POST("/notifications/:id/escalations/requeue", mw.AdminMiddleware(), handler.Requeue)
+
+	t.Cleanup(func() { svc.AssertExpectations(t) })
+	return r, svc
+}
+
+func performRequest(r *gin.Engine, method, path string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestNotificationEscalationHandler_Requeue(t *testing.T) {
+	r, svc := setupNotificationEscalationRouter(t, "admin")
+	id := uuid.New()
+	svc.On("RequeueEscalations", id).Return([]models.NotificationEscalation{
+		{NotificationID: id, Level: 1, Status: models.NotificationStatusPending},
+	}, nil)
+
+	w := performRequest(r, http.MethodPost, "/api/v1/notifications/"+id.String()+"/escalations/requeue")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"pending"`)
+}
+
+func TestNotificationEscalationHandler_RequeueErrors(t *testing.T) {
+	for err, status := range map[error]int{
+		services.ErrNotificationNotFound: http.StatusNotFound,
+		services.ErrNotificationNotDead:  http.StatusConflict,
+	} {
+		r, svc := setupNotificationEscalationRouter(t, "admin")
+		id := uuid.New()
+		svc.On("RequeueEscalations", id).Return(nil, err)
+
+		w := performRequest(r, http.MethodPost, "/api/v1/notifications/"+id.String()+"/escalations/requeue")
+
+		assert.Equal(t, status, w.Code, err.Error())
+	}
+}
+
+func TestNotificationEscalationHandler_AdminOnly(t *testing.T) {
+	r, _ := setupNotificationEscalationRouter(t, "user")
+
+	w := performRequest(r, http.MethodPost, "/api/v1/notifications/"+uuid.NewString()+"/escalations/requeue")
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
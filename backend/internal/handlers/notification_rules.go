@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"net/http"
+
+	"certitrack/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationRuleHandler struct {
+	ruleService services.NotificationRuleService
+}
+
+func NewNotificationRuleHandler(ruleService services.NotificationRuleService) *NotificationRuleHandler {
+	return &NotificationRuleHandler{
+		ruleService: ruleService,
+	}
+}
+
+func (h *NotificationRuleHandler) List(c *gin.Context) {
+	var req services.ListNotificationRulesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	response, err := h.ruleService.ListRules(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list notification rules",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notification rules retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *NotificationRuleHandler) Create(c *gin.Context) {
+	var req services.CreateNotificationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	rule, err := h.ruleService.CreateRule(&req, currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Failed to create notification rule")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Notification rule created successfully",
+		"data":    rule,
+	})
+}
+
+func (h *NotificationRuleHandler) Get(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	rule, err := h.ruleService.GetRule(id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get notification rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notification rule retrieved successfully",
+		"data":    rule,
+	})
+}
+
+func (h *NotificationRuleHandler) Update(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req services.UpdateNotificationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	rule, err := h.ruleService.UpdateRule(id, &req)
+	if err != nil {
+		h.handleError(c, err, "Failed to update notification rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notification rule updated successfully",
+		"data":    rule,
+	})
+}
+
+func (h *NotificationRuleHandler) Delete(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.ruleService.DeleteRule(id); err != nil {
+		h.handleError(c, err, "Failed to delete notification rule")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notification rule deleted successfully",
+	})
+}
+
+// ForCertification returns the rules that will send reminders for a
+// certification, and whether they are type, category or global rules.
+func (h *NotificationRuleHandler) ForCertification(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	effective, err := h.ruleService.ResolveForCertification(id)
+	if err != nil {
+		h.handleError(c, err, "Failed to resolve notification rules")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notification rules retrieved successfully",
+		"data":    effective,
+	})
+}
+
+func (h *NotificationRuleHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch err {
+	case services.ErrNotificationRuleNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Notification rule not found",
+		})
+	case services.ErrNotificationRuleExists:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "A notification rule with this scope and number of days already exists",
+		})
+	case services.ErrInvalidNotificationScope:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Set either a certification type or a category, not both",
+		})
+	case services.ErrUnknownEmailTemplate:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Email template does not exist",
+		})
+	case services.ErrCertificationTypeNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Certification type not found",
+		})
+	case services.ErrInactiveCertificationType:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Certification type is inactive",
+		})
+	case services.ErrCertificationNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Certification not found",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fallback,
+		})
+	}
+}
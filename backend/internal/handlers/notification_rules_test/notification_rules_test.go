@@ -0,0 +1,178 @@
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"certitrack/internal/handlers"
+	"certitrack/internal/middleware"
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+	"certitrack/testutils/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// setupNotificationRuleRouter mirrors the real routing: managing rules needs
+// an admin, the rules for a certification only an authenticated user.
+func setupNotificationRuleRouter(t *testing.T, role string) (*gin.Engine, *mocks.MockNotificationRuleService) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	svc := new(mocks.MockNotificationRuleService)
+	h := handlers.NewNotificationRuleHandler(svc)
+	mw := middleware.NewMiddleware(new(mocks.MockAuthService))
+
+	r := gin.New()
+	protected := r.Group("/api/v1")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user", &models.User{ID: uuid.New(), Role: role})
+		c.Set("userRole", role)
+		c.Next()
+	})
+	{
+		protected.GET("/certifications/:id/notification-rules", h.ForCertification)
+
+		admin := protected.Group("/notification-rules")
+		admin.Use(mw.AdminMiddleware())
+		admin.GET("", h.List)
+		admin.POST("", h.Create)
+		admin.GET("/:id", h.Get)
+		admin.PUT("/:id", h.Update)
+		admin.DELETE("/:id", h.Delete)
+	}
+
+	t.Cleanup(func() { svc.AssertExpectations(t) })
+	return r, svc
+}
+
+func performRequest(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestNotificationRuleHandler_Create(t *testing.T) {
+	r, svc := setupNotificationRuleRouter(t, "admin")
+	days := 60
+	svc.On("CreateRule", &services.CreateNotificationRuleRequest{
+		Category:             "safety",
+		DaysBeforeExpiration: &days,
+		RecipientRoles:       []string{"holder", "admin"},
+	}, mock.Anything).Return(&models.NotificationRule{ID: uuid.New(), Category: "safety", DaysBeforeExpiration: days}, nil)
+
+	w := performRequest(r, http.MethodPost, "/api/v1/notification-rules",
+		`{"category":"safety","days_before_expiration":60,"recipient_roles":["holder","admin"]}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"daysBeforeExpiration":60`)
+}
+
+func TestNotificationRuleHandler_Create_Validation(t *testing.T) {
+	r, _ := setupNotificationRuleRouter(t, "admin")
+
+	for _, body := range []string{
+		`{"category":"safety"}`,
+		`{"days_before_expiration":-1}`,
+		`{"days_before_expiration":7,"category":"finance"}`,
+		`{"days_before_expiration":7,"recipient_roles":["everyone"]}`,
+	} {
+		w := performRequest(r, http.MethodPost, "/api/v1/notification-rules", body)
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+}
+
+func TestNotificationRuleHandler_Create_AcceptsZeroDays(t *testing.T) {
+	r, svc := setupNotificationRuleRouter(t, "admin")
+	svc.On("CreateRule", mock.MatchedBy(func(req *services.CreateNotificationRuleRequest) bool {
+		return *req.DaysBeforeExpiration == 0
+	}), mock.Anything).Return(&models.NotificationRule{ID: uuid.New()}, nil)
+
+	w := performRequest(r, http.MethodPost, "/api/v1/notification-rules", `{"days_before_expiration":0}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestNotificationRuleHandler_CreateErrors(t *testing.T) {
+	for err, status := range map[error]int{
+		services.ErrNotificationRuleExists:    http.StatusConflict,
+		services.ErrInvalidNotificationScope:  http.StatusBadRequest,
+		services.ErrUnknownEmailTemplate:      http.StatusBadRequest,
+		services.ErrCertificationTypeNotFound: http.StatusNotFound,
+		services.ErrInactiveCertificationType: http.StatusBadRequest,
+	} {
+		r, svc := setupNotificationRuleRouter(t, "admin")
+		svc.On("CreateRule", mock.Anything, mock.Anything).Return(nil, err)
+
+		w := performRequest(r, http.MethodPost, "/api/v1/notification-rules", `{"days_before_expiration":7}`)
+
+		assert.Equal(t, status, w.Code, err.Error())
+	}
+}
+
+func TestNotificationRuleHandler_RequiresAdmin(t *testing.T) {
+	r, _ := setupNotificationRuleRouter(t, "user")
+
+	w := performRequest(r, http.MethodGet, "/api/v1/notification-rules", "")
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestNotificationRuleHandler_List(t *testing.T) {
+	r, svc := setupNotificationRuleRouter(t, "admin")
+	svc.On("ListRules", &services.ListNotificationRulesRequest{Scope: "category", Category: "safety"}).
+		Return(&services.NotificationRuleListResponse{Items: []models.NotificationRule{}}, nil)
+
+	w := performRequest(r, http.MethodGet, "/api/v1/notification-rules?scope=category&category=safety", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = performRequest(r, http.MethodGet, "/api/v1/notification-rules?scope=person", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestNotificationRuleHandler_Update(t *testing.T) {
+	r, svc := setupNotificationRuleRouter(t, "admin")
+	id := uuid.New()
+	active := false
+	svc.On("UpdateRule", id, &services.UpdateNotificationRuleRequest{IsActive: &active}).
+		Return(&models.NotificationRule{ID: id}, nil)
+
+	w := performRequest(r, http.MethodPut, "/api/v1/notification-rules/"+id.String(), `{"is_active":false}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestNotificationRuleHandler_Delete_NotFound(t *testing.T) {
+	r, svc := setupNotificationRuleRouter(t, "admin")
+	id := uuid.New()
+	svc.On("DeleteRule", id).Return(services.ErrNotificationRuleNotFound)
+
+	w := performRequest(r, http.MethodDelete, "/api/v1/notification-rules/"+id.String(), "")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestNotificationRuleHandler_ForCertification(t *testing.T) {
+	r, svc := setupNotificationRuleRouter(t, "user")
+	certID := uuid.New()
+	svc.On("ResolveForCertification", certID).Return(&services.EffectiveNotificationRules{
+		Scope: models.NotificationScopeCategory,
+		Rules: []models.NotificationRule{{DaysBeforeExpiration: 60}},
+	}, nil)
+
+	w := performRequest(r, http.MethodGet, "/api/v1/certifications/"+certID.String()+"/notification-rules", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"scope":"category"`)
+
+	missing := uuid.New()
+	svc.On("ResolveForCertification", missing).Return(nil, services.ErrCertificationNotFound)
+	w = performRequest(r, http.MethodGet, "/api/v1/certifications/"+missing.String()+"/notification-rules", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
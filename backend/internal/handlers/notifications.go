@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"net/http"
+
+	"certitrack/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler struct {
+	outboxService services.NotificationOutboxService
+}
+
+func NewNotificationHandler(outboxService services.NotificationOutboxService) *NotificationHandler {
+	return &NotificationHandler{
+		outboxService: outboxService,
+	}
+}
+
+// List returns scheduled notifications; ?status=dead lists the dead letters.
+func (h *NotificationHandler) List(c *gin.Context) {
+	var req services.ListNotificationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	response, err := h.outboxService.ListNotifications(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list notifications",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notifications retrieved successfully",
+		"data":    response,
+	})
+}
+
+// Requeue gives a dead-lettered notification a fresh set of delivery attempts.
+func (h *NotificationHandler) Requeue(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	notification, err := h.outboxService.RequeueNotification(id)
+	if err != nil {
+		switch err {
+		case services.ErrNotificationNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Notification not found",
+			})
+		case services.ErrNotificationNotDead:
+			c.JSON(http.StatusConflict, gin.H{
+				"error": "Only dead-lettered notifications can be requeued",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to requeue notification",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Notification requeued successfully",
+		"data":    notification,
+	})
+}
@@ -0,0 +1,104 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"certitrack/internal/handlers"
+	"certitrack/internal/middleware"
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+	"certitrack/testutils/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupNotificationRouter mirrors the real routing: the outbox is admin-only.
+func setupNotificationRouter(t *testing.T, role string) (*gin.Engine, *mocks.MockNotificationOutboxService) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	svc := new(mocks.MockNotificationOutboxService)
+	handler := handlers.NewNotificationHandler(svc)
+	mw := middleware.NewMiddleware(new(mocks.MockAuthService))
+
+	r := gin.New()
+	protected := r.Group("/api/v1")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user", &models.User{ID: uuid.New(), Role: role})
+		c.Set("userRole", role)
+		c.Next()
+	})
+	notifications := protected.Group("/notifications", mw.AdminMiddleware())
+	notifications.GET("", handler.List)
+	notifications.POST("/:id/requeue", handler.Requeue)
+
+	t.Cleanup(func() { svc.AssertExpectations(t) })
+	return r, svc
+}
+
+func performRequest(r *gin.Engine, method, path string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestNotificationHandler_ListDeadLetters(t *testing.T) {
+	r, svc := setupNotificationRouter(t, "admin")
+	svc.On("ListNotifications", &services.ListNotificationsRequest{Status: "dead"}).
+		Return(&services.NotificationListResponse{
+			Items: []models.Notification{{Status: models.NotificationStatusDead, ErrorMessage: "mailbox unavailable"}},
+		}, nil)
+
+	w := performRequest(r, http.MethodGet, "/api/v1/notifications?status=dead")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"errorMessage":"mailbox unavailable"`)
+}
+
+func TestNotificationHandler_ListValidation(t *testing.T) {
+	r, _ := setupNotificationRouter(t, "admin")
+
+	for _, query := range []string{"?status=lost", "?certificationId=42"} {
+		w := performRequest(r, http.MethodGet, "/api/v1/notifications"+query)
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
+
+func TestNotificationHandler_Requeue(t *testing.T) {
+	r, svc := setupNotificationRouter(t, "admin")
+	id := uuid.New()
+	svc.On("RequeueNotification", id).Return(&models.Notification{ID: id, Status: models.NotificationStatusPending}, nil)
+
+	w := performRequest(r, http.MethodPost, "/api/v1/notifications/"+id.String()+"/requeue")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"pending"`)
+}
+
+func TestNotificationHandler_RequeueErrors(t *testing.T) {
+	for err, status := range map[error]int{
+		services.ErrNotificationNotFound: http.StatusNotFound,
+		services.ErrNotificationNotDead:  http.StatusConflict,
+	} {
+		r, svc := setupNotificationRouter(t, "admin")
+		id := uuid.New()
+		svc.On("RequeueNotification", id).Return(nil, err)
+
+		w := performRequest(r, http.MethodPost, "/api/v1/notifications/"+id.String()+"/requeue")
+
+		assert.Equal(t, status, w.Code, err.Error())
+	}
+}
+
+func TestNotificationHandler_AdminOnly(t *testing.T) {
+	r, _ := setupNotificationRouter(t, "user")
+
+	w := performRequest(r, http.MethodPost, "/api/v1/notifications/"+uuid.NewString()+"/requeue")
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
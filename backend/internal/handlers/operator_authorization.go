@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"net/http"
+
+	"certitrack/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OperatorAuthorizationHandler struct {
+	authorizationService services.OperatorAuthorizationService
+}
+
+func NewOperatorAuthorizationHandler(authorizationService services.OperatorAuthorizationService) *OperatorAuthorizationHandler {
+	return &OperatorAuthorizationHandler{
+		authorizationService: authorizationService,
+	}
+}
+
+// ForEquipment lists the people currently certified to operate the equipment.
+func (h *OperatorAuthorizationHandler) ForEquipment(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req services.PageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	response, err := h.authorizationService.ListAuthorizedOperators(id, &req)
+	if err != nil {
+		switch err {
+		case services.ErrEquipmentNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Equipment not found",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to list authorized operators",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Authorized operators retrieved successfully",
+		"data":    response,
+	})
+}
+
+// ForPerson lists the equipment a person is currently certified to operate.
+func (h *OperatorAuthorizationHandler) ForPerson(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req services.PageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	response, err := h.authorizationService.ListAuthorizedEquipment(id, &req)
+	if err != nil {
+		switch err {
+		case services.ErrPersonNotFound:
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "Person not found",
+			})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error": "Failed to list authorized equipment",
+			})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Authorized equipment retrieved successfully",
+		"data":    response,
+	})
+}
@@ -0,0 +1,101 @@
+package handlers_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"certitrack/internal/handlers"
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+	"certitrack/testutils/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupOperatorAuthorizationRouter(t *testing.T) (*gin.Engine, *mocks.MockOperatorAuthorizationService) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	svc := new(mocks.MockOperatorAuthorizationService)
+	h := handlers.NewOperatorAuthorizationHandler(svc)
+
+	r := gin.New()
+	protected := r.Group("/api/v1")
+	protected.Use(func(c *gin.Context) {
+		c.Set("user", &models.User{ID: uuid.New(), Role: "user"})
+		c.Set("userRole", "user")
+		c.Next()
+	})
+	{
+		protected.GET("/equipment/:id/authorized-operators", h.ForEquipment)
+		protected.GET("/people/:id/authorized-equipment", h.ForPerson)
+	}
+
+	t.Cleanup(func() { svc.AssertExpectations(t) })
+	return r, svc
+}
+
+func performRequest(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestOperatorAuthorizationHandler_ForEquipment(t *testing.T) {
+	r, svc := setupOperatorAuthorizationRouter(t)
+	equipmentID := uuid.New()
+	svc.On("ListAuthorizedOperators", equipmentID, &services.PageRequest{Limit: 10}).Return(&services.AuthorizedOperatorListResponse{
+		OperatorTypes: []models.CertificationType{{Name: "Forklift Licence"}},
+		Items: []services.AuthorizedOperator{{
+			Person: &models.Person{ID: uuid.New(), LastName: "Baker"},
+		}},
+	}, nil)
+
+	w := performRequest(r, http.MethodGet, "/api/v1/equipment/"+equipmentID.String()+"/authorized-operators?limit=10", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"lastName":"Baker"`)
+}
+
+func TestOperatorAuthorizationHandler_ForEquipment_NotFound(t *testing.T) {
+	r, svc := setupOperatorAuthorizationRouter(t)
+	equipmentID := uuid.New()
+	svc.On("ListAuthorizedOperators", equipmentID, &services.PageRequest{}).Return(nil, services.ErrEquipmentNotFound)
+
+	w := performRequest(r, http.MethodGet, "/api/v1/equipment/"+equipmentID.String()+"/authorized-operators", "")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestOperatorAuthorizationHandler_ForPerson(t *testing.T) {
+	r, svc := setupOperatorAuthorizationRouter(t)
+	personID := uuid.New()
+	svc.On("ListAuthorizedEquipment", personID, &services.PageRequest{}).Return(&services.AuthorizedEquipmentListResponse{
+		Items: []services.AuthorizedEquipment{{
+			Equipment: &models.Equipment{ID: uuid.New(), AssetNumber: "FL-1"},
+		}},
+	}, nil)
+
+	w := performRequest(r, http.MethodGet, "/api/v1/people/"+personID.String()+"/authorized-equipment", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"assetNumber":"FL-1"`)
+}
+
+func TestOperatorAuthorizationHandler_ForPerson_Errors(t *testing.T) {
+	r, svc := setupOperatorAuthorizationRouter(t)
+	personID := uuid.New()
+	svc.On("ListAuthorizedEquipment", personID, &services.PageRequest{}).Return(nil, services.ErrPersonNotFound)
+
+	w := performRequest(r, http.MethodGet, "/api/v1/people/"+personID.String()+"/authorized-equipment", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	w = performRequest(r, http.MethodGet, "/api/v1/people/not-a-uuid/authorized-equipment", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"net/http"
+
+	"certitrack/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PeopleHandler struct {
+	personService services.PersonService
+}
+
+func NewPeopleHandler(personService services.PersonService) *PeopleHandler {
+	return &PeopleHandler{
+		personService: personService,
+	}
+}
+
+func (h *PeopleHandler) List(c *gin.Context) {
+	var req services.ListPeopleRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	response, err := h.personService.ListPeople(&req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to list people",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "People retrieved successfully",
+		"data":    response,
+	})
+}
+
+func (h *PeopleHandler) Create(c *gin.Context) {
+	var req services.CreatePersonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	person, err := h.personService.CreatePerson(&req, currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Failed to create person")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Person created successfully",
+		"data":    person,
+	})
+}
+
+func (h *PeopleHandler) Get(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	person, err := h.personService.GetPerson(id)
+	if err != nil {
+		h.handleError(c, err, "Failed to get person")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Person retrieved successfully",
+		"data":    person,
+	})
+}
+
+func (h *PeopleHandler) Update(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req services.UpdatePersonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		invalidRequest(c, err)
+		return
+	}
+
+	person, err := h.personService.UpdatePerson(id, &req, currentUserID(c))
+	if err != nil {
+		h.handleError(c, err, "Failed to update person")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Person updated successfully",
+		"data":    person,
+	})
+}
+
+func (h *PeopleHandler) Delete(c *gin.Context) {
+	id, ok := parseUUIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.personService.DeactivatePerson(id, currentUserID(c)); err != nil {
+		h.handleError(c, err, "Failed to delete person")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Person deactivated successfully",
+	})
+}
+
+func (h *PeopleHandler) handleError(c *gin.Context, err error, fallback string) {
+	switch err {
+	case services.ErrPersonNotFound:
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "Person not found",
+		})
+	case services.ErrEmployeeIDExists:
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Person with this employee ID already exists",
+		})
+	case services.ErrInvalidPersonData:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid person data",
+		})
+	case services.ErrInvalidSupervisor:
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Supervisor must be another existing person",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": fallback,
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"certitrack/internal/handlers"
+	"certitrack/internal/models"
+	"certitrack/testutils/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+const peoplePath = "/api/v1/people"
+
+var testUser = &models.User{ID: uuid.New(), Role: "user", IsActive: true}
+
+func setupPeopleRouter(t *testing.T) (*gin.Engine, *mocks.MockPersonService) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	svc := new(mocks.MockPersonService)
+	handler := handlers.NewPeopleHandler(svc)
+
+	r := gin.New()
+	v1 := r.Group("/api/v1")
+	v1.Use(func(c *gin.Context) {
+		c.Set("user", testUser)
+		c.Set("userID", testUser.ID.String())
+		c.Set("userRole", testUser.Role)
+		c.Next()
+	})
+	{
+		people := v1.Group("/people")
+		people.GET("", handler.List)
+		people.POST("", handler.Create)
+		people.GET("/:id", handler.Get)
+		people.PUT("/:id", handler.Update)
+		people.DELETE("/:id", handler.Delete)
+	}
+
+	t.Cleanup(func() { svc.AssertExpectations(t) })
+	return r, svc
+}
+
+func performRequest(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func decodeResponse(t *testing.T, w *httptest.ResponseRecorder) map[string]interface{} {
+	t.Helper()
+	var response map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response
+}
@@ -0,0 +1,96 @@
+package handlers_test
+
+import (
+	"net/http"
+	"testing"
+
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPeopleHandler_Create_Success(t *testing.T) {
+	r, svc := setupPeopleRouter(t)
+
+	person := &models.Person{ID: uuid.New(), FirstName: "Jane", LastName: "Smith", IsActive: true}
+	svc.On("CreatePerson", mock.MatchedBy(func(req *services.CreatePersonRequest) bool {
+		return req.FirstName == "Jane" && req.HireDate == "2023-02-01"
+	}), testUser.ID).Return(person, nil)
+
+	w := performRequest(r, http.MethodPost, peoplePath,
+		`{"employee_id":"EMP002","first_name":"Jane","last_name":"Smith","hire_date":"2023-02-01"}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	response := decodeResponse(t, w)
+	assert.Equal(t, "Person created successfully", response["message"])
+}
+
+func TestPeopleHandler_Create_InvalidPayload(t *testing.T) {
+	r, _ := setupPeopleRouter(t)
+
+	w := performRequest(r, http.MethodPost, peoplePath, `{"first_name":"Jane","hire_date":"01-02-2023"}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "Invalid request data", decodeResponse(t, w)["error"])
+}
+
+func TestPeopleHandler_Create_DuplicateEmployeeID(t *testing.T) {
+	r, svc := setupPeopleRouter(t)
+	svc.On("CreatePerson", mock.Anything, testUser.ID).Return(nil, services.ErrEmployeeIDExists)
+
+	w := performRequest(r, http.MethodPost, peoplePath, `{"employee_id":"EMP001","first_name":"Jane","last_name":"Smith"}`)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestPeopleHandler_Create_InvalidSupervisor(t *testing.T) {
+	r, svc := setupPeopleRouter(t)
+	svc.On("CreatePerson", mock.Anything, testUser.ID).Return(nil, services.ErrInvalidSupervisor)
+
+	w := performRequest(r, http.MethodPost, peoplePath, `{"employee_id":"EMP001","first_name":"Jane","last_name":"Smith","supervisor_id":"7f0c2d1e-5b3a-4c8e-9a6f-2e1d0b9c8a7f"}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestPeopleHandler_Get_NotFound(t *testing.T) {
+	r, svc := setupPeopleRouter(t)
+	id := uuid.New()
+	svc.On("GetPerson", id).Return(nil, services.ErrPersonNotFound)
+
+	w := performRequest(r, http.MethodGet, peoplePath+"/"+id.String(), "")
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "Person not found", decodeResponse(t, w)["error"])
+}
+
+func TestPeopleHandler_Get_InvalidID(t *testing.T) {
+	r, _ := setupPeopleRouter(t)
+
+	w := performRequest(r, http.MethodGet, peoplePath+"/not-a-uuid", "")
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestPeopleHandler_List(t *testing.T) {
+	r, svc := setupPeopleRouter(t)
+	svc.On("ListPeople", mock.MatchedBy(func(req *services.ListPeopleRequest) bool {
+		return req.Department == "HR" && req.Page == 2
+	})).Return(&services.PersonListResponse{Items: []models.Person{}}, nil)
+
+	w := performRequest(r, http.MethodGet, peoplePath+"?department=HR&page=2", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestPeopleHandler_Delete(t *testing.T) {
+	r, svc := setupPeopleRouter(t)
+	id := uuid.New()
+	svc.On("DeactivatePerson", id, testUser.ID).Return(nil)
+
+	w := performRequest(r, http.MethodDelete, peoplePath+"/"+id.String(), "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
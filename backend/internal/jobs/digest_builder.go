@@ -0,0 +1,74 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"certitrack/internal/config"
+	"certitrack/internal/services"
+)
+
+var ErrDigestInProgress = errors.New("digest build already in progress")
+
+// DigestBuilder periodically collects the reminders waiting for digest
+// recipients into their weekly digests, which the outbox then sends. A
+// recipient gets at most one digest a week however often it runs, so it is
+// safe to run on every replica.
+type DigestBuilder struct {
+	digestService services.NotificationDigestService
+	interval      time.Duration
+	now           func() time.Time
+
+	running  sync.Mutex
+	schedule schedule
+}
+
+func NewDigestBuilder(digestService services.NotificationDigestService, cfg *config.Config) *DigestBuilder {
+	return &DigestBuilder{
+		digestService: digestService,
+		interval:      cfg.Jobs.DigestInterval,
+		now:           time.Now,
+	}
+}
+
+// Start builds digests immediately and then on every interval until Stop is
+// called. A non-positive interval disables the schedule; RunOnce still works.
+func (b *DigestBuilder) Start() {
+	if b.interval <= 0 {
+		log.Println("Digest builder disabled (DIGEST_INTERVAL <= 0)")
+		return
+	}
+
+	b.schedule.start(b.interval, true, b.runScheduled)
+}
+
+// Stop cancels the schedule and waits for an in-flight build to return or
+// for ctx to expire, whichever comes first.
+func (b *DigestBuilder) Stop(ctx context.Context) error {
+	return b.schedule.stop(ctx)
+}
+
+// RunOnce performs a single build. It returns ErrDigestInProgress if a build
+// is already running in this process.
+func (b *DigestBuilder) RunOnce(ctx context.Context) (*services.DigestBuildReport, error) {
+	if !b.running.TryLock() {
+		return nil, ErrDigestInProgress
+	}
+	defer b.running.Unlock()
+
+	return b.digestService.BuildDigests(ctx, b.now())
+}
+
+func (b *DigestBuilder) runScheduled(ctx context.Context) {
+	report, err := b.RunOnce(ctx)
+	switch {
+	case errors.Is(err, ErrDigestInProgress), errors.Is(err, context.Canceled):
+	case err != nil:
+		log.Printf("Digest build failed: %v", err)
+	case report.Built > 0:
+		log.Printf("Digest build queued %d digests", report.Built)
+	}
+}
@@ -0,0 +1,44 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"certitrack/internal/config"
+	"certitrack/internal/services"
+	"certitrack/testutils/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDigestBuilder(interval time.Duration) (*DigestBuilder, *mocks.MockNotificationDigestService) {
+	svc := new(mocks.MockNotificationDigestService)
+	cfg := &config.Config{Jobs: config.JobsConfig{DigestInterval: interval}}
+	return NewDigestBuilder(svc, cfg), svc
+}
+
+func TestDigestBuilder_RunOnceUsesClock(t *testing.T) {
+	builder, svc := newTestDigestBuilder(0)
+	now := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+	builder.now = func() time.Time { return now }
+	want := &services.DigestBuildReport{Recipients: 3, Built: 3}
+	svc.On("BuildDigests", mock.Anything, now).Return(want, nil)
+
+	report, err := builder.RunOnce(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, want, report)
+	svc.AssertExpectations(t)
+}
+
+func TestDigestBuilder_RunOnceRejectsOverlap(t *testing.T) {
+	builder, _ := newTestDigestBuilder(0)
+	builder.running.Lock()
+	defer builder.running.Unlock()
+
+	_, err := builder.RunOnce(context.Background())
+
+	require.ErrorIs(t, err, ErrDigestInProgress)
+}
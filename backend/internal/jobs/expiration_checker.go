@@ -0,0 +1,90 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"certitrack/internal/config"
+	"certitrack/internal/services"
+)
+
+var ErrCheckInProgress = errors.New("expiration check already in progress")
+
+// ExpirationChecker periodically schedules the expiration reminders that
+// have become due and escalates the ones nobody acted on. Both are
+// idempotent, so it is safe to run on every replica and to rerun after a
+// failure.
+type ExpirationChecker struct {
+	notificationService services.NotificationService
+	escalationService   services.NotificationEscalationService
+	interval            time.Duration
+	now                 func() time.Time
+
+	running  sync.Mutex
+	schedule schedule
+}
+
+// ExpirationCheckReport summarizes one run of the expiration checker.
+type ExpirationCheckReport struct {
+	*services.ReminderScheduleReport
+	*services.EscalationReport
+}
+
+func NewExpirationChecker(notificationService services.NotificationService, escalationService services.NotificationEscalationService, cfg *config.Config) *ExpirationChecker {
+	return &ExpirationChecker{
+		notificationService: notificationService,
+		escalationService:   escalationService,
+		interval:            cfg.Jobs.ExpirationCheckInterval,
+		now:                 time.Now,
+	}
+}
+
+// Start runs a check immediately and then on every interval until Stop is
+// called. A non-positive interval disables the schedule; RunOnce still works.
+func (c *ExpirationChecker) Start() {
+	if c.interval <= 0 {
+		log.Println("Expiration checker disabled (EXPIRATION_CHECK_INTERVAL <= 0)")
+		return
+	}
+
+	c.schedule.start(c.interval, true, c.runScheduled)
+}
+
+// Stop cancels the schedule and waits for an in-flight check to return or
+// for ctx to expire, whichever comes first.
+func (c *ExpirationChecker) Stop(ctx context.Context) error {
+	return c.schedule.stop(ctx)
+}
+
+// RunOnce performs a single check, scheduling reminders before escalating.
+// It returns ErrCheckInProgress if a check is already running in this
+// process.
+func (c *ExpirationChecker) RunOnce(ctx context.Context) (*ExpirationCheckReport, error) {
+	if !c.running.TryLock() {
+		return nil, ErrCheckInProgress
+	}
+	defer c.running.Unlock()
+
+	now := c.now()
+	report := &ExpirationCheckReport{}
+	var err error
+	if report.ReminderScheduleReport, err = c.notificationService.ScheduleExpirationReminders(ctx, now); err != nil {
+		return report, err
+	}
+	report.EscalationReport, err = c.escalationService.EscalateUnrenewed(ctx, now)
+	return report, err
+}
+
+func (c *ExpirationChecker) runScheduled(ctx context.Context) {
+	report, err := c.RunOnce(ctx)
+	switch {
+	case errors.Is(err, ErrCheckInProgress), errors.Is(err, context.Canceled):
+	case err != nil:
+		log.Printf("Expiration check failed: %v", err)
+	case report.Scheduled > 0 || report.NoRecipients > 0 || report.Escalated > 0:
+		log.Printf("Expiration check scheduled %d reminders, %d without recipients, and escalated %d", report.Scheduled, report.NoRecipients, report.Escalated)
+	}
+}
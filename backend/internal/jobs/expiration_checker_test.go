@@ -0,0 +1,81 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"certitrack/internal/config"
+	"certitrack/internal/services"
+	"certitrack/testutils/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestChecker(interval time.Duration) (*ExpirationChecker, *mocks.MockNotificationService, *mocks.MockNotificationEscalationService) {
+	svc := new(mocks.MockNotificationService)
+	escalations := new(mocks.MockNotificationEscalationService)
+	cfg := &config.Config{Jobs: config.JobsConfig{ExpirationCheckInterval: interval}}
+	return NewExpirationChecker(svc, escalations, cfg), svc, escalations
+}
+
+func TestExpirationChecker_RunOnceUsesClock(t *testing.T) {
+	checker, svc, escalations := newTestChecker(0)
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	checker.now = func() time.Time { return now }
+	reminders := &services.ReminderScheduleReport{Checked: 4, Scheduled: 2}
+	escalated := &services.EscalationReport{Escalated: 1}
+	svc.On("ScheduleExpirationReminders", mock.Anything, now).Return(reminders, nil)
+	escalations.On("EscalateUnrenewed", mock.Anything, now).Return(escalated, nil)
+
+	report, err := checker.RunOnce(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, &ExpirationCheckReport{reminders, escalated}, report)
+	svc.AssertExpectations(t)
+	escalations.AssertExpectations(t)
+}
+
+func TestExpirationChecker_RunOnceSkipsEscalationOnError(t *testing.T) {
+	checker, svc, escalations := newTestChecker(0)
+	failure := errors.New("database unavailable")
+	svc.On("ScheduleExpirationReminders", mock.Anything, mock.Anything).Return(&services.ReminderScheduleReport{}, failure)
+
+	_, err := checker.RunOnce(context.Background())
+
+	require.ErrorIs(t, err, failure)
+	escalations.AssertNotCalled(t, "EscalateUnrenewed", mock.Anything, mock.Anything)
+}
+
+func TestExpirationChecker_RunOnceRejectsOverlap(t *testing.T) {
+	checker, _, _ := newTestChecker(0)
+	checker.running.Lock()
+	defer checker.running.Unlock()
+
+	_, err := checker.RunOnce(context.Background())
+
+	require.ErrorIs(t, err, ErrCheckInProgress)
+}
+
+func TestExpirationChecker_StartAndStop(t *testing.T) {
+	checker, svc, escalations := newTestChecker(time.Hour)
+	ran := make(chan struct{}, 1)
+	svc.On("ScheduleExpirationReminders", mock.Anything, mock.Anything).
+		Return(&services.ReminderScheduleReport{}, nil)
+	escalations.On("EscalateUnrenewed", mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) { ran <- struct{}{} }).
+		Return(&services.EscalationReport{}, nil)
+
+	checker.Start()
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("check did not run on start")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, checker.Stop(ctx))
+}
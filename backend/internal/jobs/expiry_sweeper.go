@@ -0,0 +1,73 @@
+// Package jobs contains background jobs that run alongside the HTTP server.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"certitrack/internal/config"
+	"certitrack/internal/services"
+)
+
+var ErrSweepInProgress = errors.New("expiry sweep already in progress")
+
+// ExpirySweeper periodically marks active certifications past their
+// expiration date as expired. Scheduled and manual runs never overlap.
+type ExpirySweeper struct {
+	certService services.CertificationService
+	interval    time.Duration
+	now         func() time.Time
+
+	running  sync.Mutex
+	schedule schedule
+}
+
+func NewExpirySweeper(certService services.CertificationService, cfg *config.Config) *ExpirySweeper {
+	return &ExpirySweeper{
+		certService: certService,
+		interval:    cfg.Jobs.ExpirySweepInterval,
+		now:         time.Now,
+	}
+}
+
+// Start runs a sweep immediately and then on every interval until Stop is
+// called. A non-positive interval disables the schedule; RunOnce still works.
+func (s *ExpirySweeper) Start() {
+	if s.interval <= 0 {
+		log.Println("Expiry sweeper disabled (EXPIRY_SWEEP_INTERVAL <= 0)")
+		return
+	}
+
+	s.schedule.start(s.interval, true, s.runScheduled)
+}
+
+// Stop cancels the schedule and waits for an in-flight sweep to return or
+// for ctx to expire, whichever comes first.
+func (s *ExpirySweeper) Stop(ctx context.Context) error {
+	return s.schedule.stop(ctx)
+}
+
+// RunOnce performs a single sweep and returns the number of certifications
+// that were expired. It returns ErrSweepInProgress if a sweep is running.
+func (s *ExpirySweeper) RunOnce(ctx context.Context) (int, error) {
+	if !s.running.TryLock() {
+		return 0, ErrSweepInProgress
+	}
+	defer s.running.Unlock()
+
+	return s.certService.ExpireOverdueCertifications(ctx, s.now())
+}
+
+func (s *ExpirySweeper) runScheduled(ctx context.Context) {
+	expired, err := s.RunOnce(ctx)
+	switch {
+	case errors.Is(err, ErrSweepInProgress), errors.Is(err, context.Canceled):
+	case err != nil:
+		log.Printf("Expiry sweep failed after %d certifications: %v", expired, err)
+	case expired > 0:
+		log.Printf("Expiry sweep marked %d certifications as expired", expired)
+	}
+}
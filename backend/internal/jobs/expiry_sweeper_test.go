@@ -0,0 +1,69 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"certitrack/internal/config"
+	"certitrack/testutils/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSweeper(interval time.Duration) (*ExpirySweeper, *mocks.MockCertificationService) {
+	svc := new(mocks.MockCertificationService)
+	cfg := &config.Config{Jobs: config.JobsConfig{ExpirySweepInterval: interval}}
+	return NewExpirySweeper(svc, cfg), svc
+}
+
+func TestExpirySweeper_RunOnceUsesClock(t *testing.T) {
+	sweeper, svc := newTestSweeper(0)
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	sweeper.now = func() time.Time { return now }
+	svc.On("ExpireOverdueCertifications", mock.Anything, now).Return(3, nil)
+
+	expired, err := sweeper.RunOnce(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, 3, expired)
+	svc.AssertExpectations(t)
+}
+
+func TestExpirySweeper_RunOnceRejectsOverlap(t *testing.T) {
+	sweeper, _ := newTestSweeper(0)
+	sweeper.running.Lock()
+	defer sweeper.running.Unlock()
+
+	_, err := sweeper.RunOnce(context.Background())
+
+	require.ErrorIs(t, err, ErrSweepInProgress)
+}
+
+func TestExpirySweeper_StartAndStop(t *testing.T) {
+	sweeper, svc := newTestSweeper(time.Hour)
+	ran := make(chan struct{}, 1)
+	svc.On("ExpireOverdueCertifications", mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) { ran <- struct{}{} }).
+		Return(0, nil)
+
+	sweeper.Start()
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("sweep did not run on start")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, sweeper.Stop(ctx))
+}
+
+func TestExpirySweeper_StopWithoutStart(t *testing.T) {
+	sweeper, _ := newTestSweeper(0)
+
+	sweeper.Start()
+
+	require.NoError(t, sweeper.Stop(context.Background()))
+}
@@ -0,0 +1,48 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+
+	"certitrack/internal/storage"
+)
+
+var ErrRotationInProgress = errors.New("key rotation already in progress")
+
+// KeyRotator rewraps the data keys of stored documents with the current
+// master key. It is run on demand after ENCRYPTION_KEY changes; until it has
+// finished, the previous key must stay in ENCRYPTION_RETIRED_KEYS.
+type KeyRotator struct {
+	keys storage.KeyStore
+	ring *storage.Keyring
+
+	running sync.Mutex
+}
+
+func NewKeyRotator(keys storage.KeyStore, ring *storage.Keyring) *KeyRotator {
+	return &KeyRotator{
+		keys: keys,
+		ring: ring,
+	}
+}
+
+// RunOnce performs a single rotation pass. It returns ErrRotationInProgress
+// if one is already running.
+func (r *KeyRotator) RunOnce(ctx context.Context) (*storage.RotationReport, error) {
+	if !r.running.TryLock() {
+		return nil, ErrRotationInProgress
+	}
+	defer r.running.Unlock()
+
+	report, err := storage.RotateKeys(ctx, r.keys, r.ring)
+	if report != nil {
+		for _, key := range report.Failed {
+			log.Printf("Key rotation: data key for %s could not be unwrapped", key)
+		}
+		log.Printf("Key rotation rewrapped %d data keys with %q, %d failed",
+			report.Rewrapped, r.ring.CurrentID(), len(report.Failed))
+	}
+	return report, err
+}
@@ -0,0 +1,29 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"certitrack/internal/repositories"
+	"certitrack/internal/storage"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyRotator_RunOnceRequiresEncryption(t *testing.T) {
+	rotator := NewKeyRotator(repositories.NewMockEncryptionKeyRepository(), &storage.Keyring{})
+
+	_, err := rotator.RunOnce(context.Background())
+
+	require.ErrorIs(t, err, storage.ErrEncryptionDisabled)
+}
+
+func TestKeyRotator_RunOnceRejectsOverlap(t *testing.T) {
+	rotator := NewKeyRotator(repositories.NewMockEncryptionKeyRepository(), &storage.Keyring{})
+	rotator.running.Lock()
+	defer rotator.running.Unlock()
+
+	_, err := rotator.RunOnce(context.Background())
+
+	require.ErrorIs(t, err, ErrRotationInProgress)
+}
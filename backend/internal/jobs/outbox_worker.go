@@ -0,0 +1,97 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"certitrack/internal/config"
+	"certitrack/internal/services"
+)
+
+var ErrOutboxInProgress = errors.New("outbox delivery already in progress")
+
+// OutboxWorker periodically sends the notification emails that are due.
+// Notifications are claimed before they are sent, so several replicas can
+// run the worker without sending an email twice.
+type OutboxWorker struct {
+	deliverers []deliverer
+	interval   time.Duration
+	now        func() time.Time
+
+	running  sync.Mutex
+	schedule schedule
+}
+
+// deliverer sends one batch of the outbox emails of one kind that are due.
+type deliverer interface {
+	DeliverDue(ctx context.Context, now time.Time) (*services.DeliveryReport, error)
+}
+
+func NewOutboxWorker(outboxService services.NotificationOutboxService, digestService services.NotificationDigestService, escalationService services.NotificationEscalationService, cfg *config.Config) *OutboxWorker {
+	return &OutboxWorker{
+		deliverers: []deliverer{outboxService, digestService, escalationService},
+		interval:   cfg.Jobs.OutboxInterval,
+		now:        time.Now,
+	}
+}
+
+// Start delivers due notifications immediately and then on every interval
+// until Stop is called. A non-positive interval disables the schedule;
+// RunOnce still works.
+func (w *OutboxWorker) Start() {
+	if w.interval <= 0 {
+		log.Println("Outbox worker disabled (OUTBOX_INTERVAL <= 0)")
+		return
+	}
+
+	w.schedule.start(w.interval, true, w.runScheduled)
+}
+
+// Stop cancels the schedule and waits for an in-flight delivery to return
+// or for ctx to expire, whichever comes first.
+func (w *OutboxWorker) Stop(ctx context.Context) error {
+	return w.schedule.stop(ctx)
+}
+
+// RunOnce delivers due notifications batch by batch, a batch of every kind
+// in turn, until none are left. It returns ErrOutboxInProgress if a
+// delivery is already running.
+func (w *OutboxWorker) RunOnce(ctx context.Context) (*services.DeliveryReport, error) {
+	if !w.running.TryLock() {
+		return nil, ErrOutboxInProgress
+	}
+	defer w.running.Unlock()
+
+	total := &services.DeliveryReport{}
+	for {
+		claimed := 0
+		for _, d := range w.deliverers {
+			report, err := d.DeliverDue(ctx, w.now())
+			if report != nil {
+				total.Add(report)
+				claimed += report.Claimed
+			}
+			if err != nil {
+				return total, err
+			}
+		}
+		if claimed == 0 {
+			return total, nil
+		}
+	}
+}
+
+func (w *OutboxWorker) runScheduled(ctx context.Context) {
+	report, err := w.RunOnce(ctx)
+	switch {
+	case errors.Is(err, ErrOutboxInProgress), errors.Is(err, context.Canceled):
+	case err != nil:
+		log.Printf("Outbox delivery failed: %v", err)
+	case report.Claimed > 0:
+		log.Printf("Outbox sent %d notifications: %d retrying, %d dead-lettered, %d cancelled",
+			report.Sent, report.Retrying, report.DeadLettered, report.Cancelled)
+	}
+}
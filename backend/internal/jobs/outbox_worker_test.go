@@ -0,0 +1,67 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"certitrack/internal/config"
+	"certitrack/internal/services"
+	"certitrack/testutils/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOutboxWorker(interval time.Duration) (*OutboxWorker, *mocks.MockNotificationOutboxService, *mocks.MockNotificationDigestService, *mocks.MockNotificationEscalationService) {
+	svc := new(mocks.MockNotificationOutboxService)
+	digests := new(mocks.MockNotificationDigestService)
+	escalations := new(mocks.MockNotificationEscalationService)
+	cfg := &config.Config{Jobs: config.JobsConfig{OutboxInterval: interval}}
+	return NewOutboxWorker(svc, digests, escalations, cfg), svc, digests, escalations
+}
+
+func TestOutboxWorker_RunOnceDrainsBatches(t *testing.T) {
+	worker, svc, digests, escalations := newTestOutboxWorker(0)
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	worker.now = func() time.Time { return now }
+	svc.On("DeliverDue", mock.Anything, now).Return(&services.DeliveryReport{Claimed: 50, Sent: 48, Retrying: 2}, nil).Once()
+	svc.On("DeliverDue", mock.Anything, now).Return(&services.DeliveryReport{Claimed: 3, Sent: 2, DeadLettered: 1}, nil).Once()
+	svc.On("DeliverDue", mock.Anything, now).Return(&services.DeliveryReport{}, nil).Once()
+	digests.On("DeliverDue", mock.Anything, now).Return(&services.DeliveryReport{Claimed: 2, Sent: 2}, nil).Once()
+	digests.On("DeliverDue", mock.Anything, now).Return(&services.DeliveryReport{}, nil).Twice()
+	escalations.On("DeliverDue", mock.Anything, now).Return(&services.DeliveryReport{Claimed: 1, Cancelled: 1}, nil).Once()
+	escalations.On("DeliverDue", mock.Anything, now).Return(&services.DeliveryReport{}, nil).Twice()
+
+	report, err := worker.RunOnce(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, &services.DeliveryReport{Claimed: 56, Sent: 52, Retrying: 2, DeadLettered: 1, Cancelled: 1}, report)
+	svc.AssertExpectations(t)
+	digests.AssertExpectations(t)
+	escalations.AssertExpectations(t)
+}
+
+func TestOutboxWorker_RunOnceStopsOnError(t *testing.T) {
+	worker, svc, digests, escalations := newTestOutboxWorker(0)
+	failure := errors.New("database unavailable")
+	svc.On("DeliverDue", mock.Anything, mock.Anything).Return(&services.DeliveryReport{}, failure).Once()
+
+	_, err := worker.RunOnce(context.Background())
+
+	require.ErrorIs(t, err, failure)
+	svc.AssertExpectations(t)
+	digests.AssertNotCalled(t, "DeliverDue", mock.Anything, mock.Anything)
+	escalations.AssertNotCalled(t, "DeliverDue", mock.Anything, mock.Anything)
+}
+
+func TestOutboxWorker_RunOnceRejectsOverlap(t *testing.T) {
+	worker, _, _, _ := newTestOutboxWorker(0)
+	worker.running.Lock()
+	defer worker.running.Unlock()
+
+	_, err := worker.RunOnce(context.Background())
+
+	require.ErrorIs(t, err, ErrOutboxInProgress)
+}
@@ -0,0 +1,55 @@
+package jobs
+
+import (
+	"context"
+	"time"
+)
+
+// schedule runs a function on a fixed interval in a background goroutine.
+type schedule struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// start calls run every interval until stop is called. When immediate is
+// set the first run happens right away instead of after one interval.
+func (s *schedule) start(interval time.Duration, immediate bool, run func(ctx context.Context)) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		if immediate {
+			run(ctx)
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run(ctx)
+			}
+		}
+	}()
+}
+
+// stop cancels the schedule and waits for an in-flight run to return or for
+// ctx to expire, whichever comes first.
+func (s *schedule) stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,84 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"certitrack/internal/config"
+	"certitrack/internal/services"
+)
+
+var ErrVerifyInProgress = errors.New("storage verification already in progress")
+
+// StorageVerifier periodically re-reads every stored document file and
+// reports files that are missing or no longer match their SHA-256.
+type StorageVerifier struct {
+	documentService services.DocumentService
+	interval        time.Duration
+
+	running  sync.Mutex
+	schedule schedule
+}
+
+func NewStorageVerifier(documentService services.DocumentService, cfg *config.Config) *StorageVerifier {
+	return &StorageVerifier{
+		documentService: documentService,
+		interval:        cfg.Jobs.StorageVerifyInterval,
+	}
+}
+
+// Start verifies storage on every interval until Stop is called. Unlike the
+// expiry sweep it does not run at startup, since it reads every file. A
+// non-positive interval disables the schedule; RunOnce still works.
+func (v *StorageVerifier) Start() {
+	if v.interval <= 0 {
+		log.Println("Storage verifier disabled (STORAGE_VERIFY_INTERVAL <= 0)")
+		return
+	}
+
+	v.schedule.start(v.interval, false, v.runScheduled)
+}
+
+// Stop cancels the schedule and waits for an in-flight verification to
+// return or for ctx to expire, whichever comes first.
+func (v *StorageVerifier) Stop(ctx context.Context) error {
+	return v.schedule.stop(ctx)
+}
+
+// RunOnce performs a single verification pass. It returns
+// ErrVerifyInProgress if one is already running.
+func (v *StorageVerifier) RunOnce(ctx context.Context) (*services.StorageVerifyReport, error) {
+	if !v.running.TryLock() {
+		return nil, ErrVerifyInProgress
+	}
+	defer v.running.Unlock()
+
+	report, err := v.documentService.VerifyStorage(ctx)
+	if report != nil {
+		logStorageReport(report)
+	}
+	return report, err
+}
+
+func (v *StorageVerifier) runScheduled(ctx context.Context) {
+	_, err := v.RunOnce(ctx)
+	switch {
+	case errors.Is(err, ErrVerifyInProgress), errors.Is(err, context.Canceled):
+	case err != nil:
+		log.Printf("Storage verification failed: %v", err)
+	}
+}
+
+func logStorageReport(report *services.StorageVerifyReport) {
+	for _, issue := range report.Missing {
+		log.Printf("Storage verification: %s is missing (documents %v)", issue.FilePath, issue.DocumentIDs)
+	}
+	for _, issue := range report.Corrupted {
+		log.Printf("Storage verification: %s does not match sha256 %s (documents %v)", issue.FilePath, issue.ContentHash, issue.DocumentIDs)
+	}
+	log.Printf("Storage verification checked %d files: %d missing, %d corrupted",
+		report.Checked, len(report.Missing), len(report.Corrupted))
+}
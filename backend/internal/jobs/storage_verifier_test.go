@@ -0,0 +1,37 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+
+	"certitrack/internal/config"
+	"certitrack/internal/services"
+	"certitrack/testutils/mocks"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageVerifier_RunOnce(t *testing.T) {
+	svc := new(mocks.MockDocumentService)
+	verifier := NewStorageVerifier(svc, &config.Config{})
+	report := &services.StorageVerifyReport{Checked: 2, Missing: []services.StorageIssue{{FilePath: "sha256/ab/ab"}}}
+	svc.On("VerifyStorage", mock.Anything).Return(report, nil)
+
+	got, err := verifier.RunOnce(context.Background())
+
+	require.NoError(t, err)
+	require.Same(t, report, got)
+	require.False(t, got.Healthy())
+	svc.AssertExpectations(t)
+}
+
+func TestStorageVerifier_RunOnceRejectsOverlap(t *testing.T) {
+	verifier := NewStorageVerifier(new(mocks.MockDocumentService), &config.Config{})
+	verifier.running.Lock()
+	defer verifier.running.Unlock()
+
+	_, err := verifier.RunOnce(context.Background())
+
+	require.ErrorIs(t, err, ErrVerifyInProgress)
+}
@@ -0,0 +1,113 @@
+package mailer_test
+
+import (
+	"bytes"
+	"html/template"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"certitrack/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultExpirationTemplate(t *testing.T) {
+	_, filename, _, _ := runtime.Caller(0)
+	templatePath := filepath.Join(filepath.Dir(filename), "templates/default_expiration.html")
+	tmpl, err := template.ParseFiles(templatePath)
+	require.NoError(t, err)
+
+	render := func(daysLeft int) string {
+		var buf bytes.Buffer
+		require.NoError(t, tmpl.Execute(&buf, map[string]any{
+			"Subject": "Forklift for Dana Reyes expires on 2025-03-15",
+			"Data": map[string]any{
+				"CertificationType": "Forklift",
+				"CertificateNumber": "FL-1234",
+				"Holder":            "Dana Reyes",
+				"ExpirationDate":    "2025-03-15",
+				"DaysLeft":          daysLeft,
+			},
+		}))
+		return buf.String()
+	}
+
+	body := render(5)
+	assert.Contains(t, body, "expires in 5 days")
+	assert.Contains(t, body, "Forklift")
+	assert.Contains(t, body, "Dana Reyes")
+	assert.Contains(t, body, "FL-1234")
+	assert.Contains(t, body, "2025-03-15")
+	assert.Contains(t, render(0), "expires today")
+}
+
+// Notification rules may only name reminder templates that ship with the
+// mailer.
+func TestReminderEmailTemplatesExist(t *testing.T) {
+	_, filename, _, _ := runtime.Caller(0)
+	for _, name := range models.ReminderEmailTemplates {
+		_, err := template.ParseFiles(filepath.Join(filepath.Dir(filename), "templates", name+".html"))
+		require.NoError(t, err, name)
+	}
+}
+
+func TestExpirationDigestTemplate(t *testing.T) {
+	_, filename, _, _ := runtime.Caller(0)
+	templatePath := filepath.Join(filepath.Dir(filename), "templates/expiration_digest.html")
+	tmpl, err := template.ParseFiles(templatePath)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, tmpl.Execute(&buf, map[string]any{
+		"Subject": "2 certifications expiring soon",
+		"Data": map[string]any{
+			"WeekOf": "2025-03-10",
+			"Items": []map[string]any{
+				{"CertificationType": "Forklift", "CertificateNumber": "FL-1234", "Holder": "Dana Reyes", "ExpirationDate": "2025-03-10", "DaysLeft": 0},
+				{"CertificationType": "First Aid", "CertificateNumber": "", "Holder": "Sam Ortiz", "ExpirationDate": "2025-04-09", "DaysLeft": 30},
+			},
+		},
+	}))
+	body := buf.String()
+
+	assert.Contains(t, body, "week of 2025-03-10")
+	assert.Contains(t, body, "FL-1234")
+	assert.Contains(t, body, "Today")
+	assert.Contains(t, body, "First Aid")
+	assert.Contains(t, body, "Sam Ortiz")
+	assert.Contains(t, body, "<td>30</td>")
+}
+
+func TestExpirationEscalationTemplate(t *testing.T) {
+	_, filename, _, _ := runtime.Caller(0)
+	templatePath := filepath.Join(filepath.Dir(filename), "templates/expiration_escalation.html")
+	tmpl, err := template.ParseFiles(templatePath)
+	require.NoError(t, err)
+
+	render := func(target string) string {
+		var buf bytes.Buffer
+		require.NoError(t, tmpl.Execute(&buf, map[string]any{
+			"Subject": "Not renewed: Forklift for Dana Reyes expires on 2025-03-15",
+			"Data": map[string]any{
+				"CertificationType": "Forklift",
+				"CertificateNumber": "FL-1234",
+				"Holder":            "Dana Reyes",
+				"ExpirationDate":    "2025-03-15",
+				"DaysLeft":          5,
+				"Target":            target,
+				"RemindedOn":        "2025-02-13",
+			},
+		}))
+		return buf.String()
+	}
+
+	body := render("supervisor")
+	assert.Contains(t, body, "went out on 2025-02-13")
+	assert.Contains(t, body, "holder's supervisor")
+	assert.Contains(t, body, "expires in 5 days")
+	assert.Contains(t, body, "Dana Reyes")
+	assert.Contains(t, render("department_head"), "head of the holder's department")
+	assert.Contains(t, render("admin"), "as an administrator")
+}
@@ -62,6 +62,7 @@ func (m *Middleware) AuthMiddleware() gin.HandlerFunc {
 		c.Set("user", user)
 		c.Set("userID", user.ID.String())
 		c.Set("userRole", user.Role)
+		c.Set("sessionID", services.TokenSessionID(token))
 
 		c.Next()
 	}
@@ -114,6 +115,7 @@ func (m *Middleware) OptionalAuthMiddleware() gin.HandlerFunc {
 			c.Set("user", user)
 			c.Set("userID", user.ID.String())
 			c.Set("userRole", user.Role)
+			c.Set("sessionID", services.TokenSessionID(token))
 		}
 
 		c.Next()
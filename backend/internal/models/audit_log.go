@@ -0,0 +1,70 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	AuditActionVirusDetected = "VIRUS_DETECTED"
+)
+
+// JSONMap is a map stored in a jsonb column.
+type JSONMap map[string]any
+
+func (m JSONMap) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (m *JSONMap) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into JSONMap", value)
+	}
+	return json.Unmarshal(data, m)
+}
+
+// AuditLog records a security-relevant event against a database record.
+// Rows are append-only.
+type AuditLog struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	RecordTable string     `gorm:"column:table_name;type:varchar(50);not null;index:idx_audit_logs_table_record" json:"tableName"`
+	RecordID    uuid.UUID  `gorm:"type:uuid;not null;index:idx_audit_logs_table_record" json:"recordId"`
+	Action      string     `gorm:"type:varchar(20);not null" json:"action"`
+	OldValues   JSONMap    `gorm:"type:jsonb" json:"oldValues,omitempty"`
+	NewValues   JSONMap    `gorm:"type:jsonb" json:"newValues,omitempty"`
+	UserID      *uuid.UUID `gorm:"type:uuid;index" json:"userId"`
+	CreatedAt   time.Time  `gorm:"index" json:"createdAt"`
+}
+
+func (a *AuditLog) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	CertificationStatusPending    = "pending"
+	CertificationStatusActive     = "active"
+	CertificationStatusExpired    = "expired"
+	CertificationStatusRevoked    = "revoked"
+	CertificationStatusSuperseded = "superseded"
+)
+
+type Certification struct {
+	ID                  uuid.UUID          `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	CertificationTypeID uuid.UUID          `gorm:"type:uuid;not null;index" json:"certificationTypeId"`
+	CertificationType   *CertificationType `gorm:"foreignKey:CertificationTypeID;constraint:OnDelete:RESTRICT" json:"certificationType,omitempty"`
+	// A certification belongs to exactly one person or one piece of equipment.
+	PersonID          *uuid.UUID `gorm:"type:uuid;index;check:check_person_or_equipment,(person_id IS NOT NULL AND equipment_id IS NULL) OR (person_id IS NULL AND equipment_id IS NOT NULL)" json:"personId"`
+	Person            *Person    `gorm:"foreignKey:PersonID" json:"person,omitempty"`
+	EquipmentID       *uuid.UUID `gorm:"type:uuid;index" json:"equipmentId"`
+	Equipment         *Equipment `gorm:"foreignKey:EquipmentID" json:"equipment,omitempty"`
+	CertificateNumber string     `gorm:"type:varchar(100)" json:"certificateNumber"`
+	IssuingAuthority  string     `gorm:"type:varchar(200)" json:"issuingAuthority"`
+	IssueDate         time.Time  `gorm:"type:date;not null" json:"issueDate"`
+	ExpirationDate    time.Time  `gorm:"type:date;not null;index;index:idx_certifications_expiration_status,priority:1;check:check_expiration_after_issue,expiration_date > issue_date" json:"expirationDate"`
+	Status            string     `gorm:"type:varchar(20);not null;default:'active';index;index:idx_certifications_expiration_status,priority:2" json:"status"` // 'pending', 'active', 'expired', 'revoked' or 'superseded'
+	// PredecessorID links a renewal to the certification it replaces. Each
+	// certification can be renewed at most once.
+	PredecessorID *uuid.UUID     `gorm:"type:uuid;uniqueIndex" json:"predecessorId"`
+	Predecessor   *Certification `gorm:"foreignKey:PredecessorID;constraint:OnDelete:SET NULL" json:"-"`
+	Notes         string         `gorm:"type:text" json:"notes"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	UpdatedAt     time.Time      `json:"updatedAt"`
+	CreatedBy     *uuid.UUID     `gorm:"type:uuid" json:"createdBy"`
+	UpdatedBy     *uuid.UUID     `gorm:"type:uuid" json:"updatedBy"`
+
+	StatusHistory []CertificationStatusHistory `gorm:"foreignKey:CertificationID;constraint:OnDelete:CASCADE" json:"statusHistory,omitempty"`
+	Documents     []CertificationDocument      `gorm:"foreignKey:CertificationID;constraint:OnDelete:CASCADE" json:"documents,omitempty"`
+
+	DaysUntilExpiration int `gorm:"-" json:"daysUntilExpiration"`
+}
+
+// certificationTransitions lists the statuses reachable from each status.
+// Revoked certifications can only be reinstated explicitly via activation;
+// superseded is terminal and only reached through renewal.
+var certificationTransitions = map[string][]string{
+	CertificationStatusPending:    {CertificationStatusActive, CertificationStatusExpired, CertificationStatusRevoked},
+	CertificationStatusActive:     {CertificationStatusExpired, CertificationStatusRevoked, CertificationStatusSuperseded},
+	CertificationStatusExpired:    {CertificationStatusActive, CertificationStatusRevoked, CertificationStatusSuperseded},
+	CertificationStatusRevoked:    {CertificationStatusActive},
+	CertificationStatusSuperseded: {},
+}
+
+// CanTransitionTo reports whether the certification may move from its
+// current status to the given one.
+func (c *Certification) CanTransitionTo(status string) bool {
+	for _, allowed := range certificationTransitions[c.Status] {
+		if allowed == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Certification) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+func (c *Certification) AfterFind(tx *gorm.DB) error {
+	c.DaysUntilExpiration = c.DaysUntil(time.Now())
+	return nil
+}
+
+// DaysUntil returns the number of whole days between now and the expiration
+// date. The value is negative once the certification has expired.
+func (c *Certification) DaysUntil(now time.Time) int {
+	return int(CalendarDate(c.ExpirationDate).Sub(CalendarDate(now)).Hours() / 24)
+}
+
+// CalendarDate returns the date of t in its own location as midnight UTC,
+// the form in which expiration dates are stored and compared.
+func CalendarDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Today returns the server's current local date as midnight UTC. Queries
+// filtering on expiration dates use it so they agree with DaysUntil.
+func Today() time.Time {
+	return CalendarDate(time.Now())
+}
+
+// TableName specifies the table name for GORM
+func (Certification) TableName() string {
+	return "certifications"
+}
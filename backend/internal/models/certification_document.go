@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CertificationDocument holds the current version of an uploaded file.
+// Replaced versions are archived in Versions.
+type CertificationDocument struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	CertificationID uuid.UUID  `gorm:"type:uuid;not null;index" json:"certificationId"`
+	FileName        string     `gorm:"type:varchar(255);not null" json:"fileName"`
+	FilePath        string     `gorm:"type:varchar(500);not null" json:"-"` // storage key, never exposed
+	FileSize        int64      `json:"fileSize"`
+	MimeType        string     `gorm:"type:varchar(100)" json:"mimeType"`
+	ContentHash     string     `gorm:"type:char(64);index" json:"sha256"` // hex SHA-256, also the storage key
+	Description     string     `gorm:"type:text" json:"description"`
+	UploadedAt      time.Time  `gorm:"not null;default:now()" json:"uploadedAt"`
+	UploadedBy      *uuid.UUID `gorm:"type:uuid" json:"uploadedBy"`
+	Version         int        `gorm:"not null;default:1" json:"version"`
+
+	Versions []CertificationDocumentVersion `gorm:"foreignKey:DocumentID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+func (d *CertificationDocument) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	if d.UploadedAt.IsZero() {
+		d.UploadedAt = time.Now()
+	}
+	return nil
+}
+
+// CurrentVersion describes the document's current file as a version entry.
+func (d *CertificationDocument) CurrentVersion() CertificationDocumentVersion {
+	return CertificationDocumentVersion{
+		DocumentID:  d.ID,
+		Version:     d.Version,
+		FileName:    d.FileName,
+		FilePath:    d.FilePath,
+		FileSize:    d.FileSize,
+		MimeType:    d.MimeType,
+		ContentHash: d.ContentHash,
+		UploadedAt:  d.UploadedAt,
+		UploadedBy:  d.UploadedBy,
+		Current:     true,
+	}
+}
+
+// TableName specifies the table name for GORM
+func (CertificationDocument) TableName() string {
+	return "certification_documents"
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CertificationDocumentVersion is a file that was replaced by a newer upload
+// of the same document. Archived versions are never modified.
+type CertificationDocumentVersion struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	DocumentID  uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_document_version" json:"documentId"`
+	Version     int        `gorm:"not null;uniqueIndex:idx_document_version" json:"version"`
+	FileName    string     `gorm:"type:varchar(255);not null" json:"fileName"`
+	FilePath    string     `gorm:"type:varchar(500);not null" json:"-"` // storage key, never exposed
+	FileSize    int64      `json:"fileSize"`
+	MimeType    string     `gorm:"type:varchar(100)" json:"mimeType"`
+	ContentHash string     `gorm:"type:char(64);index" json:"sha256"`
+	UploadedAt  time.Time  `gorm:"not null" json:"uploadedAt"`
+	UploadedBy  *uuid.UUID `gorm:"type:uuid" json:"uploadedBy"`
+
+	// Current is set on the entry built from the document itself.
+	Current bool `gorm:"-" json:"current"`
+}
+
+func (v *CertificationDocumentVersion) BeforeCreate(tx *gorm.DB) error {
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (CertificationDocumentVersion) TableName() string {
+	return "certification_document_versions"
+}
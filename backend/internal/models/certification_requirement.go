@@ -0,0 +1,46 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CertificationRequirement states that people in a position, a department, or
+// a position within a department must hold a certification of a given type.
+// An empty Position or Department matches any value.
+type CertificationRequirement struct {
+	ID                  uuid.UUID          `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	CertificationTypeID uuid.UUID          `gorm:"type:uuid;not null;uniqueIndex:idx_certification_requirements_scope,priority:1" json:"certificationTypeId"`
+	CertificationType   *CertificationType `gorm:"foreignKey:CertificationTypeID;constraint:OnDelete:CASCADE" json:"certificationType,omitempty"`
+	Position            string             `gorm:"type:varchar(100);not null;default:'';index;uniqueIndex:idx_certification_requirements_scope,priority:2;check:check_requirement_scope,position <> '' OR department <> ''" json:"position"`
+	Department          string             `gorm:"type:varchar(100);not null;default:'';index;uniqueIndex:idx_certification_requirements_scope,priority:3" json:"department"`
+	CreatedAt           time.Time          `json:"createdAt"`
+	CreatedBy           *uuid.UUID         `gorm:"type:uuid" json:"createdBy"`
+}
+
+func (r *CertificationRequirement) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// AppliesTo reports whether the requirement covers the person. Positions and
+// departments are free text, so they are compared case-insensitively.
+func (r *CertificationRequirement) AppliesTo(person *Person) bool {
+	if r.Position != "" && !strings.EqualFold(r.Position, strings.TrimSpace(person.Position)) {
+		return false
+	}
+	if r.Department != "" && !strings.EqualFold(r.Department, strings.TrimSpace(person.Department)) {
+		return false
+	}
+	return r.Position != "" || r.Department != ""
+}
+
+// TableName specifies the table name for GORM
+func (CertificationRequirement) TableName() string {
+	return "certification_requirements"
+}
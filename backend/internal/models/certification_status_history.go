@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CertificationStatusHistory records a single status transition of a
+// certification. Rows are append-only.
+type CertificationStatusHistory struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	CertificationID uuid.UUID  `gorm:"type:uuid;not null;index" json:"certificationId"`
+	FromStatus      string     `gorm:"type:varchar(20);not null" json:"fromStatus"`
+	ToStatus        string     `gorm:"type:varchar(20);not null" json:"toStatus"`
+	Reason          string     `gorm:"type:text" json:"reason"`
+	ChangedBy       *uuid.UUID `gorm:"type:uuid" json:"changedBy"` // nil for system transitions
+	ChangedAt       time.Time  `gorm:"not null;index" json:"changedAt"`
+}
+
+func (h *CertificationStatusHistory) BeforeCreate(tx *gorm.DB) error {
+	if h.ID == uuid.Nil {
+		h.ID = uuid.New()
+	}
+	if h.ChangedAt.IsZero() {
+		h.ChangedAt = time.Now()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (CertificationStatusHistory) TableName() string {
+	return "certification_status_history"
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	CertificationCategorySafety       = "safety"
+	CertificationCategoryProfessional = "professional"
+	CertificationCategoryEquipment    = "equipment"
+)
+
+type CertificationType struct {
+	ID                    uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Name                  string     `gorm:"type:varchar(200);not null" json:"name"`
+	Description           string     `gorm:"type:text" json:"description"`
+	Category              string     `gorm:"type:varchar(50);not null;index" json:"category"` // 'safety', 'professional' or 'equipment'
+	DefaultValidityPeriod *int       `json:"defaultValidityPeriod"`                           // in days
+	RequiresRenewal       bool       `gorm:"not null" json:"requiresRenewal"`
+	IsActive              bool       `gorm:"default:true;index" json:"isActive"`
+	CreatedAt             time.Time  `json:"createdAt"`
+	UpdatedAt             time.Time  `json:"updatedAt"`
+	CreatedBy             *uuid.UUID `gorm:"type:uuid" json:"createdBy"`
+}
+
+func (ct *CertificationType) BeforeCreate(tx *gorm.DB) error {
+	if ct.ID == uuid.Nil {
+		ct.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (CertificationType) TableName() string {
+	return "certification_types"
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	DocumentAccessDownload  = "DOWNLOAD"
+	DocumentAccessThumbnail = "THUMBNAIL"
+)
+
+const (
+	DocumentAccessViaSession = "SESSION"
+	DocumentAccessViaLink    = "LINK"
+)
+
+// DocumentAccessLog records one read of a stored document. Entries are kept
+// when the document is deleted, so there is no foreign key to it.
+type DocumentAccessLog struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	DocumentID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"documentId"`
+	CertificationID uuid.UUID  `gorm:"type:uuid;not null" json:"certificationId"`
+	Version         int        `gorm:"not null" json:"version"`
+	UserID          *uuid.UUID `gorm:"type:uuid;index" json:"userId"` // for links, the user who created the link
+	Action          string     `gorm:"type:varchar(20);not null" json:"action"`
+	Via             string     `gorm:"type:varchar(20);not null" json:"via"`
+	IPAddress       string     `gorm:"type:varchar(45)" json:"ipAddress"`
+	UserAgent       string     `gorm:"type:varchar(512)" json:"userAgent"`
+	AccessedAt      time.Time  `gorm:"not null;index" json:"accessedAt"`
+}
+
+func (l *DocumentAccessLog) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (DocumentAccessLog) TableName() string {
+	return "document_access_logs"
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// EncryptionKey is the data key of one stored object, wrapped with a master
+// key. Rotating the master key rewraps these rows; the objects themselves are
+// never rewritten.
+type EncryptionKey struct {
+	ObjectKey   string    `gorm:"type:varchar(500);primaryKey" json:"objectKey"`
+	MasterKeyID string    `gorm:"type:varchar(64);not null;index" json:"masterKeyId"`
+	WrappedKey  []byte    `gorm:"type:bytea;not null" json:"-"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// TableName specifies the table name for GORM
+func (EncryptionKey) TableName() string {
+	return "encryption_keys"
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Equipment struct {
+	ID           uuid.UUID       `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AssetNumber  string          `gorm:"type:varchar(100);uniqueIndex;not null" json:"assetNumber"`
+	Name         string          `gorm:"type:varchar(200);not null" json:"name"`
+	Description  string          `gorm:"type:text" json:"description"`
+	Manufacturer string          `gorm:"type:varchar(100);index" json:"manufacturer"`
+	Model        string          `gorm:"type:varchar(100)" json:"model"`
+	SerialNumber string          `gorm:"type:varchar(100)" json:"serialNumber"`
+	Location     string          `gorm:"type:varchar(200);index" json:"location"`
+	PurchaseDate *time.Time      `gorm:"type:date" json:"purchaseDate"`
+	ClassID      *uuid.UUID      `gorm:"type:uuid;index" json:"classId"`
+	Class        *EquipmentClass `gorm:"foreignKey:ClassID;constraint:OnDelete:RESTRICT" json:"class,omitempty"`
+	IsActive     bool            `gorm:"default:true;index" json:"isActive"`
+	CreatedAt    time.Time       `json:"createdAt"`
+	UpdatedAt    time.Time       `json:"updatedAt"`
+	CreatedBy    *uuid.UUID      `gorm:"type:uuid" json:"createdBy"`
+	UpdatedBy    *uuid.UUID      `gorm:"type:uuid" json:"updatedBy"`
+
+	Compliance *EquipmentCompliance `gorm:"-" json:"compliance,omitempty"`
+}
+
+func (e *Equipment) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (Equipment) TableName() string {
+	return "equipment"
+}
@@ -0,0 +1,143 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Compliance status of a piece of equipment, and of each certification its
+// class requires.
+const (
+	EquipmentCompliant    = "compliant"
+	EquipmentAtRisk       = "at_risk"
+	EquipmentNonCompliant = "non_compliant"
+)
+
+// DefaultAtRiskDays is how long before a required certification expires that
+// the equipment is reported as at risk, unless its class says otherwise.
+const DefaultAtRiskDays = 30
+
+// EquipmentClass groups equipment that must hold the same certifications,
+// for example cranes needing a load test and pressure vessels a pressure test.
+// OperatorTypes are the certifications a person needs to operate equipment of
+// the class, such as a forklift licence.
+type EquipmentClass struct {
+	ID            uuid.UUID           `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Name          string              `gorm:"type:varchar(100);not null;uniqueIndex" json:"name"`
+	Description   string              `gorm:"type:text" json:"description"`
+	AtRiskDays    int                 `gorm:"not null;default:30" json:"atRiskDays"`
+	RequiredTypes []CertificationType `gorm:"many2many:equipment_class_requirements;constraint:OnDelete:CASCADE" json:"requiredTypes"`
+	OperatorTypes []CertificationType `gorm:"many2many:equipment_class_operator_requirements;constraint:OnDelete:CASCADE" json:"operatorTypes"`
+	CreatedAt     time.Time           `json:"createdAt"`
+	UpdatedAt     time.Time           `json:"updatedAt"`
+	CreatedBy     *uuid.UUID          `gorm:"type:uuid" json:"createdBy"`
+}
+
+func (c *EquipmentClass) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (EquipmentClass) TableName() string {
+	return "equipment_classes"
+}
+
+// EquipmentCompliance is the state of a piece of equipment against the
+// certifications its class requires.
+type EquipmentCompliance struct {
+	Status       string                       `json:"status"`
+	Requirements []EquipmentRequirementStatus `json:"requirements"`
+}
+
+// EquipmentRequirementStatus is the state of one required certification type.
+// Certification is the active certification that is valid the longest.
+type EquipmentRequirementStatus struct {
+	CertificationType *CertificationType `json:"certificationType"`
+	Status            string             `json:"status"`
+	Certification     *Certification     `json:"certification,omitempty"`
+}
+
+// EvaluateCompliance checks the equipment's certifications against the
+// class's active required types. A requirement is non-compliant without an
+// active, unexpired certification and at risk when the best one expires
+// within AtRiskDays; the equipment takes the worst status of its
+// requirements. Equipment without a class is compliant.
+func (c *EquipmentClass) EvaluateCompliance(certs []Certification, now time.Time) *EquipmentCompliance {
+	compliance := &EquipmentCompliance{
+		Status:       EquipmentCompliant,
+		Requirements: []EquipmentRequirementStatus{},
+	}
+	if c == nil {
+		return compliance
+	}
+
+	for i := range c.RequiredTypes {
+		certType := &c.RequiredTypes[i]
+		if !certType.IsActive {
+			continue
+		}
+
+		best := bestValidCertification(certs, certType.ID, now)
+		status := EquipmentCompliant
+		switch {
+		case best == nil:
+			status = EquipmentNonCompliant
+		case best.DaysUntil(now) < c.AtRiskDays:
+			status = EquipmentAtRisk
+		}
+		compliance.Requirements = append(compliance.Requirements, EquipmentRequirementStatus{
+			CertificationType: certType,
+			Status:            status,
+			Certification:     best,
+		})
+
+		if status == EquipmentNonCompliant || compliance.Status == EquipmentCompliant {
+			compliance.Status = status
+		}
+	}
+	return compliance
+}
+
+// AuthorizeOperator checks a person's certifications against the class's
+// active operator types. It returns the valid certification that lasts
+// longest for each type, or false when one is missing. A class without
+// active operator types authorizes nobody.
+func (c *EquipmentClass) AuthorizeOperator(certs []Certification, now time.Time) ([]Certification, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	var held []Certification
+	for _, certType := range c.OperatorTypes {
+		if !certType.IsActive {
+			continue
+		}
+		best := bestValidCertification(certs, certType.ID, now)
+		if best == nil {
+			return nil, false
+		}
+		held = append(held, *best)
+	}
+	return held, len(held) > 0
+}
+
+// bestValidCertification returns the active, unexpired certification of the
+// given type that expires last, or nil.
+func bestValidCertification(certs []Certification, typeID uuid.UUID, now time.Time) *Certification {
+	var best *Certification
+	for i := range certs {
+		cert := &certs[i]
+		if cert.CertificationTypeID != typeID || cert.Status != CertificationStatusActive || cert.DaysUntil(now) < 0 {
+			continue
+		}
+		if best == nil || cert.ExpirationDate.After(best.ExpirationDate) {
+			best = cert
+		}
+	}
+	return best
+}
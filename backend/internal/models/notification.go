@@ -0,0 +1,160 @@
+package models
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// A notification is pending until it has been sent to every recipient. It
+// is dead once delivery failed too often, until an admin requeues it, and
+// cancelled when the certification stopped being active before it was sent.
+// A notification that only goes out in digests is never sent by itself.
+const (
+	NotificationStatusPending   = "pending"
+	NotificationStatusSent      = "sent"
+	NotificationStatusDead      = "dead"
+	NotificationStatusCancelled = "cancelled"
+	NotificationStatusDigest    = "digest"
+)
+
+// Notification is an expiration reminder scheduled for a certification when
+// it crossed one of its notification rules. The rule's threshold is part of
+// the key, so changing a rule's number of days schedules it again, while
+// rerunning the expiration check never does.
+type Notification struct {
+	ID                   uuid.UUID         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	CertificationID      uuid.UUID         `gorm:"type:uuid;not null;uniqueIndex:idx_notifications_reminder,priority:1" json:"certificationId"`
+	Certification        *Certification    `gorm:"foreignKey:CertificationID;constraint:OnDelete:CASCADE" json:"certification,omitempty"`
+	NotificationRuleID   uuid.UUID         `gorm:"type:uuid;not null;uniqueIndex:idx_notifications_reminder,priority:2" json:"notificationRuleId"`
+	NotificationRule     *NotificationRule `gorm:"foreignKey:NotificationRuleID;constraint:OnDelete:CASCADE" json:"notificationRule,omitempty"`
+	DaysBeforeExpiration int               `gorm:"not null;uniqueIndex:idx_notifications_reminder,priority:3" json:"daysBeforeExpiration"`
+	EmailTemplate        string            `gorm:"type:varchar(100);not null" json:"emailTemplate"`
+	// Recipients are the email addresses resolved from the rule's recipient
+	// roles when the reminder was scheduled.
+	Recipients StringList `gorm:"type:jsonb;not null;default:'[]'" json:"recipients"`
+	// DigestRecipients get the reminder in their weekly digest instead.
+	DigestRecipients StringList `gorm:"type:jsonb;not null;default:'[]'" json:"digestRecipients"`
+	// DeliveredTo are the recipients the email has been sent to, so a retry
+	// only goes to the ones that failed.
+	DeliveredTo  StringList `gorm:"type:jsonb;not null;default:'[]'" json:"deliveredTo"`
+	Status       string     `gorm:"type:varchar(20);not null;default:'pending';index:idx_notifications_due,priority:1" json:"status"` // 'pending', 'sent', 'dead', 'cancelled' or 'digest'
+	ScheduledFor time.Time  `gorm:"not null;index" json:"scheduledFor"`
+	// Attempts counts delivery attempts, including ones that were cut short.
+	Attempts      int        `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time  `gorm:"not null;default:CURRENT_TIMESTAMP;index:idx_notifications_due,priority:2" json:"nextAttemptAt"`
+	SentAt        *time.Time `json:"sentAt"`
+	ErrorMessage  string     `gorm:"type:text" json:"errorMessage"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+
+	// EscalationLevel is the last step of the escalation chain taken because
+	// the certification was not renewed after this reminder, or 0.
+	EscalationLevel int                      `gorm:"not null;default:0" json:"escalationLevel"`
+	Escalations     []NotificationEscalation `gorm:"foreignKey:NotificationID;constraint:OnDelete:CASCADE" json:"escalations,omitempty"`
+}
+
+// Undelivered returns the recipients the email has not been sent to yet.
+func (n *Notification) Undelivered() []string {
+	return undelivered(n.Recipients, n.DeliveredTo)
+}
+
+func (n *Notification) BeforeCreate(tx *gorm.DB) error {
+	if n.ID == uuid.Nil {
+		n.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+// Escalation targets, in the order a certification that is not renewed
+// after its first reminder is escalated to them.
+const (
+	EscalationSupervisor     = "supervisor"
+	EscalationDepartmentHead = "department_head"
+	EscalationAdmin          = "admin"
+)
+
+// EscalationChain lists the escalation targets by level: level 1 is the
+// holder's supervisor.
+var EscalationChain = []string{EscalationSupervisor, EscalationDepartmentHead, EscalationAdmin}
+
+// NotificationEscalationStatusSkipped marks an escalation step that had
+// nobody to go to, such as a supervisor for a holder without one.
+const NotificationEscalationStatusSkipped = "skipped"
+
+// NotificationEscalation is one step of the escalation chain of a reminder.
+// It is delivered through the outbox like the reminder itself.
+type NotificationEscalation struct {
+	ID             uuid.UUID     `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	NotificationID uuid.UUID     `gorm:"type:uuid;not null;uniqueIndex:idx_notification_escalations_step,priority:1" json:"notificationId"`
+	Notification   *Notification `gorm:"foreignKey:NotificationID" json:"-"`
+	Level          int           `gorm:"not null;uniqueIndex:idx_notification_escalations_step,priority:2" json:"level"`
+	Target         string        `gorm:"type:varchar(20);not null" json:"target"` // 'supervisor', 'department_head' or 'admin'
+	Recipients     StringList    `gorm:"type:jsonb;not null;default:'[]'" json:"recipients"`
+	DeliveredTo    StringList    `gorm:"type:jsonb;not null;default:'[]'" json:"deliveredTo"`
+	Status         string        `gorm:"type:varchar(20);not null;default:'pending';index:idx_notification_escalations_due,priority:1" json:"status"` // 'pending', 'sent', 'dead', 'cancelled' or 'skipped'
+	Attempts       int           `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt  time.Time     `gorm:"not null;default:CURRENT_TIMESTAMP;index:idx_notification_escalations_due,priority:2" json:"nextAttemptAt"`
+	SentAt         *time.Time    `json:"sentAt"`
+	ErrorMessage   string        `gorm:"type:text" json:"errorMessage"`
+	CreatedAt      time.Time     `json:"createdAt"`
+	UpdatedAt      time.Time     `json:"updatedAt"`
+}
+
+// Undelivered returns the recipients the email has not been sent to yet.
+func (e *NotificationEscalation) Undelivered() []string {
+	return undelivered(e.Recipients, e.DeliveredTo)
+}
+
+func (e *NotificationEscalation) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (NotificationEscalation) TableName() string {
+	return "notification_escalations"
+}
+
+func undelivered(recipients, deliveredTo StringList) []string {
+	var result []string
+	for _, recipient := range recipients {
+		if !slices.Contains(deliveredTo, recipient) {
+			result = append(result, recipient)
+		}
+	}
+	return result
+}
+
+// CrossedNotificationRule returns the rule whose reminder is due for a
+// certification expiring in daysUntil days: the crossed threshold closest to
+// the expiration date. Thresholds crossed earlier are passed over, so a
+// certification added 10 days before it expires gets the 15-day reminder
+// rather than the 30- and 15-day ones at once. It returns false once the
+// certification has expired or while no threshold has been crossed.
+func CrossedNotificationRule(rules []NotificationRule, daysUntil int) (NotificationRule, bool) {
+	var crossed NotificationRule
+	found := false
+	if daysUntil < 0 {
+		return crossed, false
+	}
+	for _, rule := range rules {
+		if rule.DaysBeforeExpiration < daysUntil {
+			continue
+		}
+		if !found || rule.DaysBeforeExpiration < crossed.DaysBeforeExpiration {
+			crossed = rule
+			found = true
+		}
+	}
+	return crossed, found
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// NotificationDigest is one recipient's weekly email listing the reminders
+// due for them since their previous digest. It goes through the same
+// outbox as single reminders: it is pending until sent, retried on
+// failure, and dead once it has used up its attempts.
+type NotificationDigest struct {
+	ID        uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	Recipient string    `gorm:"type:varchar(255);not null;uniqueIndex:idx_notification_digests_period,priority:1" json:"recipient"`
+	// PeriodStart is the Monday the digest's week starts on. A recipient gets
+	// at most one digest per week.
+	PeriodStart   time.Time                `gorm:"type:date;not null;uniqueIndex:idx_notification_digests_period,priority:2" json:"periodStart"`
+	Items         []NotificationDigestItem `gorm:"foreignKey:NotificationDigestID;constraint:OnDelete:CASCADE" json:"items,omitempty"`
+	Status        string                   `gorm:"type:varchar(20);not null;default:'pending';index:idx_notification_digests_due,priority:1" json:"status"` // 'pending', 'sent', 'dead' or 'cancelled'
+	Attempts      int                      `gorm:"not null;default:0" json:"attempts"`
+	NextAttemptAt time.Time                `gorm:"not null;default:CURRENT_TIMESTAMP;index:idx_notification_digests_due,priority:2" json:"nextAttemptAt"`
+	SentAt        *time.Time               `json:"sentAt"`
+	ErrorMessage  string                   `gorm:"type:text" json:"errorMessage"`
+	CreatedAt     time.Time                `json:"createdAt"`
+	UpdatedAt     time.Time                `json:"updatedAt"`
+}
+
+func (d *NotificationDigest) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GORM
+func (NotificationDigest) TableName() string {
+	return "notification_digests"
+}
+
+// NotificationDigestItem puts a reminder into a recipient's digest.
+type NotificationDigestItem struct {
+	NotificationDigestID uuid.UUID     `gorm:"type:uuid;primaryKey" json:"notificationDigestId"`
+	NotificationID       uuid.UUID     `gorm:"type:uuid;primaryKey;index" json:"notificationId"`
+	Notification         *Notification `gorm:"foreignKey:NotificationID;constraint:OnDelete:CASCADE" json:"notification,omitempty"`
+}
+
+// TableName specifies the table name for GORM
+func (NotificationDigestItem) TableName() string {
+	return "notification_digest_items"
+}
+
+// DigestPeriodStart returns the start of the digest week containing t:
+// midnight UTC on its Monday.
+func DigestPeriodStart(t time.Time) time.Time {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,152 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Level at which a notification rule applies. Type-specific rules take
+// precedence over category rules, which take precedence over global rules.
+const (
+	NotificationScopeType     = "type"
+	NotificationScopeCategory = "category"
+	NotificationScopeGlobal   = "global"
+)
+
+// DefaultEmailTemplate is the template used when a rule does not name one.
+const DefaultEmailTemplate = "default_expiration"
+
+// ReminderEmailTemplates are the mailer templates, without the .html
+// extension, that a rule can send its reminders with.
+var ReminderEmailTemplates = []string{DefaultEmailTemplate}
+
+// Who a notification rule sends to: the person holding the certification,
+// or every active admin user.
+const (
+	RecipientHolder = "holder"
+	RecipientAdmin  = "admin"
+)
+
+// How a rule's reminders reach their recipients: one email per reminder, or
+// collected into a weekly digest per recipient. A user's own preference
+// overrides the rule's.
+const (
+	DeliveryImmediate = "immediate"
+	DeliveryDigest    = "digest"
+)
+
+// StringList is a list of strings stored in a jsonb column.
+type StringList []string
+
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]string(l))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (l *StringList) Scan(value any) error {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into StringList", value)
+	}
+	return json.Unmarshal(data, (*[]string)(l))
+}
+
+// NotificationRule sends an expiration reminder DaysBeforeExpiration days
+// before a certification expires. A rule with a CertificationTypeID applies
+// to that type, one with a Category to every type in the category, and one
+// with neither to all certifications.
+type NotificationRule struct {
+	ID                   uuid.UUID          `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	CertificationTypeID  *uuid.UUID         `gorm:"type:uuid;index;check:check_notification_rule_scope,certification_type_id IS NULL OR category = ''" json:"certificationTypeId"`
+	CertificationType    *CertificationType `gorm:"foreignKey:CertificationTypeID;constraint:OnDelete:CASCADE" json:"certificationType,omitempty"`
+	Category             string             `gorm:"type:varchar(50);not null;default:'';index" json:"category"`
+	DaysBeforeExpiration int                `gorm:"not null;check:check_notification_rule_days,days_before_expiration >= 0" json:"daysBeforeExpiration"`
+	IsActive             bool               `gorm:"not null;default:true;index" json:"isActive"`
+	EmailTemplate        string             `gorm:"type:varchar(100);not null;default:'default_expiration'" json:"emailTemplate"`
+	RecipientRoles       StringList         `gorm:"type:jsonb;not null;default:'[]'" json:"recipientRoles"`
+	Delivery             string             `gorm:"type:varchar(20);not null;default:'immediate'" json:"delivery"` // 'immediate' or 'digest'
+	CreatedAt            time.Time          `json:"createdAt"`
+	UpdatedAt            time.Time          `json:"updatedAt"`
+	CreatedBy            *uuid.UUID         `gorm:"type:uuid" json:"createdBy"`
+}
+
+func (r *NotificationRule) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// Scope reports the level the rule applies at.
+func (r *NotificationRule) Scope() string {
+	switch {
+	case r.CertificationTypeID != nil:
+		return NotificationScopeType
+	case r.Category != "":
+		return NotificationScopeCategory
+	default:
+		return NotificationScopeGlobal
+	}
+}
+
+// TableName specifies the table name for GORM
+func (NotificationRule) TableName() string {
+	return "notification_rules"
+}
+
+// EffectiveNotificationRules picks the active rules that apply to
+// certifications of the given type: the type's own rules if it has any,
+// otherwise its category's, otherwise the global rules. Levels are never
+// mixed, so a category with a 60-day rule does not also get the global
+// 30-day one unless it defines it. The rules are returned earliest reminder
+// first together with the scope they came from; the scope is empty when no
+// rule applies.
+func EffectiveNotificationRules(rules []NotificationRule, certType *CertificationType) (string, []NotificationRule) {
+	byScope := make(map[string][]NotificationRule)
+	for _, rule := range rules {
+		if !rule.IsActive {
+			continue
+		}
+		switch rule.Scope() {
+		case NotificationScopeType:
+			if *rule.CertificationTypeID != certType.ID {
+				continue
+			}
+		case NotificationScopeCategory:
+			if rule.Category != certType.Category {
+				continue
+			}
+		}
+		byScope[rule.Scope()] = append(byScope[rule.Scope()], rule)
+	}
+
+	for _, scope := range []string{NotificationScopeType, NotificationScopeCategory, NotificationScopeGlobal} {
+		if effective := byScope[scope]; len(effective) > 0 {
+			sort.Slice(effective, func(i, j int) bool {
+				return effective[i].DaysBeforeExpiration > effective[j].DaysBeforeExpiration
+			})
+			return scope, effective
+		}
+	}
+	return "", []NotificationRule{}
+}
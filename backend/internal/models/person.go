@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Person struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	EmployeeID *string    `gorm:"type:varchar(50);uniqueIndex" json:"employeeId"`
+	FirstName  string     `gorm:"type:varchar(100);not null" json:"firstName"`
+	LastName   string     `gorm:"type:varchar(100);not null" json:"lastName"`
+	Email      string     `gorm:"type:varchar(255);index" json:"email"`
+	Phone      string     `gorm:"type:varchar(20)" json:"phone"`
+	Department string     `gorm:"type:varchar(100);index" json:"department"`
+	Position   string     `gorm:"type:varchar(100)" json:"position"`
+	HireDate   *time.Time `gorm:"type:date" json:"hireDate"`
+	IsActive   bool       `gorm:"default:true;index" json:"isActive"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+	CreatedBy  *uuid.UUID `gorm:"type:uuid" json:"createdBy"`
+	UpdatedBy  *uuid.UUID `gorm:"type:uuid" json:"updatedBy"`
+
+	// SupervisorID and IsDepartmentHead decide who unrenewed certifications
+	// are escalated to.
+	SupervisorID     *uuid.UUID `gorm:"type:uuid;index" json:"supervisorId"`
+	IsDepartmentHead bool       `gorm:"not null;default:false" json:"isDepartmentHead"`
+}
+
+func (p *Person) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+func (p *Person) FullName() string {
+	return p.FirstName + " " + p.LastName
+}
+
+// TableName specifies the table name for GORM
+func (Person) TableName() string {
+	return "people"
+}
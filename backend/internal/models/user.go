@@ -22,10 +22,14 @@ type User struct {
 	UpdatedAt time.Time  `json:"updatedAt"`
 	LastLogin *time.Time `json:"lastLogin"`
 
-	// Relationships (will be uncommented when other models are created)
-	// CreatedPeople         []Person        `gorm:"foreignKey:CreatedBy" json:"-"`
-	// CreatedEquipment      []Equipment     `gorm:"foreignKey:CreatedBy" json:"-"`
-	// CreatedCertifications []Certification `gorm:"foreignKey:CreatedBy" json:"-"`
+	// NotificationDelivery overrides how notification rules deliver reminders
+	// to this user: 'immediate', 'digest', or empty to follow the rules.
+	NotificationDelivery string `gorm:"type:varchar(20);not null;default:''" json:"notificationDelivery"`
+
+	// Relationships
+	CreatedPeople         []Person        `gorm:"foreignKey:CreatedBy" json:"-"`
+	CreatedEquipment      []Equipment     `gorm:"foreignKey:CreatedBy" json:"-"`
+	CreatedCertifications []Certification `gorm:"foreignKey:CreatedBy" json:"-"`
 }
 
 func (u *User) BeforeCreate(tx *gorm.DB) error {
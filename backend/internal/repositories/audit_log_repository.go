@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"certitrack/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AuditLogRepository interface {
+	Create(entry *models.AuditLog) error
+	ListByRecord(table string, recordID uuid.UUID) ([]models.AuditLog, error)
+}
+
+type AuditLogRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewAuditLogRepositoryImpl(db *gorm.DB) AuditLogRepository {
+	return &AuditLogRepositoryImpl{db: db}
+}
+
+func (r *AuditLogRepositoryImpl) Create(entry *models.AuditLog) error {
+	return r.db.Create(entry).Error
+}
+
+func (r *AuditLogRepositoryImpl) ListByRecord(table string, recordID uuid.UUID) ([]models.AuditLog, error) {
+	var entries []models.AuditLog
+	err := r.db.
+		Where("table_name = ? AND record_id = ?", table, recordID).
+		Order("created_at ASC").
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
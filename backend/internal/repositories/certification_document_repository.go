@@ -0,0 +1,170 @@
+package repositories
+
+import (
+	"errors"
+
+	"certitrack/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrStaleDocumentVersion is returned by ReplaceCurrent when the document
+// was replaced concurrently.
+var ErrStaleDocumentVersion = errors.New("document version changed concurrently")
+
+type CertificationDocumentRepository interface {
+	Create(doc *models.CertificationDocument) error
+	Delete(id uuid.UUID) error
+	FindByID(id uuid.UUID) (*models.CertificationDocument, error)
+	ListByCertification(certID uuid.UUID) ([]models.CertificationDocument, error)
+	ReplaceCurrent(doc *models.CertificationDocument, archived *models.CertificationDocumentVersion) error
+	ListVersions(docID uuid.UUID) ([]models.CertificationDocumentVersion, error)
+	FindVersion(docID uuid.UUID, version int) (*models.CertificationDocumentVersion, error)
+	DeleteVersion(id uuid.UUID) error
+	CountReferences(filePath string) (int64, error)
+	ListStoredObjects() ([]StoredObject, error)
+}
+
+// StoredObject is one reference from a document or archived version to a
+// stored file. Deduplicated files appear once per reference.
+type StoredObject struct {
+	DocumentID  uuid.UUID
+	Version     int
+	FilePath    string
+	ContentHash string
+}
+
+type CertificationDocumentRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewCertificationDocumentRepositoryImpl(db *gorm.DB) CertificationDocumentRepository {
+	return &CertificationDocumentRepositoryImpl{db: db}
+}
+
+func (r *CertificationDocumentRepositoryImpl) Create(doc *models.CertificationDocument) error {
+	return r.db.Omit("Versions").Create(doc).Error
+}
+
+func (r *CertificationDocumentRepositoryImpl) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.CertificationDocument{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *CertificationDocumentRepositoryImpl) FindByID(id uuid.UUID) (*models.CertificationDocument, error) {
+	var doc models.CertificationDocument
+	if err := r.db.Where("id = ?", id).First(&doc).Error; err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func (r *CertificationDocumentRepositoryImpl) ListByCertification(certID uuid.UUID) ([]models.CertificationDocument, error) {
+	var docs []models.CertificationDocument
+	err := r.db.
+		Where("certification_id = ?", certID).
+		Order("uploaded_at ASC").
+		Find(&docs).Error
+	if err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// ReplaceCurrent archives the previous current version and stores doc's new
+// file fields in one transaction. The update only applies if the document is
+// still at archived.Version.
+func (r *CertificationDocumentRepositoryImpl) ReplaceCurrent(doc *models.CertificationDocument, archived *models.CertificationDocumentVersion) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.CertificationDocument{}).
+			Where("id = ? AND version = ?", doc.ID, archived.Version).
+			Updates(map[string]interface{}{
+				"file_name":    doc.FileName,
+				"file_path":    doc.FilePath,
+				"file_size":    doc.FileSize,
+				"mime_type":    doc.MimeType,
+				"content_hash": doc.ContentHash,
+				"description":  doc.Description,
+				"uploaded_at":  doc.UploadedAt,
+				"uploaded_by":  doc.UploadedBy,
+				"version":      doc.Version,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrStaleDocumentVersion
+		}
+
+		archived.DocumentID = doc.ID
+		return tx.Create(archived).Error
+	})
+}
+
+// ListVersions returns the archived versions of a document, oldest first.
+// The current version lives on the document itself.
+func (r *CertificationDocumentRepositoryImpl) ListVersions(docID uuid.UUID) ([]models.CertificationDocumentVersion, error) {
+	var versions []models.CertificationDocumentVersion
+	err := r.db.
+		Where("document_id = ?", docID).
+		Order("version ASC").
+		Find(&versions).Error
+	if err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+func (r *CertificationDocumentRepositoryImpl) FindVersion(docID uuid.UUID, version int) (*models.CertificationDocumentVersion, error) {
+	var v models.CertificationDocumentVersion
+	if err := r.db.Where("document_id = ? AND version = ?", docID, version).First(&v).Error; err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *CertificationDocumentRepositoryImpl) DeleteVersion(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.CertificationDocumentVersion{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// CountReferences returns how many documents and archived versions point at
+// filePath. Deduplicated files may only be removed once this reaches zero.
+func (r *CertificationDocumentRepositoryImpl) CountReferences(filePath string) (int64, error) {
+	var documents, versions int64
+	if err := r.db.Model(&models.CertificationDocument{}).Where("file_path = ?", filePath).Count(&documents).Error; err != nil {
+		return 0, err
+	}
+	if err := r.db.Model(&models.CertificationDocumentVersion{}).Where("file_path = ?", filePath).Count(&versions).Error; err != nil {
+		return 0, err
+	}
+	return documents + versions, nil
+}
+
+// ListStoredObjects returns every file reference, current and archived.
+func (r *CertificationDocumentRepositoryImpl) ListStoredObjects() ([]StoredObject, error) {
+	var objects []StoredObject
+	err := r.db.Raw(`
+		SELECT id AS document_id, version, file_path, content_hash FROM certification_documents
+		UNION ALL
+		SELECT document_id, version, file_path, content_hash FROM certification_document_versions
+		ORDER BY file_path, document_id, version`).
+		Scan(&objects).Error
+	if err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
@@ -0,0 +1,314 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"certitrack/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CertificationFilter struct {
+	Pagination
+	Search              string
+	PersonID            *uuid.UUID
+	EquipmentID         *uuid.UUID
+	CertificationTypeID *uuid.UUID
+	Status              string
+	// ExpiringWithinDays limits results to active certifications expiring
+	// between today and today + N days.
+	ExpiringWithinDays *int
+	SortBy             string
+	SortOrder          string
+}
+
+var certificationSortColumns = map[string]string{
+	"expirationDate": "expiration_date",
+	"issueDate":      "issue_date",
+	"createdAt":      "created_at",
+}
+
+// ErrStaleCertificationStatus is returned by ChangeStatus when the stored
+// status no longer matches the transition's from-status.
+var ErrStaleCertificationStatus = errors.New("certification status changed concurrently")
+
+type CertificationRepository interface {
+	Create(cert *models.Certification) error
+	Update(cert *models.Certification) error
+	Delete(id uuid.UUID) error
+	FindByID(id uuid.UUID) (*models.Certification, error)
+	List(filter CertificationFilter) ([]models.Certification, int64, error)
+	ChangeStatus(cert *models.Certification, entry *models.CertificationStatusHistory) error
+	ListStatusHistory(certID uuid.UUID) ([]models.CertificationStatusHistory, error)
+	FindOverdueActive(asOf time.Time) ([]models.Certification, error)
+	FindActiveExpiringBetween(from, to time.Time) ([]models.Certification, error)
+	CreateRenewal(successor, predecessor *models.Certification, entry *models.CertificationStatusHistory) error
+	FindSuccessor(id uuid.UUID) (*models.Certification, error)
+	FindForPeople(personIDs []uuid.UUID) ([]models.Certification, error)
+	FindForEquipment(equipmentIDs []uuid.UUID) ([]models.Certification, error)
+	FindActiveHeldByPeople(typeIDs []uuid.UUID) ([]models.Certification, error)
+}
+
+type CertificationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewCertificationRepositoryImpl(db *gorm.DB) CertificationRepository {
+	return &CertificationRepositoryImpl{db: db}
+}
+
+func (r *CertificationRepositoryImpl) Create(cert *models.Certification) error {
+	return r.db.Omit("CertificationType", "Person", "Equipment", "Predecessor", "StatusHistory", "Documents").Create(cert).Error
+}
+
+// Update saves the certification's fields except its status, which only
+// changes through ChangeStatus and CreateRenewal so every change is guarded
+// and recorded in the status history.
+func (r *CertificationRepositoryImpl) Update(cert *models.Certification) error {
+	return r.db.Omit("Status", "CertificationType", "Person", "Equipment", "Predecessor", "StatusHistory", "Documents").Save(cert).Error
+}
+
+func (r *CertificationRepositoryImpl) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.Certification{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *CertificationRepositoryImpl) FindByID(id uuid.UUID) (*models.Certification, error) {
+	var cert models.Certification
+	err := r.db.
+		Preload("CertificationType").
+		Preload("Person").
+		Preload("Equipment").
+		Preload("Documents", func(db *gorm.DB) *gorm.DB {
+			return db.Order("uploaded_at ASC")
+		}).
+		Where("id = ?", id).
+		First(&cert).Error
+	if err != nil {
+		return nil, err
+	}
+	return &cert, nil
+}
+
+func (r *CertificationRepositoryImpl) List(filter CertificationFilter) ([]models.Certification, int64, error) {
+	query := r.db.Model(&models.Certification{})
+
+	if filter.Search != "" {
+		like := "%" + filter.Search + "%"
+		query = query.Where("certificate_number ILIKE ? OR issuing_authority ILIKE ?", like, like)
+	}
+	if filter.PersonID != nil {
+		query = query.Where("person_id = ?", *filter.PersonID)
+	}
+	if filter.EquipmentID != nil {
+		query = query.Where("equipment_id = ?", *filter.EquipmentID)
+	}
+	if filter.CertificationTypeID != nil {
+		query = query.Where("certification_type_id = ?", *filter.CertificationTypeID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.ExpiringWithinDays != nil {
+		today := models.Today()
+		query = query.
+			Where("status = ?", models.CertificationStatusActive).
+			Where("expiration_date BETWEEN ? AND ?", today, today.AddDate(0, 0, *filter.ExpiringWithinDays))
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	column, ok := certificationSortColumns[filter.SortBy]
+	if !ok {
+		column = "expiration_date"
+	}
+	order := "ASC"
+	if filter.SortOrder == "desc" {
+		order = "DESC"
+	}
+
+	page := filter.Pagination.Normalize()
+	var certs []models.Certification
+	err := query.
+		Preload("CertificationType").
+		Preload("Person").
+		Preload("Equipment").
+		Order(column + " " + order).
+		Limit(page.Limit).
+		Offset(page.Offset()).
+		Find(&certs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return certs, total, nil
+}
+
+// ChangeStatus updates the certification status and appends the history entry
+// in a single transaction. The update only applies while the stored status
+// still equals entry.FromStatus.
+func (r *CertificationRepositoryImpl) ChangeStatus(cert *models.Certification, entry *models.CertificationStatusHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return changeCertificationStatus(tx, cert, entry)
+	})
+}
+
+// CreateRenewal inserts the successor and moves the predecessor to its new
+// status in one transaction, so a renewal is never half-applied.
+func (r *CertificationRepositoryImpl) CreateRenewal(successor, predecessor *models.Certification, entry *models.CertificationStatusHistory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := changeCertificationStatus(tx, predecessor, entry); err != nil {
+			return err
+		}
+		return tx.Omit("CertificationType", "Person", "Equipment", "Predecessor", "StatusHistory", "Documents").Create(successor).Error
+	})
+}
+
+func (r *CertificationRepositoryImpl) FindSuccessor(id uuid.UUID) (*models.Certification, error) {
+	var cert models.Certification
+	err := r.db.
+		Preload("CertificationType").
+		Where("predecessor_id = ?", id).
+		First(&cert).Error
+	if err != nil {
+		return nil, err
+	}
+	return &cert, nil
+}
+
+func changeCertificationStatus(tx *gorm.DB, cert *models.Certification, entry *models.CertificationStatusHistory) error {
+	result := tx.Model(&models.Certification{}).
+		Where("id = ? AND status = ?", cert.ID, entry.FromStatus).
+		Updates(map[string]interface{}{
+			"status":     entry.ToStatus,
+			"updated_by": cert.UpdatedBy,
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrStaleCertificationStatus
+	}
+
+	entry.CertificationID = cert.ID
+	return tx.Create(entry).Error
+}
+
+func (r *CertificationRepositoryImpl) ListStatusHistory(certID uuid.UUID) ([]models.CertificationStatusHistory, error) {
+	var history []models.CertificationStatusHistory
+	err := r.db.
+		Where("certification_id = ?", certID).
+		Order("changed_at ASC").
+		Find(&history).Error
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// FindOverdueActive returns active certifications whose expiration date is
+// strictly before asOf's calendar day.
+func (r *CertificationRepositoryImpl) FindOverdueActive(asOf time.Time) ([]models.Certification, error) {
+	today := time.Date(asOf.Year(), asOf.Month(), asOf.Day(), 0, 0, 0, 0, time.UTC)
+
+	var certs []models.Certification
+	err := r.db.
+		Where("status = ? AND expiration_date < ?", models.CertificationStatusActive, today).
+		Order("expiration_date ASC").
+		Find(&certs).Error
+	if err != nil {
+		return nil, err
+	}
+	return certs, nil
+}
+
+// FindActiveExpiringBetween returns active certifications expiring on a
+// calendar day from from's through to's, inclusive, with the holder loaded.
+// Certifications of inactive people are left out.
+func (r *CertificationRepositoryImpl) FindActiveExpiringBetween(from, to time.Time) ([]models.Certification, error) {
+	first := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	last := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	var certs []models.Certification
+	err := r.db.
+		Preload("Person").
+		Joins("LEFT JOIN people ON people.id = certifications.person_id").
+		Where("certifications.person_id IS NULL OR people.is_active = ?", true).
+		Where("certifications.status = ? AND certifications.expiration_date BETWEEN ? AND ?", models.CertificationStatusActive, first, last).
+		Order("certifications.expiration_date ASC").
+		Find(&certs).Error
+	if err != nil {
+		return nil, err
+	}
+	return certs, nil
+}
+
+// FindForPeople returns every certification held by the given people, latest
+// expiration first.
+func (r *CertificationRepositoryImpl) FindForPeople(personIDs []uuid.UUID) ([]models.Certification, error) {
+	if len(personIDs) == 0 {
+		return nil, nil
+	}
+
+	var certs []models.Certification
+	err := r.db.
+		Where("person_id IN ?", personIDs).
+		Order("expiration_date DESC").
+		Find(&certs).Error
+	if err != nil {
+		return nil, err
+	}
+	return certs, nil
+}
+
+// FindForEquipment returns every certification held by the given equipment,
+// latest expiration first.
+func (r *CertificationRepositoryImpl) FindForEquipment(equipmentIDs []uuid.UUID) ([]models.Certification, error) {
+	if len(equipmentIDs) == 0 {
+		return nil, nil
+	}
+
+	var certs []models.Certification
+	err := r.db.
+		Where("equipment_id IN ?", equipmentIDs).
+		Order("expiration_date DESC").
+		Find(&certs).Error
+	if err != nil {
+		return nil, err
+	}
+	return certs, nil
+}
+
+// FindActiveHeldByPeople returns the active certifications of the given types
+// held by active people, with the person loaded. Expiration is left to the
+// caller, which compares it against its own clock.
+func (r *CertificationRepositoryImpl) FindActiveHeldByPeople(typeIDs []uuid.UUID) ([]models.Certification, error) {
+	if len(typeIDs) == 0 {
+		return nil, nil
+	}
+
+	var certs []models.Certification
+	err := r.db.
+		Preload("Person").
+		Joins("JOIN people ON people.id = certifications.person_id").
+		Where("people.is_active = ?", true).
+		Where("certifications.status = ? AND certifications.certification_type_id IN ?", models.CertificationStatusActive, typeIDs).
+		Order("certifications.expiration_date DESC").
+		Find(&certs).Error
+	if err != nil {
+		return nil, err
+	}
+	return certs, nil
+}
@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"certitrack/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CertificationRequirementFilter struct {
+	Pagination
+	Position            string
+	Department          string
+	CertificationTypeID *uuid.UUID
+}
+
+type CertificationRequirementRepository interface {
+	Create(requirement *models.CertificationRequirement) error
+	Delete(id uuid.UUID) error
+	FindByID(id uuid.UUID) (*models.CertificationRequirement, error)
+	List(filter CertificationRequirementFilter) ([]models.CertificationRequirement, int64, error)
+	FindForActiveTypes() ([]models.CertificationRequirement, error)
+}
+
+type CertificationRequirementRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewCertificationRequirementRepositoryImpl(db *gorm.DB) CertificationRequirementRepository {
+	return &CertificationRequirementRepositoryImpl{db: db}
+}
+
+func (r *CertificationRequirementRepositoryImpl) Create(requirement *models.CertificationRequirement) error {
+	return r.db.Omit("CertificationType").Create(requirement).Error
+}
+
+func (r *CertificationRequirementRepositoryImpl) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.CertificationRequirement{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *CertificationRequirementRepositoryImpl) FindByID(id uuid.UUID) (*models.CertificationRequirement, error) {
+	var requirement models.CertificationRequirement
+	err := r.db.
+		Preload("CertificationType").
+		Where("id = ?", id).
+		First(&requirement).Error
+	if err != nil {
+		return nil, err
+	}
+	return &requirement, nil
+}
+
+func (r *CertificationRequirementRepositoryImpl) List(filter CertificationRequirementFilter) ([]models.CertificationRequirement, int64, error) {
+	query := r.db.Model(&models.CertificationRequirement{})
+
+	if filter.Position != "" {
+		query = query.Where("LOWER(position) = LOWER(?)", filter.Position)
+	}
+	if filter.Department != "" {
+		query = query.Where("LOWER(department) = LOWER(?)", filter.Department)
+	}
+	if filter.CertificationTypeID != nil {
+		query = query.Where("certification_type_id = ?", *filter.CertificationTypeID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	page := filter.Pagination.Normalize()
+	var requirements []models.CertificationRequirement
+	err := query.
+		Preload("CertificationType").
+		Order("department ASC, position ASC").
+		Limit(page.Limit).
+		Offset(page.Offset()).
+		Find(&requirements).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return requirements, total, nil
+}
+
+// FindForActiveTypes returns every requirement whose certification type is
+// still active. Inactive types cannot be issued, so requiring them would
+// report gaps nobody can close.
+func (r *CertificationRequirementRepositoryImpl) FindForActiveTypes() ([]models.CertificationRequirement, error) {
+	var requirements []models.CertificationRequirement
+	err := r.db.
+		Preload("CertificationType").
+		Joins("JOIN certification_types ON certification_types.id = certification_requirements.certification_type_id").
+		Where("certification_types.is_active = ?", true).
+		Find(&requirements).Error
+	if err != nil {
+		return nil, err
+	}
+	return requirements, nil
+}
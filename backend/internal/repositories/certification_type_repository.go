@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"certitrack/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CertificationTypeFilter struct {
+	Pagination
+	Category string
+	IsActive *bool
+}
+
+type CertificationTypeRepository interface {
+	Create(certType *models.CertificationType) error
+	Update(certType *models.CertificationType) error
+	Delete(id uuid.UUID) error
+	FindByID(id uuid.UUID) (*models.CertificationType, error)
+	List(filter CertificationTypeFilter) ([]models.CertificationType, int64, error)
+}
+
+type CertificationTypeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewCertificationTypeRepositoryImpl(db *gorm.DB) CertificationTypeRepository {
+	return &CertificationTypeRepositoryImpl{db: db}
+}
+
+func (r *CertificationTypeRepositoryImpl) Create(certType *models.CertificationType) error {
+	return r.db.Create(certType).Error
+}
+
+func (r *CertificationTypeRepositoryImpl) Update(certType *models.CertificationType) error {
+	return r.db.Save(certType).Error
+}
+
+// Delete removes the type permanently. Types referenced by certifications are
+// protected by a foreign key, so the database rejects the delete with
+// gorm.ErrForeignKeyViolated.
+func (r *CertificationTypeRepositoryImpl) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.CertificationType{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *CertificationTypeRepositoryImpl) FindByID(id uuid.UUID) (*models.CertificationType, error) {
+	var certType models.CertificationType
+	if err := r.db.Where("id = ?", id).First(&certType).Error; err != nil {
+		return nil, err
+	}
+	return &certType, nil
+}
+
+func (r *CertificationTypeRepositoryImpl) List(filter CertificationTypeFilter) ([]models.CertificationType, int64, error) {
+	query := r.db.Model(&models.CertificationType{})
+
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	page := filter.Pagination.Normalize()
+	var types []models.CertificationType
+	err := query.
+		Order("name ASC").
+		Limit(page.Limit).
+		Offset(page.Offset()).
+		Find(&types).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return types, total, nil
+}
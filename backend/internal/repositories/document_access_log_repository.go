@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"certitrack/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DocumentAccessFilter narrows a document access log query. Nil fields are
+// not filtered on.
+type DocumentAccessFilter struct {
+	Pagination Pagination
+	DocumentID *uuid.UUID
+	UserID     *uuid.UUID
+}
+
+type DocumentAccessLogRepository interface {
+	Create(entry *models.DocumentAccessLog) error
+	List(filter DocumentAccessFilter) ([]models.DocumentAccessLog, int64, error)
+}
+
+type DocumentAccessLogRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewDocumentAccessLogRepositoryImpl(db *gorm.DB) DocumentAccessLogRepository {
+	return &DocumentAccessLogRepositoryImpl{db: db}
+}
+
+func (r *DocumentAccessLogRepositoryImpl) Create(entry *models.DocumentAccessLog) error {
+	return r.db.Create(entry).Error
+}
+
+// List returns matching entries, most recent first, and the total count.
+func (r *DocumentAccessLogRepositoryImpl) List(filter DocumentAccessFilter) ([]models.DocumentAccessLog, int64, error) {
+	query := r.db.Model(&models.DocumentAccessLog{})
+	if filter.DocumentID != nil {
+		query = query.Where("document_id = ?", *filter.DocumentID)
+	}
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	page := filter.Pagination.Normalize()
+	var entries []models.DocumentAccessLog
+	err := query.
+		Order("accessed_at DESC").
+		Offset(page.Offset()).
+		Limit(page.Limit).
+		Find(&entries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
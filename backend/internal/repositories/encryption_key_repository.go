@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"certitrack/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type EncryptionKeyRepository interface {
+	FindByObjectKey(objectKey string) (*models.EncryptionKey, error)
+	CreateIfAbsent(key *models.EncryptionKey) error
+	DeleteByObjectKey(objectKey string) error
+	ListNotWrappedWith(masterKeyID, after string, limit int) ([]models.EncryptionKey, error)
+	Rewrap(objectKey, fromMasterKeyID, toMasterKeyID string, wrapped []byte) error
+}
+
+type EncryptionKeyRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewEncryptionKeyRepositoryImpl(db *gorm.DB) EncryptionKeyRepository {
+	return &EncryptionKeyRepositoryImpl{db: db}
+}
+
+func (r *EncryptionKeyRepositoryImpl) FindByObjectKey(objectKey string) (*models.EncryptionKey, error) {
+	var key models.EncryptionKey
+	if err := r.db.Where("object_key = ?", objectKey).First(&key).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// CreateIfAbsent stores key unless the object already has one; callers read
+// the key back to use whichever was stored first.
+func (r *EncryptionKeyRepositoryImpl) CreateIfAbsent(key *models.EncryptionKey) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(key).Error
+}
+
+func (r *EncryptionKeyRepositoryImpl) DeleteByObjectKey(objectKey string) error {
+	return r.db.Where("object_key = ?", objectKey).Delete(&models.EncryptionKey{}).Error
+}
+
+// ListNotWrappedWith pages through the keys wrapped with any master key other
+// than masterKeyID, ordered by object key and starting after after.
+func (r *EncryptionKeyRepositoryImpl) ListNotWrappedWith(masterKeyID, after string, limit int) ([]models.EncryptionKey, error) {
+	var keys []models.EncryptionKey
+	err := r.db.
+		Where("master_key_id <> ? AND object_key > ?", masterKeyID, after).
+		Order("object_key ASC").
+		Limit(limit).
+		Find(&keys).Error
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Rewrap replaces the wrapped key if it is still wrapped with
+// fromMasterKeyID, so concurrent rotations cannot overwrite each other.
+func (r *EncryptionKeyRepositoryImpl) Rewrap(objectKey, fromMasterKeyID, toMasterKeyID string, wrapped []byte) error {
+	return r.db.Model(&models.EncryptionKey{}).
+		Where("object_key = ? AND master_key_id = ?", objectKey, fromMasterKeyID).
+		Updates(map[string]any{
+			"master_key_id": toMasterKeyID,
+			"wrapped_key":   wrapped,
+		}).Error
+}
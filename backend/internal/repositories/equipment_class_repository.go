@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"certitrack/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type EquipmentClassRepository interface {
+	Create(class *models.EquipmentClass) error
+	Update(class *models.EquipmentClass) error
+	Delete(id uuid.UUID) error
+	FindByID(id uuid.UUID) (*models.EquipmentClass, error)
+	List(pagination Pagination) ([]models.EquipmentClass, int64, error)
+	FindWithOperatorTypes() ([]models.EquipmentClass, error)
+}
+
+type EquipmentClassRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewEquipmentClassRepositoryImpl(db *gorm.DB) EquipmentClassRepository {
+	return &EquipmentClassRepositoryImpl{db: db}
+}
+
+// Create inserts the class and links its required and operator types, which
+// must already exist.
+func (r *EquipmentClassRepositoryImpl) Create(class *models.EquipmentClass) error {
+	return r.db.Omit("RequiredTypes.*", "OperatorTypes.*").Create(class).Error
+}
+
+// Update saves the class and replaces its required and operator types in one
+// transaction.
+func (r *EquipmentClassRepositoryImpl) Update(class *models.EquipmentClass) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("RequiredTypes", "OperatorTypes").Save(class).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(class).Omit("RequiredTypes.*").Association("RequiredTypes").Replace(class.RequiredTypes); err != nil {
+			return err
+		}
+		return tx.Model(class).Omit("OperatorTypes.*").Association("OperatorTypes").Replace(class.OperatorTypes)
+	})
+}
+
+// Delete removes the class. Classes still assigned to equipment are protected
+// by a foreign key, so the database rejects the delete with
+// gorm.ErrForeignKeyViolated.
+func (r *EquipmentClassRepositoryImpl) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.EquipmentClass{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *EquipmentClassRepositoryImpl) FindByID(id uuid.UUID) (*models.EquipmentClass, error) {
+	var class models.EquipmentClass
+	err := r.db.
+		Preload("RequiredTypes").
+		Preload("OperatorTypes").
+		Where("id = ?", id).
+		First(&class).Error
+	if err != nil {
+		return nil, err
+	}
+	return &class, nil
+}
+
+func (r *EquipmentClassRepositoryImpl) List(pagination Pagination) ([]models.EquipmentClass, int64, error) {
+	query := r.db.Model(&models.EquipmentClass{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	page := pagination.Normalize()
+	var classes []models.EquipmentClass
+	err := query.
+		Preload("RequiredTypes").
+		Preload("OperatorTypes").
+		Order("name ASC").
+		Limit(page.Limit).
+		Offset(page.Offset()).
+		Find(&classes).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return classes, total, nil
+}
+
+// FindWithOperatorTypes returns every class that names at least one operator
+// type, with its operator types loaded.
+func (r *EquipmentClassRepositoryImpl) FindWithOperatorTypes() ([]models.EquipmentClass, error) {
+	var classes []models.EquipmentClass
+	err := r.db.
+		Preload("OperatorTypes").
+		Where("EXISTS (SELECT 1 FROM equipment_class_operator_requirements o WHERE o.equipment_class_id = equipment_classes.id)").
+		Order("name ASC").
+		Find(&classes).Error
+	if err != nil {
+		return nil, err
+	}
+	return classes, nil
+}
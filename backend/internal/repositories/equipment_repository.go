@@ -0,0 +1,166 @@
+package repositories
+
+import (
+	"fmt"
+
+	"certitrack/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type EquipmentFilter struct {
+	Pagination
+	Search       string
+	Location     string
+	Manufacturer string
+	IsActive     *bool
+	ClassID      *uuid.UUID
+	// ComplianceStatus is one of the models.Equipment* compliance statuses.
+	ComplianceStatus string
+}
+
+// lackingCertification matches equipment whose class requires an active
+// certification type for which the equipment has no active certification
+// valid through today plus the given number of days. It mirrors
+// models.EquipmentClass.EvaluateCompliance.
+const lackingCertification = `EXISTS (
+	SELECT 1 FROM equipment_classes ec
+	JOIN equipment_class_requirements r ON r.equipment_class_id = ec.id
+	JOIN certification_types t ON t.id = r.certification_type_id AND t.is_active
+	WHERE ec.id = equipment.class_id AND NOT EXISTS (
+		SELECT 1 FROM certifications c
+		WHERE c.equipment_id = equipment.id
+			AND c.certification_type_id = r.certification_type_id
+			AND c.status = 'active'
+			AND c.expiration_date >= CAST(? AS date) + %s
+	)
+)`
+
+var (
+	lackingValidCertification  = fmt.Sprintf(lackingCertification, "0")
+	lackingCertificationAtRisk = fmt.Sprintf(lackingCertification, "ec.at_risk_days")
+)
+
+type EquipmentRepository interface {
+	Create(equipment *models.Equipment) error
+	Update(equipment *models.Equipment) error
+	FindByID(id uuid.UUID) (*models.Equipment, error)
+	List(filter EquipmentFilter) ([]models.Equipment, int64, error)
+	AssetNumberExists(assetNumber string, excludeID uuid.UUID) bool
+	FindActiveInClasses(classIDs []uuid.UUID) ([]models.Equipment, error)
+}
+
+type EquipmentRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewEquipmentRepositoryImpl(db *gorm.DB) EquipmentRepository {
+	return &EquipmentRepositoryImpl{db: db}
+}
+
+func (r *EquipmentRepositoryImpl) Create(equipment *models.Equipment) error {
+	return r.db.Omit("Class").Create(equipment).Error
+}
+
+func (r *EquipmentRepositoryImpl) Update(equipment *models.Equipment) error {
+	return r.db.Omit("Class").Save(equipment).Error
+}
+
+func (r *EquipmentRepositoryImpl) FindByID(id uuid.UUID) (*models.Equipment, error) {
+	var equipment models.Equipment
+	err := r.db.
+		Preload("Class.RequiredTypes").
+		Preload("Class.OperatorTypes").
+		Where("id = ?", id).
+		First(&equipment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &equipment, nil
+}
+
+func (r *EquipmentRepositoryImpl) List(filter EquipmentFilter) ([]models.Equipment, int64, error) {
+	query := r.db.Model(&models.Equipment{})
+
+	if filter.Search != "" {
+		like := "%" + filter.Search + "%"
+		query = query.Where(
+			"name ILIKE ? OR asset_number ILIKE ? OR serial_number ILIKE ?",
+			like, like, like,
+		)
+	}
+	if filter.Location != "" {
+		query = query.Where("location = ?", filter.Location)
+	}
+	if filter.Manufacturer != "" {
+		query = query.Where("manufacturer = ?", filter.Manufacturer)
+	}
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
+	if filter.ClassID != nil {
+		query = query.Where("class_id = ?", *filter.ClassID)
+	}
+	if filter.ComplianceStatus != "" {
+		today := models.Today()
+		switch filter.ComplianceStatus {
+		case models.EquipmentNonCompliant:
+			query = query.Where(lackingValidCertification, today)
+		case models.EquipmentAtRisk:
+			query = query.Where(lackingCertificationAtRisk, today).Where("NOT "+lackingValidCertification, today)
+		case models.EquipmentCompliant:
+			query = query.Where("NOT "+lackingCertificationAtRisk, today)
+		}
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	page := filter.Pagination.Normalize()
+	var equipment []models.Equipment
+	err := query.
+		Preload("Class.RequiredTypes").
+		Order("asset_number ASC").
+		Limit(page.Limit).
+		Offset(page.Offset()).
+		Find(&equipment).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return equipment, total, nil
+}
+
+func (r *EquipmentRepositoryImpl) AssetNumberExists(assetNumber string, excludeID uuid.UUID) bool {
+	var count int64
+	query := r.db.Model(&models.Equipment{}).Where("asset_number = ?", assetNumber)
+	if excludeID != uuid.Nil {
+		query = query.Where("id <> ?", excludeID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return false
+	}
+	return count > 0
+}
+
+// FindActiveInClasses returns the active equipment assigned to any of the
+// given classes, ordered by asset number.
+func (r *EquipmentRepositoryImpl) FindActiveInClasses(classIDs []uuid.UUID) ([]models.Equipment, error) {
+	if len(classIDs) == 0 {
+		return nil, nil
+	}
+
+	var equipment []models.Equipment
+	err := r.db.
+		Preload("Class").
+		Where("is_active = ? AND class_id IN ?", true, classIDs).
+		Order("asset_number ASC").
+		Find(&equipment).Error
+	if err != nil {
+		return nil, err
+	}
+	return equipment, nil
+}
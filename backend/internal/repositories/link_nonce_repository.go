@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+// LinkNonceRepository tracks the nonces of single-use document links.
+type LinkNonceRepository interface {
+	// Claim marks nonce as used and reports whether this call was the first
+	// to do so. The record only needs to outlive the link.
+	Claim(nonce string, expiresIn time.Duration) (bool, error)
+}
+
+type linkNonceRepository struct {
+	client RedisClient
+}
+
+func NewLinkNonceRepository(client RedisClient) LinkNonceRepository {
+	return &linkNonceRepository{client: client}
+}
+
+func (r *linkNonceRepository) Claim(nonce string, expiresIn time.Duration) (bool, error) {
+	key := "doclink:used:" + nonce
+	return r.client.SetNX(context.Background(), key, "1", expiresIn).Result()
+}
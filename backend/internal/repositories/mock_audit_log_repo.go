@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"sync"
+	"time"
+
+	"certitrack/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// MockAuditLogRepository is an in-memory implementation of
+// AuditLogRepository used only in unit tests.
+type MockAuditLogRepository struct {
+	mu      sync.RWMutex
+	entries []models.AuditLog
+
+	// Optional hooks to simulate errors
+	CreateErr error
+}
+
+// NewMockAuditLogRepository creates an empty repository ready for testing.
+func NewMockAuditLogRepository() *MockAuditLogRepository {
+	return &MockAuditLogRepository{}
+}
+
+func (m *MockAuditLogRepository) Create(entry *models.AuditLog) error {
+	if m.CreateErr != nil {
+		return m.CreateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if entry.ID == uuid.Nil {
+		entry.ID = uuid.New()
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	m.entries = append(m.entries, *entry)
+	return nil
+}
+
+func (m *MockAuditLogRepository) ListByRecord(table string, recordID uuid.UUID) ([]models.AuditLog, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var out []models.AuditLog
+	for _, e := range m.entries {
+		if e.RecordTable == table && e.RecordID == recordID {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// All returns every recorded entry in insertion order.
+func (m *MockAuditLogRepository) All() []models.AuditLog {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]models.AuditLog(nil), m.entries...)
+}
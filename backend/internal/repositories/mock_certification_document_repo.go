@@ -0,0 +1,212 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"certitrack/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MockCertificationDocumentRepository is an in-memory implementation of
+// CertificationDocumentRepository used only in unit tests.
+type MockCertificationDocumentRepository struct {
+	mu       sync.RWMutex
+	byID     map[uuid.UUID]*models.CertificationDocument
+	versions map[uuid.UUID]*models.CertificationDocumentVersion
+
+	// Optional hooks to simulate errors
+	CreateErr error
+	UpdateErr error
+	DeleteErr error
+	FindErr   error
+}
+
+// NewMockCertificationDocumentRepository creates an empty repository ready for testing.
+func NewMockCertificationDocumentRepository() *MockCertificationDocumentRepository {
+	return &MockCertificationDocumentRepository{
+		byID:     make(map[uuid.UUID]*models.CertificationDocument),
+		versions: make(map[uuid.UUID]*models.CertificationDocumentVersion),
+	}
+}
+
+func (m *MockCertificationDocumentRepository) Create(doc *models.CertificationDocument) error {
+	if m.CreateErr != nil {
+		return m.CreateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if doc.ID == uuid.Nil {
+		doc.ID = uuid.New()
+	}
+	if doc.UploadedAt.IsZero() {
+		doc.UploadedAt = time.Now()
+	}
+	if doc.Version == 0 {
+		doc.Version = 1
+	}
+	stored := *doc
+	m.byID[doc.ID] = &stored
+	return nil
+}
+
+func (m *MockCertificationDocumentRepository) Delete(id uuid.UUID) error {
+	if m.DeleteErr != nil {
+		return m.DeleteErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.byID[id]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	delete(m.byID, id)
+	for vid, v := range m.versions {
+		if v.DocumentID == id {
+			delete(m.versions, vid)
+		}
+	}
+	return nil
+}
+
+func (m *MockCertificationDocumentRepository) FindByID(id uuid.UUID) (*models.CertificationDocument, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if d, ok := m.byID[id]; ok {
+		doc := *d
+		return &doc, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *MockCertificationDocumentRepository) ListByCertification(certID uuid.UUID) ([]models.CertificationDocument, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []models.CertificationDocument
+	for _, d := range m.byID {
+		if d.CertificationID == certID {
+			result = append(result, *d)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].UploadedAt.Before(result[j].UploadedAt) })
+	return result, nil
+}
+
+func (m *MockCertificationDocumentRepository) ReplaceCurrent(doc *models.CertificationDocument, archived *models.CertificationDocumentVersion) error {
+	if m.UpdateErr != nil {
+		return m.UpdateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, ok := m.byID[doc.ID]
+	if !ok || current.Version != archived.Version {
+		return ErrStaleDocumentVersion
+	}
+	stored := *doc
+	m.byID[doc.ID] = &stored
+
+	archived.DocumentID = doc.ID
+	if archived.ID == uuid.Nil {
+		archived.ID = uuid.New()
+	}
+	version := *archived
+	m.versions[archived.ID] = &version
+	return nil
+}
+
+func (m *MockCertificationDocumentRepository) ListVersions(docID uuid.UUID) ([]models.CertificationDocumentVersion, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []models.CertificationDocumentVersion
+	for _, v := range m.versions {
+		if v.DocumentID == docID {
+			result = append(result, *v)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+	return result, nil
+}
+
+func (m *MockCertificationDocumentRepository) FindVersion(docID uuid.UUID, version int) (*models.CertificationDocumentVersion, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, v := range m.versions {
+		if v.DocumentID == docID && v.Version == version {
+			found := *v
+			return &found, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *MockCertificationDocumentRepository) DeleteVersion(id uuid.UUID) error {
+	if m.DeleteErr != nil {
+		return m.DeleteErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.versions[id]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	delete(m.versions, id)
+	return nil
+}
+
+func (m *MockCertificationDocumentRepository) CountReferences(filePath string) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var count int64
+	for _, d := range m.byID {
+		if d.FilePath == filePath {
+			count++
+		}
+	}
+	for _, v := range m.versions {
+		if v.FilePath == filePath {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MockCertificationDocumentRepository) ListStoredObjects() ([]StoredObject, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var objects []StoredObject
+	for _, d := range m.byID {
+		objects = append(objects, StoredObject{DocumentID: d.ID, Version: d.Version, FilePath: d.FilePath, ContentHash: d.ContentHash})
+	}
+	for _, v := range m.versions {
+		objects = append(objects, StoredObject{DocumentID: v.DocumentID, Version: v.Version, FilePath: v.FilePath, ContentHash: v.ContentHash})
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		if objects[i].FilePath != objects[j].FilePath {
+			return objects[i].FilePath < objects[j].FilePath
+		}
+		return objects[i].Version < objects[j].Version
+	})
+	return objects, nil
+}
@@ -0,0 +1,334 @@
+package repositories
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"certitrack/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MockCertificationRepository is an in-memory implementation of
+// CertificationRepository used only in unit tests.
+type MockCertificationRepository struct {
+	mu      sync.RWMutex
+	byID    map[uuid.UUID]*models.Certification
+	history []models.CertificationStatusHistory
+	// people resolves holders for FindActiveHeldByPeople; see WithPeople.
+	people *MockPersonRepository
+
+	// Optional hooks to simulate errors
+	CreateErr error
+	UpdateErr error
+	DeleteErr error
+	FindErr   error
+}
+
+// NewMockCertificationRepository creates an empty repository ready for testing.
+func NewMockCertificationRepository() *MockCertificationRepository {
+	return &MockCertificationRepository{
+		byID: make(map[uuid.UUID]*models.Certification),
+	}
+}
+
+func (m *MockCertificationRepository) Create(cert *models.Certification) error {
+	if m.CreateErr != nil {
+		return m.CreateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if cert.ID == uuid.Nil {
+		cert.ID = uuid.New()
+	}
+	stored := *cert
+	m.byID[cert.ID] = &stored
+	return nil
+}
+
+func (m *MockCertificationRepository) Update(cert *models.Certification) error {
+	if m.UpdateErr != nil {
+		return m.UpdateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored := *cert
+	// Like the real repository, Update never changes the status.
+	if current, ok := m.byID[cert.ID]; ok {
+		stored.Status = current.Status
+	}
+	m.byID[cert.ID] = &stored
+	return nil
+}
+
+func (m *MockCertificationRepository) Delete(id uuid.UUID) error {
+	if m.DeleteErr != nil {
+		return m.DeleteErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.byID[id]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	delete(m.byID, id)
+	return nil
+}
+
+func (m *MockCertificationRepository) FindByID(id uuid.UUID) (*models.Certification, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if c, ok := m.byID[id]; ok {
+		cert := *c
+		return &cert, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *MockCertificationRepository) List(filter CertificationFilter) ([]models.Certification, int64, error) {
+	if m.FindErr != nil {
+		return nil, 0, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	today := models.Today()
+	search := strings.ToLower(filter.Search)
+	var result []models.Certification
+	for _, c := range m.byID {
+		if filter.PersonID != nil && (c.PersonID == nil || *c.PersonID != *filter.PersonID) {
+			continue
+		}
+		if filter.EquipmentID != nil && (c.EquipmentID == nil || *c.EquipmentID != *filter.EquipmentID) {
+			continue
+		}
+		if filter.CertificationTypeID != nil && c.CertificationTypeID != *filter.CertificationTypeID {
+			continue
+		}
+		if filter.Status != "" && c.Status != filter.Status {
+			continue
+		}
+		if filter.ExpiringWithinDays != nil {
+			limit := today.AddDate(0, 0, *filter.ExpiringWithinDays)
+			if c.Status != models.CertificationStatusActive || c.ExpirationDate.Before(today) || c.ExpirationDate.After(limit) {
+				continue
+			}
+		}
+		if search != "" && !strings.Contains(strings.ToLower(c.CertificateNumber+" "+c.IssuingAuthority), search) {
+			continue
+		}
+		result = append(result, *c)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ExpirationDate.Before(result[j].ExpirationDate) })
+
+	return paginate(result, filter.Pagination), int64(len(result)), nil
+}
+
+func (m *MockCertificationRepository) ChangeStatus(cert *models.Certification, entry *models.CertificationStatusHistory) error {
+	if m.UpdateErr != nil {
+		return m.UpdateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.changeStatus(cert, entry)
+}
+
+func (m *MockCertificationRepository) CreateRenewal(successor, predecessor *models.Certification, entry *models.CertificationStatusHistory) error {
+	if m.CreateErr != nil {
+		return m.CreateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, c := range m.byID {
+		if c.PredecessorID != nil && successor.PredecessorID != nil && *c.PredecessorID == *successor.PredecessorID {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	if err := m.changeStatus(predecessor, entry); err != nil {
+		return err
+	}
+
+	if successor.ID == uuid.Nil {
+		successor.ID = uuid.New()
+	}
+	stored := *successor
+	m.byID[successor.ID] = &stored
+	return nil
+}
+
+func (m *MockCertificationRepository) FindSuccessor(id uuid.UUID) (*models.Certification, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, c := range m.byID {
+		if c.PredecessorID != nil && *c.PredecessorID == id {
+			cert := *c
+			return &cert, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// changeStatus must be called with m.mu held.
+func (m *MockCertificationRepository) changeStatus(cert *models.Certification, entry *models.CertificationStatusHistory) error {
+	stored, ok := m.byID[cert.ID]
+	if !ok || stored.Status != entry.FromStatus {
+		return ErrStaleCertificationStatus
+	}
+	stored.Status = entry.ToStatus
+	stored.UpdatedBy = cert.UpdatedBy
+
+	if entry.ID == uuid.Nil {
+		entry.ID = uuid.New()
+	}
+	if entry.ChangedAt.IsZero() {
+		entry.ChangedAt = time.Now()
+	}
+	entry.CertificationID = cert.ID
+	m.history = append(m.history, *entry)
+	return nil
+}
+
+func (m *MockCertificationRepository) ListStatusHistory(certID uuid.UUID) ([]models.CertificationStatusHistory, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []models.CertificationStatusHistory
+	for _, entry := range m.history {
+		if entry.CertificationID == certID {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockCertificationRepository) FindOverdueActive(asOf time.Time) ([]models.Certification, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []models.Certification
+	for _, c := range m.byID {
+		if c.Status == models.CertificationStatusActive && c.DaysUntil(asOf) < 0 {
+			result = append(result, *c)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockCertificationRepository) FindActiveExpiringBetween(from, to time.Time) ([]models.Certification, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []models.Certification
+	for _, c := range m.byID {
+		if c.Status != models.CertificationStatusActive || c.DaysUntil(from) < 0 || c.DaysUntil(to) > 0 {
+			continue
+		}
+		cert := *c
+		if c.PersonID != nil && m.people != nil {
+			person, err := m.people.FindByID(*c.PersonID)
+			if err != nil || !person.IsActive {
+				continue
+			}
+			cert.Person = person
+		}
+		result = append(result, cert)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ExpirationDate.Before(result[j].ExpirationDate) })
+	return result, nil
+}
+
+func (m *MockCertificationRepository) FindForPeople(personIDs []uuid.UUID) ([]models.Certification, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	wanted := make(map[uuid.UUID]bool, len(personIDs))
+	for _, id := range personIDs {
+		wanted[id] = true
+	}
+	var result []models.Certification
+	for _, c := range m.byID {
+		if c.PersonID != nil && wanted[*c.PersonID] {
+			result = append(result, *c)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ExpirationDate.After(result[j].ExpirationDate) })
+	return result, nil
+}
+
+func (m *MockCertificationRepository) FindForEquipment(equipmentIDs []uuid.UUID) ([]models.Certification, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	wanted := make(map[uuid.UUID]bool, len(equipmentIDs))
+	for _, id := range equipmentIDs {
+		wanted[id] = true
+	}
+	var result []models.Certification
+	for _, c := range m.byID {
+		if c.EquipmentID != nil && wanted[*c.EquipmentID] {
+			result = append(result, *c)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ExpirationDate.After(result[j].ExpirationDate) })
+	return result, nil
+}
+
+// WithPeople makes the repository resolve certificate holders from the given
+// repository, as the real one joins the people table and preloads them.
+func (m *MockCertificationRepository) WithPeople(people *MockPersonRepository) *MockCertificationRepository {
+	m.people = people
+	return m
+}
+
+func (m *MockCertificationRepository) FindActiveHeldByPeople(typeIDs []uuid.UUID) ([]models.Certification, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	wanted := make(map[uuid.UUID]bool, len(typeIDs))
+	for _, id := range typeIDs {
+		wanted[id] = true
+	}
+	var result []models.Certification
+	for _, c := range m.byID {
+		if c.PersonID == nil || !wanted[c.CertificationTypeID] || c.Status != models.CertificationStatusActive || m.people == nil {
+			continue
+		}
+		person, err := m.people.FindByID(*c.PersonID)
+		if err != nil || !person.IsActive {
+			continue
+		}
+		cert := *c
+		cert.Person = person
+		result = append(result, cert)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ExpirationDate.After(result[j].ExpirationDate) })
+	return result, nil
+}
@@ -0,0 +1,138 @@
+package repositories
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"certitrack/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MockCertificationRequirementRepository is an in-memory implementation of
+// CertificationRequirementRepository used only in unit tests. Certification
+// types are resolved from the given type repository, as the real repository
+// preloads them.
+type MockCertificationRequirementRepository struct {
+	mu    sync.RWMutex
+	byID  map[uuid.UUID]*models.CertificationRequirement
+	types *MockCertificationTypeRepository
+
+	// Optional hooks to simulate errors
+	CreateErr error
+	DeleteErr error
+	FindErr   error
+}
+
+// NewMockCertificationRequirementRepository creates an empty repository ready for testing.
+func NewMockCertificationRequirementRepository(types *MockCertificationTypeRepository) *MockCertificationRequirementRepository {
+	return &MockCertificationRequirementRepository{
+		byID:  make(map[uuid.UUID]*models.CertificationRequirement),
+		types: types,
+	}
+}
+
+func (m *MockCertificationRequirementRepository) Create(requirement *models.CertificationRequirement) error {
+	if m.CreateErr != nil {
+		return m.CreateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, r := range m.byID {
+		if r.CertificationTypeID == requirement.CertificationTypeID &&
+			r.Position == requirement.Position && r.Department == requirement.Department {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	if requirement.ID == uuid.Nil {
+		requirement.ID = uuid.New()
+	}
+	stored := *requirement
+	stored.CertificationType = nil
+	m.byID[requirement.ID] = &stored
+	return nil
+}
+
+func (m *MockCertificationRequirementRepository) Delete(id uuid.UUID) error {
+	if m.DeleteErr != nil {
+		return m.DeleteErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.byID[id]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	delete(m.byID, id)
+	return nil
+}
+
+func (m *MockCertificationRequirementRepository) FindByID(id uuid.UUID) (*models.CertificationRequirement, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if r, ok := m.byID[id]; ok {
+		return m.withType(r), nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *MockCertificationRequirementRepository) List(filter CertificationRequirementFilter) ([]models.CertificationRequirement, int64, error) {
+	if m.FindErr != nil {
+		return nil, 0, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []models.CertificationRequirement
+	for _, r := range m.byID {
+		if filter.Position != "" && !strings.EqualFold(r.Position, filter.Position) {
+			continue
+		}
+		if filter.Department != "" && !strings.EqualFold(r.Department, filter.Department) {
+			continue
+		}
+		if filter.CertificationTypeID != nil && r.CertificationTypeID != *filter.CertificationTypeID {
+			continue
+		}
+		result = append(result, *m.withType(r))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Department != result[j].Department {
+			return result[i].Department < result[j].Department
+		}
+		return result[i].Position < result[j].Position
+	})
+
+	return paginate(result, filter.Pagination), int64(len(result)), nil
+}
+
+func (m *MockCertificationRequirementRepository) FindForActiveTypes() ([]models.CertificationRequirement, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []models.CertificationRequirement
+	for _, r := range m.byID {
+		requirement := m.withType(r)
+		if requirement.CertificationType != nil && requirement.CertificationType.IsActive {
+			result = append(result, *requirement)
+		}
+	}
+	return result, nil
+}
+
+// withType must be called with m.mu held.
+func (m *MockCertificationRequirementRepository) withType(r *models.CertificationRequirement) *models.CertificationRequirement {
+	requirement := *r
+	if certType, err := m.types.FindByID(r.CertificationTypeID); err == nil {
+		requirement.CertificationType = certType
+	}
+	return &requirement
+}
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+
+	"certitrack/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MockCertificationTypeRepository is an in-memory implementation of
+// CertificationTypeRepository used only in unit tests.
+type MockCertificationTypeRepository struct {
+	mu   sync.RWMutex
+	byID map[uuid.UUID]*models.CertificationType
+
+	// Optional hooks to simulate errors
+	CreateErr error
+	UpdateErr error
+	DeleteErr error
+	FindErr   error
+}
+
+// NewMockCertificationTypeRepository creates an empty repository ready for testing.
+func NewMockCertificationTypeRepository() *MockCertificationTypeRepository {
+	return &MockCertificationTypeRepository{
+		byID: make(map[uuid.UUID]*models.CertificationType),
+	}
+}
+
+func (m *MockCertificationTypeRepository) Create(certType *models.CertificationType) error {
+	if m.CreateErr != nil {
+		return m.CreateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if certType.ID == uuid.Nil {
+		certType.ID = uuid.New()
+	}
+	m.byID[certType.ID] = certType
+	return nil
+}
+
+func (m *MockCertificationTypeRepository) Update(certType *models.CertificationType) error {
+	if m.UpdateErr != nil {
+		return m.UpdateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.byID[certType.ID] = certType
+	return nil
+}
+
+func (m *MockCertificationTypeRepository) Delete(id uuid.UUID) error {
+	if m.DeleteErr != nil {
+		return m.DeleteErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.byID[id]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	delete(m.byID, id)
+	return nil
+}
+
+func (m *MockCertificationTypeRepository) FindByID(id uuid.UUID) (*models.CertificationType, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if ct, ok := m.byID[id]; ok {
+		certType := *ct
+		return &certType, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *MockCertificationTypeRepository) List(filter CertificationTypeFilter) ([]models.CertificationType, int64, error) {
+	if m.FindErr != nil {
+		return nil, 0, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []models.CertificationType
+	for _, ct := range m.byID {
+		if filter.Category != "" && ct.Category != filter.Category {
+			continue
+		}
+		if filter.IsActive != nil && ct.IsActive != *filter.IsActive {
+			continue
+		}
+		result = append(result, *ct)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return paginate(result, filter.Pagination), int64(len(result)), nil
+}
@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"certitrack/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// MockDocumentAccessLogRepository is an in-memory implementation of
+// DocumentAccessLogRepository used only in unit tests.
+type MockDocumentAccessLogRepository struct {
+	mu      sync.RWMutex
+	entries []models.DocumentAccessLog
+
+	// Optional hooks to simulate errors
+	CreateErr error
+	FindErr   error
+}
+
+// NewMockDocumentAccessLogRepository creates an empty repository ready for testing.
+func NewMockDocumentAccessLogRepository() *MockDocumentAccessLogRepository {
+	return &MockDocumentAccessLogRepository{}
+}
+
+func (m *MockDocumentAccessLogRepository) Create(entry *models.DocumentAccessLog) error {
+	if m.CreateErr != nil {
+		return m.CreateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if entry.ID == uuid.Nil {
+		entry.ID = uuid.New()
+	}
+	if entry.AccessedAt.IsZero() {
+		entry.AccessedAt = time.Now()
+	}
+	m.entries = append(m.entries, *entry)
+	return nil
+}
+
+func (m *MockDocumentAccessLogRepository) List(filter DocumentAccessFilter) ([]models.DocumentAccessLog, int64, error) {
+	if m.FindErr != nil {
+		return nil, 0, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var matched []models.DocumentAccessLog
+	for _, e := range m.entries {
+		if filter.DocumentID != nil && e.DocumentID != *filter.DocumentID {
+			continue
+		}
+		if filter.UserID != nil && (e.UserID == nil || *e.UserID != *filter.UserID) {
+			continue
+		}
+		matched = append(matched, e)
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].AccessedAt.After(matched[j].AccessedAt)
+	})
+
+	total := int64(len(matched))
+	page := filter.Pagination.Normalize()
+	start := page.Offset()
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := start + page.Limit
+	if end > len(matched) {
+		end = len(matched)
+	}
+	return matched[start:end], total, nil
+}
+
+// All returns every recorded entry in insertion order.
+func (m *MockDocumentAccessLogRepository) All() []models.DocumentAccessLog {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]models.DocumentAccessLog(nil), m.entries...)
+}
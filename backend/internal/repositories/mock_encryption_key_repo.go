@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"certitrack/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// MockEncryptionKeyRepository is an in-memory implementation of
+// EncryptionKeyRepository used only in unit tests.
+type MockEncryptionKeyRepository struct {
+	mu   sync.RWMutex
+	keys map[string]models.EncryptionKey
+
+	// Optional hooks to simulate errors
+	CreateErr error
+	UpdateErr error
+	DeleteErr error
+	FindErr   error
+}
+
+// NewMockEncryptionKeyRepository creates an empty repository ready for testing.
+func NewMockEncryptionKeyRepository() *MockEncryptionKeyRepository {
+	return &MockEncryptionKeyRepository{
+		keys: make(map[string]models.EncryptionKey),
+	}
+}
+
+func (m *MockEncryptionKeyRepository) FindByObjectKey(objectKey string) (*models.EncryptionKey, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	key, ok := m.keys[objectKey]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	key.WrappedKey = append([]byte(nil), key.WrappedKey...)
+	return &key, nil
+}
+
+func (m *MockEncryptionKeyRepository) CreateIfAbsent(key *models.EncryptionKey) error {
+	if m.CreateErr != nil {
+		return m.CreateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.keys[key.ObjectKey]; ok {
+		return nil
+	}
+	stored := *key
+	stored.WrappedKey = append([]byte(nil), key.WrappedKey...)
+	stored.CreatedAt = time.Now()
+	stored.UpdatedAt = stored.CreatedAt
+	m.keys[key.ObjectKey] = stored
+	return nil
+}
+
+func (m *MockEncryptionKeyRepository) DeleteByObjectKey(objectKey string) error {
+	if m.DeleteErr != nil {
+		return m.DeleteErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.keys, objectKey)
+	return nil
+}
+
+func (m *MockEncryptionKeyRepository) ListNotWrappedWith(masterKeyID, after string, limit int) ([]models.EncryptionKey, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var out []models.EncryptionKey
+	for _, k := range m.keys {
+		if k.MasterKeyID != masterKeyID && k.ObjectKey > after {
+			out = append(out, k)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ObjectKey < out[j].ObjectKey })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (m *MockEncryptionKeyRepository) Rewrap(objectKey, fromMasterKeyID, toMasterKeyID string, wrapped []byte) error {
+	if m.UpdateErr != nil {
+		return m.UpdateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key, ok := m.keys[objectKey]
+	if !ok || key.MasterKeyID != fromMasterKeyID {
+		return nil
+	}
+	key.MasterKeyID = toMasterKeyID
+	key.WrappedKey = append([]byte(nil), wrapped...)
+	key.UpdatedAt = time.Now()
+	m.keys[objectKey] = key
+	return nil
+}
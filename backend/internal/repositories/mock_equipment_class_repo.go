@@ -0,0 +1,149 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+
+	"certitrack/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MockEquipmentClassRepository is an in-memory implementation of
+// EquipmentClassRepository used only in unit tests. Required types are
+// resolved from the given type repository, as the real repository preloads
+// them.
+type MockEquipmentClassRepository struct {
+	mu    sync.RWMutex
+	byID  map[uuid.UUID]*models.EquipmentClass
+	types *MockCertificationTypeRepository
+
+	// Optional hooks to simulate errors
+	CreateErr error
+	UpdateErr error
+	DeleteErr error
+	FindErr   error
+}
+
+// NewMockEquipmentClassRepository creates an empty repository ready for testing.
+func NewMockEquipmentClassRepository(types *MockCertificationTypeRepository) *MockEquipmentClassRepository {
+	return &MockEquipmentClassRepository{
+		byID:  make(map[uuid.UUID]*models.EquipmentClass),
+		types: types,
+	}
+}
+
+func (m *MockEquipmentClassRepository) Create(class *models.EquipmentClass) error {
+	if m.CreateErr != nil {
+		return m.CreateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, c := range m.byID {
+		if c.Name == class.Name {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	if class.ID == uuid.Nil {
+		class.ID = uuid.New()
+	}
+	m.byID[class.ID] = cloneClass(class)
+	return nil
+}
+
+func (m *MockEquipmentClassRepository) Update(class *models.EquipmentClass) error {
+	if m.UpdateErr != nil {
+		return m.UpdateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, c := range m.byID {
+		if id != class.ID && c.Name == class.Name {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	m.byID[class.ID] = cloneClass(class)
+	return nil
+}
+
+func (m *MockEquipmentClassRepository) Delete(id uuid.UUID) error {
+	if m.DeleteErr != nil {
+		return m.DeleteErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.byID[id]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	delete(m.byID, id)
+	return nil
+}
+
+func (m *MockEquipmentClassRepository) FindByID(id uuid.UUID) (*models.EquipmentClass, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if c, ok := m.byID[id]; ok {
+		return m.withTypes(c), nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *MockEquipmentClassRepository) List(pagination Pagination) ([]models.EquipmentClass, int64, error) {
+	if m.FindErr != nil {
+		return nil, 0, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []models.EquipmentClass
+	for _, c := range m.byID {
+		result = append(result, *m.withTypes(c))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return paginate(result, pagination), int64(len(result)), nil
+}
+
+func (m *MockEquipmentClassRepository) FindWithOperatorTypes() ([]models.EquipmentClass, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []models.EquipmentClass
+	for _, c := range m.byID {
+		if len(c.OperatorTypes) > 0 {
+			result = append(result, *m.withTypes(c))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+func cloneClass(c *models.EquipmentClass) *models.EquipmentClass {
+	class := *c
+	class.RequiredTypes = append([]models.CertificationType(nil), c.RequiredTypes...)
+	class.OperatorTypes = append([]models.CertificationType(nil), c.OperatorTypes...)
+	return &class
+}
+
+// withTypes returns a copy of the class with its required and operator types
+// reloaded.
+func (m *MockEquipmentClassRepository) withTypes(c *models.EquipmentClass) *models.EquipmentClass {
+	class := cloneClass(c)
+	for _, types := range [][]models.CertificationType{class.RequiredTypes, class.OperatorTypes} {
+		for i, t := range types {
+			if certType, err := m.types.FindByID(t.ID); err == nil {
+				types[i] = *certType
+			}
+		}
+	}
+	return class
+}
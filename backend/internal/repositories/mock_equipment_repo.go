@@ -0,0 +1,165 @@
+package repositories
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"certitrack/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MockEquipmentRepository is an in-memory implementation of EquipmentRepository used only in unit tests.
+type MockEquipmentRepository struct {
+	mu   sync.RWMutex
+	byID map[uuid.UUID]*models.Equipment
+
+	// Classes and certifications are consulted, when set with
+	// WithCompliance, to preload classes and filter by compliance status.
+	classes *MockEquipmentClassRepository
+	certs   *MockCertificationRepository
+
+	// Optional hooks to simulate errors
+	CreateErr error
+	UpdateErr error
+	FindErr   error
+}
+
+// NewMockEquipmentRepository creates an empty repository ready for testing.
+func NewMockEquipmentRepository() *MockEquipmentRepository {
+	return &MockEquipmentRepository{
+		byID: make(map[uuid.UUID]*models.Equipment),
+	}
+}
+
+// WithCompliance makes the repository resolve equipment classes and evaluate
+// compliance from the given repositories, as the real one does in SQL.
+func (m *MockEquipmentRepository) WithCompliance(classes *MockEquipmentClassRepository, certs *MockCertificationRepository) *MockEquipmentRepository {
+	m.classes = classes
+	m.certs = certs
+	return m
+}
+
+func (m *MockEquipmentRepository) Create(equipment *models.Equipment) error {
+	if m.CreateErr != nil {
+		return m.CreateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if equipment.ID == uuid.Nil {
+		equipment.ID = uuid.New()
+	}
+	m.byID[equipment.ID] = equipment
+	return nil
+}
+
+func (m *MockEquipmentRepository) Update(equipment *models.Equipment) error {
+	if m.UpdateErr != nil {
+		return m.UpdateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.byID[equipment.ID] = equipment
+	return nil
+}
+
+func (m *MockEquipmentRepository) FindByID(id uuid.UUID) (*models.Equipment, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if e, ok := m.byID[id]; ok {
+		return m.withClass(e), nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *MockEquipmentRepository) List(filter EquipmentFilter) ([]models.Equipment, int64, error) {
+	if m.FindErr != nil {
+		return nil, 0, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	search := strings.ToLower(filter.Search)
+	var result []models.Equipment
+	for _, e := range m.byID {
+		if filter.Location != "" && e.Location != filter.Location {
+			continue
+		}
+		if filter.Manufacturer != "" && e.Manufacturer != filter.Manufacturer {
+			continue
+		}
+		if filter.IsActive != nil && e.IsActive != *filter.IsActive {
+			continue
+		}
+		if filter.ClassID != nil && (e.ClassID == nil || *e.ClassID != *filter.ClassID) {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(e.Name+" "+e.AssetNumber+" "+e.SerialNumber), search) {
+			continue
+		}
+		equipment := m.withClass(e)
+		if filter.ComplianceStatus != "" && m.complianceStatus(equipment) != filter.ComplianceStatus {
+			continue
+		}
+		result = append(result, *equipment)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].AssetNumber < result[j].AssetNumber })
+
+	return paginate(result, filter.Pagination), int64(len(result)), nil
+}
+
+func (m *MockEquipmentRepository) AssetNumberExists(assetNumber string, excludeID uuid.UUID) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for id, e := range m.byID {
+		if id != excludeID && e.AssetNumber == assetNumber {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MockEquipmentRepository) FindActiveInClasses(classIDs []uuid.UUID) ([]models.Equipment, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []models.Equipment
+	for _, e := range m.byID {
+		if e.IsActive && e.ClassID != nil && slices.Contains(classIDs, *e.ClassID) {
+			result = append(result, *m.withClass(e))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].AssetNumber < result[j].AssetNumber })
+	return result, nil
+}
+
+// withClass returns a copy of e with its class resolved; m.mu must be held.
+func (m *MockEquipmentRepository) withClass(e *models.Equipment) *models.Equipment {
+	equipment := *e
+	equipment.Class = nil
+	if m.classes != nil && e.ClassID != nil {
+		if class, err := m.classes.FindByID(*e.ClassID); err == nil {
+			equipment.Class = class
+		}
+	}
+	return &equipment
+}
+
+func (m *MockEquipmentRepository) complianceStatus(e *models.Equipment) string {
+	var certs []models.Certification
+	if m.certs != nil {
+		certs, _ = m.certs.FindForEquipment([]uuid.UUID{e.ID})
+	}
+	return e.Class.EvaluateCompliance(certs, time.Now()).Status
+}
@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"sync"
+	"time"
+)
+
+// MockLinkNonceRepository is an in-memory implementation of
+// LinkNonceRepository used only in unit tests. Expiry is not simulated.
+type MockLinkNonceRepository struct {
+	mu     sync.Mutex
+	claims map[string]bool
+
+	// Optional hooks to simulate errors
+	ClaimErr error
+}
+
+// NewMockLinkNonceRepository creates an empty repository ready for testing.
+func NewMockLinkNonceRepository() *MockLinkNonceRepository {
+	return &MockLinkNonceRepository{claims: make(map[string]bool)}
+}
+
+func (m *MockLinkNonceRepository) Claim(nonce string, expiresIn time.Duration) (bool, error) {
+	if m.ClaimErr != nil {
+		return false, m.ClaimErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.claims[nonce] {
+		return false, nil
+	}
+	m.claims[nonce] = true
+	return true, nil
+}
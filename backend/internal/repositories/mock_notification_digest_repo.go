@@ -0,0 +1,221 @@
+package repositories
+
+import (
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"certitrack/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MockNotificationDigestRepository is an in-memory implementation of
+// NotificationDigestRepository used only in unit tests. It reads reminders
+// from a MockNotificationRepository, and their certifications from the
+// repository that one was given via WithCertifications.
+type MockNotificationDigestRepository struct {
+	mu            sync.RWMutex
+	byID          map[uuid.UUID]*models.NotificationDigest
+	notifications *MockNotificationRepository
+
+	// Optional hooks to simulate errors
+	CreateErr error
+	UpdateErr error
+	FindErr   error
+}
+
+// NewMockNotificationDigestRepository creates an empty repository ready for
+// testing.
+func NewMockNotificationDigestRepository(notifications *MockNotificationRepository) *MockNotificationDigestRepository {
+	return &MockNotificationDigestRepository{
+		byID:          make(map[uuid.UUID]*models.NotificationDigest),
+		notifications: notifications,
+	}
+}
+
+func (m *MockNotificationDigestRepository) FindPendingItems() ([]DigestItem, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var items []DigestItem
+	for _, n := range m.notifications.All() {
+		if !m.certificationActive(n.CertificationID) {
+			continue
+		}
+		for _, recipient := range n.DigestRecipients {
+			if !m.inDigest(n.ID, recipient) {
+				items = append(items, DigestItem{NotificationID: n.ID, Recipient: recipient})
+			}
+		}
+	}
+	// All is ordered by schedule, so a stable sort keeps that within each
+	// recipient.
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Recipient < items[j].Recipient })
+	return items, nil
+}
+
+func (m *MockNotificationDigestRepository) CreateDigest(digest *models.NotificationDigest, notificationIDs []uuid.UUID) (bool, error) {
+	if m.CreateErr != nil {
+		return false, m.CreateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, d := range m.byID {
+		if d.Recipient == digest.Recipient && d.PeriodStart.Equal(digest.PeriodStart) {
+			return false, nil
+		}
+	}
+	if digest.ID == uuid.Nil {
+		digest.ID = uuid.New()
+	}
+	if digest.Status == "" {
+		digest.Status = models.NotificationStatusPending
+	}
+	digest.Items = make([]models.NotificationDigestItem, len(notificationIDs))
+	for i, id := range notificationIDs {
+		digest.Items[i] = models.NotificationDigestItem{NotificationDigestID: digest.ID, NotificationID: id}
+	}
+	m.byID[digest.ID] = cloneDigest(digest)
+	return true, nil
+}
+
+func (m *MockNotificationDigestRepository) ClaimDue(now time.Time, lease time.Duration, limit int) ([]models.NotificationDigest, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var due []*models.NotificationDigest
+	for _, d := range m.byID {
+		if d.Status == models.NotificationStatusPending && !d.NextAttemptAt.After(now) {
+			due = append(due, d)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].NextAttemptAt.Before(due[j].NextAttemptAt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	claimed := make([]models.NotificationDigest, 0, len(due))
+	for _, d := range due {
+		d.Attempts++
+		d.NextAttemptAt = now.Add(lease)
+		digest := *cloneDigest(d)
+		for i := range digest.Items {
+			digest.Items[i].Notification = m.loadNotification(digest.Items[i].NotificationID)
+		}
+		claimed = append(claimed, digest)
+	}
+	return claimed, nil
+}
+
+func (m *MockNotificationDigestRepository) SaveDelivery(digest *models.NotificationDigest, attempts int) error {
+	if m.UpdateErr != nil {
+		return m.UpdateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.byID[digest.ID]
+	if !ok || stored.Attempts != attempts {
+		return ErrStaleNotification
+	}
+	updated := cloneDigest(digest)
+	updated.Items = stored.Items
+	m.byID[digest.ID] = updated
+	return nil
+}
+
+func (m *MockNotificationDigestRepository) FindByID(id uuid.UUID) (*models.NotificationDigest, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if d, ok := m.byID[id]; ok {
+		return cloneDigest(d), nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *MockNotificationDigestRepository) List(filter NotificationDigestFilter) ([]models.NotificationDigest, int64, error) {
+	if m.FindErr != nil {
+		return nil, 0, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []models.NotificationDigest
+	for _, d := range m.byID {
+		if filter.Status != "" && d.Status != filter.Status {
+			continue
+		}
+		if filter.Recipient != "" && d.Recipient != filter.Recipient {
+			continue
+		}
+		result = append(result, *cloneDigest(d))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].PeriodStart.Equal(result[j].PeriodStart) {
+			return result[i].PeriodStart.After(result[j].PeriodStart)
+		}
+		return result[i].Recipient < result[j].Recipient
+	})
+
+	return paginate(result, filter.Pagination), int64(len(result)), nil
+}
+
+func (m *MockNotificationDigestRepository) certificationActive(id uuid.UUID) bool {
+	if m.notifications.certs == nil {
+		return true
+	}
+	cert, err := m.notifications.certs.FindByID(id)
+	return err == nil && cert.Status == models.CertificationStatusActive
+}
+
+func (m *MockNotificationDigestRepository) inDigest(notificationID uuid.UUID, recipient string) bool {
+	for _, d := range m.byID {
+		if d.Recipient != recipient {
+			continue
+		}
+		if slices.ContainsFunc(d.Items, func(item models.NotificationDigestItem) bool {
+			return item.NotificationID == notificationID
+		}) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MockNotificationDigestRepository) loadNotification(id uuid.UUID) *models.Notification {
+	n, err := m.notifications.FindByID(id)
+	if err != nil {
+		return nil
+	}
+	if m.notifications.certs != nil {
+		if cert, err := m.notifications.certs.FindByID(n.CertificationID); err == nil {
+			n.Certification = cert
+		}
+	}
+	return n
+}
+
+func cloneDigest(d *models.NotificationDigest) *models.NotificationDigest {
+	clone := *d
+	clone.Items = make([]models.NotificationDigestItem, len(d.Items))
+	for i, item := range d.Items {
+		clone.Items[i] = models.NotificationDigestItem{
+			NotificationDigestID: item.NotificationDigestID,
+			NotificationID:       item.NotificationID,
+		}
+	}
+	return &clone
+}
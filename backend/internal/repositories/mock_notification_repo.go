@@ -0,0 +1,303 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"certitrack/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MockNotificationRepository is an in-memory implementation of
+// NotificationRepository used only in unit tests. It enforces the same
+// unique key as the notifications table.
+type MockNotificationRepository struct {
+	mu          sync.RWMutex
+	byID        map[uuid.UUID]*models.Notification
+	escalations map[uuid.UUID]*models.NotificationEscalation
+	// certs resolves certifications for ClaimDue; see WithCertifications.
+	certs *MockCertificationRepository
+
+	// Optional hooks to simulate errors
+	CreateErr error
+	UpdateErr error
+	FindErr   error
+}
+
+// NewMockNotificationRepository creates an empty repository ready for testing.
+func NewMockNotificationRepository() *MockNotificationRepository {
+	return &MockNotificationRepository{
+		byID:        make(map[uuid.UUID]*models.Notification),
+		escalations: make(map[uuid.UUID]*models.NotificationEscalation),
+	}
+}
+
+// WithCertifications makes ClaimDue load certifications from the given
+// repository, as the real one preloads them.
+func (m *MockNotificationRepository) WithCertifications(certs *MockCertificationRepository) *MockNotificationRepository {
+	m.certs = certs
+	return m
+}
+
+func (m *MockNotificationRepository) Schedule(notification *models.Notification) (bool, error) {
+	if m.CreateErr != nil {
+		return false, m.CreateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, n := range m.byID {
+		if n.CertificationID == notification.CertificationID &&
+			n.NotificationRuleID == notification.NotificationRuleID &&
+			n.DaysBeforeExpiration == notification.DaysBeforeExpiration {
+			return false, nil
+		}
+	}
+	if notification.ID == uuid.Nil {
+		notification.ID = uuid.New()
+	}
+	if notification.Status == "" {
+		notification.Status = models.NotificationStatusPending
+	}
+	m.byID[notification.ID] = cloneNotification(notification)
+	return true, nil
+}
+
+func (m *MockNotificationRepository) ClaimDue(now time.Time, lease time.Duration, limit int) ([]models.Notification, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var due []*models.Notification
+	for _, n := range m.byID {
+		if n.Status == models.NotificationStatusPending && !n.NextAttemptAt.After(now) {
+			due = append(due, n)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].NextAttemptAt.Before(due[j].NextAttemptAt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	claimed := make([]models.Notification, 0, len(due))
+	for _, n := range due {
+		n.Attempts++
+		n.NextAttemptAt = now.Add(lease)
+		notification := *cloneNotification(n)
+		if m.certs != nil {
+			if cert, err := m.certs.FindByID(n.CertificationID); err == nil {
+				notification.Certification = cert
+			}
+		}
+		claimed = append(claimed, notification)
+	}
+	return claimed, nil
+}
+
+func (m *MockNotificationRepository) SaveDelivery(notification *models.Notification, attempts int) error {
+	if m.UpdateErr != nil {
+		return m.UpdateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.byID[notification.ID]
+	if !ok || stored.Attempts != attempts {
+		return ErrStaleNotification
+	}
+	updated := cloneNotification(notification)
+	updated.Certification = nil
+	m.byID[notification.ID] = updated
+	return nil
+}
+
+func (m *MockNotificationRepository) FindByID(id uuid.UUID) (*models.Notification, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if n, ok := m.byID[id]; ok {
+		notification := cloneNotification(n)
+		notification.Escalations = m.escalationsOf(id)
+		return notification, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *MockNotificationRepository) List(filter NotificationFilter) ([]models.Notification, int64, error) {
+	if m.FindErr != nil {
+		return nil, 0, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []models.Notification
+	for _, n := range m.byID {
+		if filter.Status != "" && n.Status != filter.Status {
+			continue
+		}
+		if filter.CertificationID != nil && n.CertificationID != *filter.CertificationID {
+			continue
+		}
+		notification := *cloneNotification(n)
+		notification.Escalations = m.escalationsOf(n.ID)
+		result = append(result, notification)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ScheduledFor.After(result[j].ScheduledFor) })
+
+	return paginate(result, filter.Pagination), int64(len(result)), nil
+}
+
+// All returns every stored notification, earliest scheduled first.
+func (m *MockNotificationRepository) All() []models.Notification {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := make([]models.Notification, 0, len(m.byID))
+	for _, n := range m.byID {
+		result = append(result, *cloneNotification(n))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ScheduledFor.Before(result[j].ScheduledFor) })
+	return result
+}
+
+func (m *MockNotificationRepository) FindEscalationCandidates(remindedBefore time.Time, maxLevel int) ([]models.Notification, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	first := make(map[uuid.UUID]*models.Notification)
+	for _, n := range m.byID {
+		if f, ok := first[n.CertificationID]; !ok || n.ScheduledFor.Before(f.ScheduledFor) {
+			first[n.CertificationID] = n
+		}
+	}
+
+	var result []models.Notification
+	for _, n := range first {
+		if n.ScheduledFor.After(remindedBefore) || n.EscalationLevel >= maxLevel {
+			continue
+		}
+		notification := *cloneNotification(n)
+		if m.certs != nil {
+			cert, err := m.certs.FindByID(n.CertificationID)
+			if err != nil || cert.Status != models.CertificationStatusActive {
+				continue
+			}
+			notification.Certification = cert
+		}
+		result = append(result, notification)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ScheduledFor.Before(result[j].ScheduledFor) })
+	return result, nil
+}
+
+func (m *MockNotificationRepository) Escalate(escalation *models.NotificationEscalation) (bool, error) {
+	if m.CreateErr != nil {
+		return false, m.CreateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, e := range m.escalations {
+		if e.NotificationID == escalation.NotificationID && e.Level == escalation.Level {
+			return false, nil
+		}
+	}
+	if escalation.ID == uuid.Nil {
+		escalation.ID = uuid.New()
+	}
+	m.escalations[escalation.ID] = cloneEscalation(escalation)
+	if n, ok := m.byID[escalation.NotificationID]; ok && n.EscalationLevel < escalation.Level {
+		n.EscalationLevel = escalation.Level
+	}
+	return true, nil
+}
+
+func (m *MockNotificationRepository) ClaimDueEscalations(now time.Time, lease time.Duration, limit int) ([]models.NotificationEscalation, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var due []*models.NotificationEscalation
+	for _, e := range m.escalations {
+		if e.Status == models.NotificationStatusPending && !e.NextAttemptAt.After(now) {
+			due = append(due, e)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].NextAttemptAt.Before(due[j].NextAttemptAt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	claimed := make([]models.NotificationEscalation, 0, len(due))
+	for _, e := range due {
+		e.Attempts++
+		e.NextAttemptAt = now.Add(lease)
+		escalation := *cloneEscalation(e)
+		if n, ok := m.byID[e.NotificationID]; ok {
+			escalation.Notification = cloneNotification(n)
+			if m.certs != nil {
+				if cert, err := m.certs.FindByID(n.CertificationID); err == nil {
+					escalation.Notification.Certification = cert
+				}
+			}
+		}
+		claimed = append(claimed, escalation)
+	}
+	return claimed, nil
+}
+
+func (m *MockNotificationRepository) SaveEscalationDelivery(escalation *models.NotificationEscalation, attempts int) error {
+	if m.UpdateErr != nil {
+		return m.UpdateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.escalations[escalation.ID]
+	if !ok || stored.Attempts != attempts {
+		return ErrStaleNotification
+	}
+	m.escalations[escalation.ID] = cloneEscalation(escalation)
+	return nil
+}
+
+// escalationsOf returns the notification's escalation steps by level. The
+// caller must hold the lock.
+func (m *MockNotificationRepository) escalationsOf(notificationID uuid.UUID) []models.NotificationEscalation {
+	var result []models.NotificationEscalation
+	for _, e := range m.escalations {
+		if e.NotificationID == notificationID {
+			result = append(result, *cloneEscalation(e))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Level < result[j].Level })
+	return result
+}
+
+func cloneEscalation(e *models.NotificationEscalation) *models.NotificationEscalation {
+	clone := *e
+	clone.Notification = nil
+	clone.Recipients = append(models.StringList(nil), e.Recipients...)
+	clone.DeliveredTo = append(models.StringList(nil), e.DeliveredTo...)
+	return &clone
+}
+
+func cloneNotification(n *models.Notification) *models.Notification {
+	clone := *n
+	clone.Recipients = append(models.StringList(nil), n.Recipients...)
+	clone.DeliveredTo = append(models.StringList(nil), n.DeliveredTo...)
+	clone.Escalations = nil
+	return &clone
+}
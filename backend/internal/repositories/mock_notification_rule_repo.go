@@ -0,0 +1,181 @@
+package repositories
+
+import (
+	"sort"
+	"sync"
+
+	"certitrack/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MockNotificationRuleRepository is an in-memory implementation of
+// NotificationRuleRepository used only in unit tests. Certification types
+// are resolved from the given type repository, as the real repository
+// preloads them.
+type MockNotificationRuleRepository struct {
+	mu    sync.RWMutex
+	byID  map[uuid.UUID]*models.NotificationRule
+	types *MockCertificationTypeRepository
+
+	// Optional hooks to simulate errors
+	CreateErr error
+	UpdateErr error
+	DeleteErr error
+	FindErr   error
+}
+
+// NewMockNotificationRuleRepository creates an empty repository ready for testing.
+func NewMockNotificationRuleRepository(types *MockCertificationTypeRepository) *MockNotificationRuleRepository {
+	return &MockNotificationRuleRepository{
+		byID:  make(map[uuid.UUID]*models.NotificationRule),
+		types: types,
+	}
+}
+
+func (m *MockNotificationRuleRepository) Create(rule *models.NotificationRule) error {
+	if m.CreateErr != nil {
+		return m.CreateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if rule.ID == uuid.Nil {
+		rule.ID = uuid.New()
+	}
+	m.byID[rule.ID] = cloneRule(rule)
+	return nil
+}
+
+func (m *MockNotificationRuleRepository) Update(rule *models.NotificationRule) error {
+	if m.UpdateErr != nil {
+		return m.UpdateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.byID[rule.ID]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	m.byID[rule.ID] = cloneRule(rule)
+	return nil
+}
+
+func (m *MockNotificationRuleRepository) Delete(id uuid.UUID) error {
+	if m.DeleteErr != nil {
+		return m.DeleteErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.byID[id]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	delete(m.byID, id)
+	return nil
+}
+
+func (m *MockNotificationRuleRepository) FindByID(id uuid.UUID) (*models.NotificationRule, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if r, ok := m.byID[id]; ok {
+		return m.withType(r), nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *MockNotificationRuleRepository) List(filter NotificationRuleFilter) ([]models.NotificationRule, int64, error) {
+	if m.FindErr != nil {
+		return nil, 0, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []models.NotificationRule
+	for _, r := range m.byID {
+		if filter.CertificationTypeID != nil && (r.CertificationTypeID == nil || *r.CertificationTypeID != *filter.CertificationTypeID) {
+			continue
+		}
+		if filter.Category != "" && r.Category != filter.Category {
+			continue
+		}
+		if filter.Scope != "" && r.Scope() != filter.Scope {
+			continue
+		}
+		if filter.IsActive != nil && r.IsActive != *filter.IsActive {
+			continue
+		}
+		result = append(result, *m.withType(r))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Scope() != result[j].Scope() {
+			return result[i].Scope() > result[j].Scope()
+		}
+		if result[i].Category != result[j].Category {
+			return result[i].Category > result[j].Category
+		}
+		return result[i].DaysBeforeExpiration > result[j].DaysBeforeExpiration
+	})
+
+	return paginate(result, filter.Pagination), int64(len(result)), nil
+}
+
+func (m *MockNotificationRuleRepository) FindActiveFor(certType *models.CertificationType) ([]models.NotificationRule, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []models.NotificationRule
+	for _, r := range m.byID {
+		if !r.IsActive {
+			continue
+		}
+		if r.CertificationTypeID != nil && *r.CertificationTypeID != certType.ID {
+			continue
+		}
+		if r.CertificationTypeID == nil && r.Category != "" && r.Category != certType.Category {
+			continue
+		}
+		result = append(result, *cloneRule(r))
+	}
+	return result, nil
+}
+
+func (m *MockNotificationRuleRepository) RuleExists(rule *models.NotificationRule) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for id, r := range m.byID {
+		if id == rule.ID || r.Category != rule.Category || r.DaysBeforeExpiration != rule.DaysBeforeExpiration {
+			continue
+		}
+		if (r.CertificationTypeID == nil) != (rule.CertificationTypeID == nil) {
+			continue
+		}
+		if r.CertificationTypeID == nil || *r.CertificationTypeID == *rule.CertificationTypeID {
+			return true
+		}
+	}
+	return false
+}
+
+func cloneRule(r *models.NotificationRule) *models.NotificationRule {
+	rule := *r
+	rule.CertificationType = nil
+	rule.RecipientRoles = append(models.StringList(nil), r.RecipientRoles...)
+	return &rule
+}
+
+// withType must be called with m.mu held.
+func (m *MockNotificationRuleRepository) withType(r *models.NotificationRule) *models.NotificationRule {
+	rule := cloneRule(r)
+	if r.CertificationTypeID != nil {
+		if certType, err := m.types.FindByID(*r.CertificationTypeID); err == nil {
+			rule.CertificationType = certType
+		}
+	}
+	return rule
+}
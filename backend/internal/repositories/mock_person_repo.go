@@ -0,0 +1,158 @@
+package repositories
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"certitrack/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MockPersonRepository is an in-memory implementation of PersonRepository used only in unit tests.
+type MockPersonRepository struct {
+	mu   sync.RWMutex
+	byID map[uuid.UUID]*models.Person
+
+	// Optional hooks to simulate errors
+	CreateErr error
+	UpdateErr error
+	FindErr   error
+}
+
+// NewMockPersonRepository creates an empty repository ready for testing.
+func NewMockPersonRepository() *MockPersonRepository {
+	return &MockPersonRepository{
+		byID: make(map[uuid.UUID]*models.Person),
+	}
+}
+
+func (m *MockPersonRepository) Create(person *models.Person) error {
+	if m.CreateErr != nil {
+		return m.CreateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if person.ID == uuid.Nil {
+		person.ID = uuid.New()
+	}
+	m.byID[person.ID] = person
+	return nil
+}
+
+func (m *MockPersonRepository) Update(person *models.Person) error {
+	if m.UpdateErr != nil {
+		return m.UpdateErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.byID[person.ID] = person
+	return nil
+}
+
+func (m *MockPersonRepository) FindByID(id uuid.UUID) (*models.Person, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if p, ok := m.byID[id]; ok {
+		person := *p
+		return &person, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *MockPersonRepository) List(filter PersonFilter) ([]models.Person, int64, error) {
+	if m.FindErr != nil {
+		return nil, 0, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	search := strings.ToLower(filter.Search)
+	var result []models.Person
+	for _, p := range m.byID {
+		if filter.Department != "" && p.Department != filter.Department {
+			continue
+		}
+		if filter.IsActive != nil && p.IsActive != *filter.IsActive {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(p.FullName()+" "+p.Email), search) {
+			continue
+		}
+		result = append(result, *p)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].LastName < result[j].LastName })
+
+	return paginate(result, filter.Pagination), int64(len(result)), nil
+}
+
+func (m *MockPersonRepository) EmployeeIDExists(employeeID string, excludeID uuid.UUID) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for id, p := range m.byID {
+		if id != excludeID && p.EmployeeID != nil && *p.EmployeeID == employeeID {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MockPersonRepository) FindActiveInScopes(positions, departments []string) ([]models.Person, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var result []models.Person
+	for _, p := range m.byID {
+		if !p.IsActive {
+			continue
+		}
+		if slices.Contains(positions, strings.ToLower(strings.TrimSpace(p.Position))) ||
+			slices.Contains(departments, strings.ToLower(strings.TrimSpace(p.Department))) {
+			result = append(result, *p)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].LastName < result[j].LastName })
+	return result, nil
+}
+
+func (m *MockPersonRepository) FindActiveDepartmentHeads(department string) ([]models.Person, error) {
+	if m.FindErr != nil {
+		return nil, m.FindErr
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	department = strings.ToLower(strings.TrimSpace(department))
+	var result []models.Person
+	for _, p := range m.byID {
+		if p.IsActive && p.IsDepartmentHead && department != "" &&
+			strings.ToLower(strings.TrimSpace(p.Department)) == department {
+			result = append(result, *p)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].LastName < result[j].LastName })
+	return result, nil
+}
+
+func paginate[T any](items []T, p Pagination) []T {
+	p = p.Normalize()
+	start := p.Offset()
+	if start >= len(items) {
+		return []T{}
+	}
+	end := start + p.Limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
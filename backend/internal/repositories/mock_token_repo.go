@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"sync"
+	"time"
+)
+
+// MockTokenRepository is an in-memory implementation of TokenRepository used
+// only in unit tests. Expiry is not simulated.
+type MockTokenRepository struct {
+	mu       sync.Mutex
+	tokens   map[string]bool
+	sessions map[string]bool
+
+	// Optional hooks to simulate errors
+	RevokeErr error
+	CheckErr  error
+}
+
+// NewMockTokenRepository creates an empty repository ready for testing.
+func NewMockTokenRepository() *MockTokenRepository {
+	return &MockTokenRepository{
+		tokens:   make(map[string]bool),
+		sessions: make(map[string]bool),
+	}
+}
+
+func (m *MockTokenRepository) RevokeToken(token string, expiresIn time.Duration) error {
+	if m.RevokeErr != nil {
+		return m.RevokeErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens[token] = true
+	return nil
+}
+
+func (m *MockTokenRepository) IsTokenRevoked(token string) (bool, error) {
+	if m.CheckErr != nil {
+		return false, m.CheckErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.tokens[token], nil
+}
+
+func (m *MockTokenRepository) RevokeSession(sessionID string, expiresIn time.Duration) error {
+	if m.RevokeErr != nil {
+		return m.RevokeErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[sessionID] = true
+	return nil
+}
+
+func (m *MockTokenRepository) IsSessionRevoked(sessionID string) (bool, error) {
+	if m.CheckErr != nil {
+		return false, m.CheckErr
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sessions[sessionID], nil
+}
@@ -2,11 +2,13 @@ package repositories
 
 import (
     "errors"
+    "sort"
     "sync"
     "time"
 
     "certitrack/internal/models"
     "github.com/google/uuid"
+    "gorm.io/gorm"
 )
 
 // MockUserRepository is an in-memory implementation of UserRepository used only in unit tests.
@@ -94,3 +96,47 @@ func (m *MockUserRepository) UpdateLastLogin(id string, t time.Time) error {
     }
     return errors.New("user not found")
 }
+
+func (m *MockUserRepository) FindActiveAdmins() ([]models.User, error) {
+    if m.FindErr != nil {
+        return nil, m.FindErr
+    }
+    m.mu.RLock()
+    defer m.mu.RUnlock()
+    var result []models.User
+    for _, u := range m.byID {
+        if u.IsAdmin() && u.IsActive {
+            result = append(result, *u)
+        }
+    }
+    sort.Slice(result, func(i, j int) bool { return result[i].Email < result[j].Email })
+    return result, nil
+}
+
+func (m *MockUserRepository) FindDeliveryPreferences() (map[string]string, error) {
+    if m.FindErr != nil {
+        return nil, m.FindErr
+    }
+    m.mu.RLock()
+    defer m.mu.RUnlock()
+    preferences := make(map[string]string)
+    for _, u := range m.byID {
+        if u.IsActive && u.NotificationDelivery != "" {
+            preferences[u.Email] = u.NotificationDelivery
+        }
+    }
+    return preferences, nil
+}
+
+func (m *MockUserRepository) UpdateNotificationDelivery(id string, delivery string) error {
+    if m.UpdateErr != nil {
+        return m.UpdateErr
+    }
+    m.mu.Lock()
+    defer m.mu.Unlock()
+    if u, ok := m.byID[id]; ok {
+        u.NotificationDelivery = delivery
+        return nil
+    }
+    return gorm.ErrRecordNotFound
+}
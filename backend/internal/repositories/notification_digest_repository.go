@@ -0,0 +1,189 @@
+package repositories
+
+import (
+	"time"
+
+	"certitrack/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotificationDigestFilter narrows a digest query. Empty fields are not
+// filtered on.
+type NotificationDigestFilter struct {
+	Pagination
+	Status    string
+	Recipient string
+}
+
+// DigestItem is a reminder waiting to go out in a recipient's digest.
+type DigestItem struct {
+	NotificationID uuid.UUID
+	Recipient      string
+}
+
+type NotificationDigestRepository interface {
+	FindPendingItems() ([]DigestItem, error)
+	CreateDigest(digest *models.NotificationDigest, notificationIDs []uuid.UUID) (bool, error)
+	ClaimDue(now time.Time, lease time.Duration, limit int) ([]models.NotificationDigest, error)
+	SaveDelivery(digest *models.NotificationDigest, attempts int) error
+	FindByID(id uuid.UUID) (*models.NotificationDigest, error)
+	List(filter NotificationDigestFilter) ([]models.NotificationDigest, int64, error)
+}
+
+type NotificationDigestRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewNotificationDigestRepositoryImpl(db *gorm.DB) NotificationDigestRepository {
+	return &NotificationDigestRepositoryImpl{db: db}
+}
+
+// FindPendingItems returns, per digest recipient, the reminders for active
+// certifications that are not in one of that recipient's digests yet,
+// ordered by recipient and then by when the reminder was scheduled.
+func (r *NotificationDigestRepositoryImpl) FindPendingItems() ([]DigestItem, error) {
+	var items []DigestItem
+	err := r.db.Raw(`
+		SELECT n.id AS notification_id, r.recipient
+		FROM notifications n
+		CROSS JOIN LATERAL jsonb_array_elements_text(n.digest_recipients) AS r(recipient)
+		JOIN certifications c ON c.id = n.certification_id
+		WHERE c.status = ?
+		AND NOT EXISTS (
+			SELECT 1
+			FROM notification_digest_items i
+			JOIN notification_digests d ON d.id = i.notification_digest_id
+			WHERE i.notification_id = n.id AND d.recipient = r.recipient
+		)
+		ORDER BY r.recipient ASC, n.scheduled_for ASC`,
+		models.CertificationStatusActive,
+	).Scan(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// CreateDigest inserts the digest with the given reminders as its items,
+// unless the recipient already has a digest for the period, and reports
+// whether it was inserted.
+func (r *NotificationDigestRepositoryImpl) CreateDigest(digest *models.NotificationDigest, notificationIDs []uuid.UUID) (bool, error) {
+	inserted := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Omit("Items").Clauses(clause.OnConflict{DoNothing: true}).Create(digest)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		items := make([]models.NotificationDigestItem, len(notificationIDs))
+		for i, id := range notificationIDs {
+			items[i] = models.NotificationDigestItem{NotificationDigestID: digest.ID, NotificationID: id}
+		}
+		if err := tx.Omit("Notification").Create(&items).Error; err != nil {
+			return err
+		}
+		digest.Items = items
+		inserted = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return inserted, nil
+}
+
+// ClaimDue takes up to limit pending digests whose next attempt is due, the
+// same way NotificationRepository.ClaimDue does. Each item's reminder is
+// loaded with its certification, the certification's type and its holder.
+func (r *NotificationDigestRepositoryImpl) ClaimDue(now time.Time, lease time.Duration, limit int) ([]models.NotificationDigest, error) {
+	var ids []uuid.UUID
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.NotificationDigest{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.NotificationStatusPending, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		return tx.Model(&models.NotificationDigest{}).
+			Where("id IN ?", ids).
+			Updates(map[string]any{
+				"attempts":        gorm.Expr("attempts + 1"),
+				"next_attempt_at": now.Add(lease),
+			}).Error
+	})
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	var claimed []models.NotificationDigest
+	err = r.db.
+		Preload("Items.Notification.Certification.CertificationType").
+		Preload("Items.Notification.Certification.Person").
+		Preload("Items.Notification.Certification.Equipment").
+		Where("id IN ?", ids).
+		Order("period_start ASC, recipient ASC").
+		Find(&claimed).Error
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+// SaveDelivery stores the outcome of a delivery attempt, or a requeue, as
+// long as the digest still has the given number of attempts. It returns
+// ErrStaleNotification otherwise.
+func (r *NotificationDigestRepositoryImpl) SaveDelivery(digest *models.NotificationDigest, attempts int) error {
+	result := r.db.Model(digest).
+		Where("attempts = ?", attempts).
+		Select("status", "attempts", "next_attempt_at", "sent_at", "error_message", "updated_at").
+		Updates(digest)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrStaleNotification
+	}
+	return nil
+}
+
+func (r *NotificationDigestRepositoryImpl) FindByID(id uuid.UUID) (*models.NotificationDigest, error) {
+	var digest models.NotificationDigest
+	if err := r.db.Preload("Items").Where("id = ?", id).First(&digest).Error; err != nil {
+		return nil, err
+	}
+	return &digest, nil
+}
+
+// List returns matching digests, latest period first, and the total count.
+func (r *NotificationDigestRepositoryImpl) List(filter NotificationDigestFilter) ([]models.NotificationDigest, int64, error) {
+	query := r.db.Model(&models.NotificationDigest{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Recipient != "" {
+		query = query.Where("recipient = ?", filter.Recipient)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	page := filter.Pagination.Normalize()
+	var digests []models.NotificationDigest
+	err := query.
+		Preload("Items").
+		Order("period_start DESC, recipient ASC").
+		Offset(page.Offset()).
+		Limit(page.Limit).
+		Find(&digests).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return digests, total, nil
+}
@@ -0,0 +1,259 @@
+package repositories
+
+import (
+	"errors"
+	"time"
+
+	"certitrack/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotificationFilter narrows a notification query. Empty fields are not
+// filtered on.
+type NotificationFilter struct {
+	Pagination
+	Status          string
+	CertificationID *uuid.UUID
+}
+
+// ErrStaleNotification is returned by SaveDelivery when the notification was
+// claimed or changed by someone else in the meantime.
+var ErrStaleNotification = errors.New("notification changed concurrently")
+
+type NotificationRepository interface {
+	Schedule(notification *models.Notification) (bool, error)
+	ClaimDue(now time.Time, lease time.Duration, limit int) ([]models.Notification, error)
+	SaveDelivery(notification *models.Notification, attempts int) error
+	FindByID(id uuid.UUID) (*models.Notification, error)
+	List(filter NotificationFilter) ([]models.Notification, int64, error)
+	FindEscalationCandidates(remindedBefore time.Time, maxLevel int) ([]models.Notification, error)
+	Escalate(escalation *models.NotificationEscalation) (bool, error)
+	ClaimDueEscalations(now time.Time, lease time.Duration, limit int) ([]models.NotificationEscalation, error)
+	SaveEscalationDelivery(escalation *models.NotificationEscalation, attempts int) error
+}
+
+type NotificationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepositoryImpl(db *gorm.DB) NotificationRepository {
+	return &NotificationRepositoryImpl{db: db}
+}
+
+// Schedule inserts the notification unless one already exists for the same
+// certification, rule and threshold, and reports whether it was inserted.
+// The unique index decides, so concurrent checkers cannot both insert it.
+func (r *NotificationRepositoryImpl) Schedule(notification *models.Notification) (bool, error) {
+	result := r.db.
+		Omit("Certification", "NotificationRule", "Escalations").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(notification)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ClaimDue takes up to limit pending notifications whose next attempt is due,
+// counts the attempt and pushes their next attempt back by lease, so no
+// other worker picks them up while they are being sent. A worker that dies
+// mid-send leaves them to be retried once the lease runs out. The
+// certification, its type and its holder are loaded.
+func (r *NotificationRepositoryImpl) ClaimDue(now time.Time, lease time.Duration, limit int) ([]models.Notification, error) {
+	var ids []uuid.UUID
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Notification{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.NotificationStatusPending, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		return tx.Model(&models.Notification{}).
+			Where("id IN ?", ids).
+			Updates(map[string]any{
+				"attempts":        gorm.Expr("attempts + 1"),
+				"next_attempt_at": now.Add(lease),
+			}).Error
+	})
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	var claimed []models.Notification
+	err = r.db.
+		Preload("Certification.CertificationType").
+		Preload("Certification.Person").
+		Preload("Certification.Equipment").
+		Where("id IN ?", ids).
+		Order("scheduled_for ASC").
+		Find(&claimed).Error
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+// SaveDelivery stores the outcome of a delivery attempt, or a requeue, as
+// long as the notification still has the given number of attempts. It
+// returns ErrStaleNotification otherwise.
+func (r *NotificationRepositoryImpl) SaveDelivery(notification *models.Notification, attempts int) error {
+	result := r.db.Model(notification).
+		Where("attempts = ?", attempts).
+		Select("status", "delivered_to", "attempts", "next_attempt_at", "sent_at", "error_message", "updated_at").
+		Updates(notification)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrStaleNotification
+	}
+	return nil
+}
+
+func (r *NotificationRepositoryImpl) FindByID(id uuid.UUID) (*models.Notification, error) {
+	var notification models.Notification
+	if err := r.db.Preload("Escalations").Where("id = ?", id).First(&notification).Error; err != nil {
+		return nil, err
+	}
+	return &notification, nil
+}
+
+// List returns matching notifications, most recently scheduled first, and
+// the total count.
+func (r *NotificationRepositoryImpl) List(filter NotificationFilter) ([]models.Notification, int64, error) {
+	query := r.db.Model(&models.Notification{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.CertificationID != nil {
+		query = query.Where("certification_id = ?", *filter.CertificationID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	page := filter.Pagination.Normalize()
+	var notifications []models.Notification
+	err := query.
+		Preload("Escalations", func(db *gorm.DB) *gorm.DB { return db.Order("level ASC") }).
+		Order("scheduled_for DESC, created_at DESC").
+		Offset(page.Offset()).
+		Limit(page.Limit).
+		Find(&notifications).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+// FindEscalationCandidates returns the first reminder of every active
+// certification, as long as it was scheduled on or before remindedBefore and
+// its escalation has not reached maxLevel. The certification, its type and
+// its holder are loaded. Renewing a certification supersedes it, so these
+// are the certifications nobody has renewed since they were reminded.
+func (r *NotificationRepositoryImpl) FindEscalationCandidates(remindedBefore time.Time, maxLevel int) ([]models.Notification, error) {
+	first := r.db.Table("notifications AS n").
+		Select("DISTINCT ON (n.certification_id) n.id").
+		Joins("JOIN certifications c ON c.id = n.certification_id").
+		Where("c.status = ?", models.CertificationStatusActive).
+		Order("n.certification_id, n.scheduled_for ASC, n.created_at ASC")
+
+	var notifications []models.Notification
+	err := r.db.
+		Preload("Certification.CertificationType").
+		Preload("Certification.Person").
+		Preload("Certification.Equipment").
+		Where("id IN (?)", first).
+		Where("scheduled_for <= ? AND escalation_level < ?", remindedBefore, maxLevel).
+		Order("scheduled_for ASC").
+		Find(&notifications).Error
+	if err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// Escalate records the escalation step on its notification unless the step
+// was taken already, and reports whether it was recorded.
+func (r *NotificationRepositoryImpl) Escalate(escalation *models.NotificationEscalation) (bool, error) {
+	inserted := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Omit("Notification").Clauses(clause.OnConflict{DoNothing: true}).Create(escalation)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		inserted = true
+		return tx.Model(&models.Notification{}).
+			Where("id = ? AND escalation_level < ?", escalation.NotificationID, escalation.Level).
+			Update("escalation_level", escalation.Level).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return inserted, nil
+}
+
+// ClaimDueEscalations takes up to limit pending escalation steps the same
+// way ClaimDue takes notifications. Each step's notification is loaded with
+// its certification, the certification's type and its holder.
+func (r *NotificationRepositoryImpl) ClaimDueEscalations(now time.Time, lease time.Duration, limit int) ([]models.NotificationEscalation, error) {
+	var ids []uuid.UUID
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.NotificationEscalation{}).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.NotificationStatusPending, now).
+			Order("next_attempt_at ASC").
+			Limit(limit).
+			Pluck("id", &ids).Error
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		return tx.Model(&models.NotificationEscalation{}).
+			Where("id IN ?", ids).
+			Updates(map[string]any{
+				"attempts":        gorm.Expr("attempts + 1"),
+				"next_attempt_at": now.Add(lease),
+			}).Error
+	})
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	var claimed []models.NotificationEscalation
+	err = r.db.
+		Preload("Notification.Certification.CertificationType").
+		Preload("Notification.Certification.Person").
+		Preload("Notification.Certification.Equipment").
+		Where("id IN ?", ids).
+		Order("created_at ASC").
+		Find(&claimed).Error
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+// SaveEscalationDelivery stores the outcome of a delivery attempt, or a
+// requeue, of an escalation step the way SaveDelivery does for
+// notifications.
+func (r *NotificationRepositoryImpl) SaveEscalationDelivery(escalation *models.NotificationEscalation, attempts int) error {
+	result := r.db.Model(escalation).
+		Where("attempts = ?", attempts).
+		Select("status", "delivered_to", "attempts", "next_attempt_at", "sent_at", "error_message", "updated_at").
+		Updates(escalation)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrStaleNotification
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"certitrack/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type NotificationRuleFilter struct {
+	Pagination
+	CertificationTypeID *uuid.UUID
+	Category            string
+	Scope               string
+	IsActive            *bool
+}
+
+type NotificationRuleRepository interface {
+	Create(rule *models.NotificationRule) error
+	Update(rule *models.NotificationRule) error
+	Delete(id uuid.UUID) error
+	FindByID(id uuid.UUID) (*models.NotificationRule, error)
+	List(filter NotificationRuleFilter) ([]models.NotificationRule, int64, error)
+	FindActiveFor(certType *models.CertificationType) ([]models.NotificationRule, error)
+	RuleExists(rule *models.NotificationRule) bool
+}
+
+type NotificationRuleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewNotificationRuleRepositoryImpl(db *gorm.DB) NotificationRuleRepository {
+	return &NotificationRuleRepositoryImpl{db: db}
+}
+
+// Create inserts every column, so an inactive rule is not stored with the
+// column default.
+func (r *NotificationRuleRepositoryImpl) Create(rule *models.NotificationRule) error {
+	return r.db.Select("*").Omit("CertificationType").Create(rule).Error
+}
+
+func (r *NotificationRuleRepositoryImpl) Update(rule *models.NotificationRule) error {
+	return r.db.Omit("CertificationType").Save(rule).Error
+}
+
+func (r *NotificationRuleRepositoryImpl) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.NotificationRule{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *NotificationRuleRepositoryImpl) FindByID(id uuid.UUID) (*models.NotificationRule, error) {
+	var rule models.NotificationRule
+	err := r.db.
+		Preload("CertificationType").
+		Where("id = ?", id).
+		First(&rule).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *NotificationRuleRepositoryImpl) List(filter NotificationRuleFilter) ([]models.NotificationRule, int64, error) {
+	query := r.db.Model(&models.NotificationRule{})
+
+	if filter.CertificationTypeID != nil {
+		query = query.Where("certification_type_id = ?", *filter.CertificationTypeID)
+	}
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+	switch filter.Scope {
+	case models.NotificationScopeType:
+		query = query.Where("certification_type_id IS NOT NULL")
+	case models.NotificationScopeCategory:
+		query = query.Where("certification_type_id IS NULL AND category <> ''")
+	case models.NotificationScopeGlobal:
+		query = query.Where("certification_type_id IS NULL AND category = ''")
+	}
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	page := filter.Pagination.Normalize()
+	var rules []models.NotificationRule
+	err := query.
+		Preload("CertificationType").
+		Order("certification_type_id NULLS LAST, category DESC, days_before_expiration DESC").
+		Limit(page.Limit).
+		Offset(page.Offset()).
+		Find(&rules).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return rules, total, nil
+}
+
+// FindActiveFor returns the active rules at every level that could apply to
+// the type; models.EffectiveNotificationRules picks among them.
+func (r *NotificationRuleRepositoryImpl) FindActiveFor(certType *models.CertificationType) ([]models.NotificationRule, error) {
+	var rules []models.NotificationRule
+	err := r.db.
+		Where("is_active = ?", true).
+		Where("certification_type_id = ? OR (certification_type_id IS NULL AND category IN ?)", certType.ID, []string{certType.Category, ""}).
+		Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// RuleExists reports whether another rule has the same scope and number of
+// days. NULL type IDs never compare equal in a unique index, so this is
+// checked here instead.
+func (r *NotificationRuleRepositoryImpl) RuleExists(rule *models.NotificationRule) bool {
+	query := r.db.Model(&models.NotificationRule{}).
+		Where("category = ? AND days_before_expiration = ?", rule.Category, rule.DaysBeforeExpiration)
+	if rule.CertificationTypeID != nil {
+		query = query.Where("certification_type_id = ?", *rule.CertificationTypeID)
+	} else {
+		query = query.Where("certification_type_id IS NULL")
+	}
+	if rule.ID != uuid.Nil {
+		query = query.Where("id <> ?", rule.ID)
+	}
+
+	var count int64
+	query.Count(&count)
+	return count > 0
+}
@@ -0,0 +1,31 @@
+package repositories
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// Pagination describes a page of results requested by the caller.
+type Pagination struct {
+	Page  int
+	Limit int
+}
+
+// Normalize clamps the page and limit to sane values.
+func (p Pagination) Normalize() Pagination {
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	if p.Limit < 1 {
+		p.Limit = DefaultPageSize
+	}
+	if p.Limit > MaxPageSize {
+		p.Limit = MaxPageSize
+	}
+	return p
+}
+
+func (p Pagination) Offset() int {
+	n := p.Normalize()
+	return (n.Page - 1) * n.Limit
+}
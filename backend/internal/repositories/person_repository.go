@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"strings"
+
+	"certitrack/internal/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PersonFilter struct {
+	Pagination
+	Search     string
+	Department string
+	IsActive   *bool
+}
+
+type PersonRepository interface {
+	Create(person *models.Person) error
+	Update(person *models.Person) error
+	FindByID(id uuid.UUID) (*models.Person, error)
+	List(filter PersonFilter) ([]models.Person, int64, error)
+	EmployeeIDExists(employeeID string, excludeID uuid.UUID) bool
+	FindActiveInScopes(positions, departments []string) ([]models.Person, error)
+	FindActiveDepartmentHeads(department string) ([]models.Person, error)
+}
+
+type PersonRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPersonRepositoryImpl(db *gorm.DB) PersonRepository {
+	return &PersonRepositoryImpl{db: db}
+}
+
+func (r *PersonRepositoryImpl) Create(person *models.Person) error {
+	return r.db.Create(person).Error
+}
+
+func (r *PersonRepositoryImpl) Update(person *models.Person) error {
+	return r.db.Save(person).Error
+}
+
+func (r *PersonRepositoryImpl) FindByID(id uuid.UUID) (*models.Person, error) {
+	var person models.Person
+	if err := r.db.Where("id = ?", id).First(&person).Error; err != nil {
+		return nil, err
+	}
+	return &person, nil
+}
+
+func (r *PersonRepositoryImpl) List(filter PersonFilter) ([]models.Person, int64, error) {
+	query := r.db.Model(&models.Person{})
+
+	if filter.Search != "" {
+		like := "%" + filter.Search + "%"
+		query = query.Where(
+			"first_name ILIKE ? OR last_name ILIKE ? OR email ILIKE ? OR employee_id ILIKE ?",
+			like, like, like, like,
+		)
+	}
+	if filter.Department != "" {
+		query = query.Where("department = ?", filter.Department)
+	}
+	if filter.IsActive != nil {
+		query = query.Where("is_active = ?", *filter.IsActive)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	page := filter.Pagination.Normalize()
+	var people []models.Person
+	err := query.
+		Order("last_name ASC, first_name ASC").
+		Limit(page.Limit).
+		Offset(page.Offset()).
+		Find(&people).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return people, total, nil
+}
+
+func (r *PersonRepositoryImpl) EmployeeIDExists(employeeID string, excludeID uuid.UUID) bool {
+	var count int64
+	query := r.db.Model(&models.Person{}).Where("employee_id = ?", employeeID)
+	if excludeID != uuid.Nil {
+		query = query.Where("id <> ?", excludeID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return false
+	}
+	return count > 0
+}
+
+// FindActiveInScopes returns active people whose position or department is in
+// the given lists. Values are compared case-insensitively and must already be
+// lower case.
+func (r *PersonRepositoryImpl) FindActiveInScopes(positions, departments []string) ([]models.Person, error) {
+	if len(positions) == 0 && len(departments) == 0 {
+		return nil, nil
+	}
+
+	scope := r.db.Where("1 = 0")
+	if len(positions) > 0 {
+		scope = scope.Or("LOWER(TRIM(position)) IN ?", positions)
+	}
+	if len(departments) > 0 {
+		scope = scope.Or("LOWER(TRIM(department)) IN ?", departments)
+	}
+
+	var people []models.Person
+	err := r.db.
+		Where("is_active = ?", true).
+		Where(scope).
+		Order("last_name ASC, first_name ASC").
+		Find(&people).Error
+	if err != nil {
+		return nil, err
+	}
+	return people, nil
+}
+
+// FindActiveDepartmentHeads returns the active heads of the department,
+// compared case-insensitively.
+func (r *PersonRepositoryImpl) FindActiveDepartmentHeads(department string) ([]models.Person, error) {
+	department = strings.ToLower(strings.TrimSpace(department))
+	if department == "" {
+		return nil, nil
+	}
+
+	var people []models.Person
+	err := r.db.
+		Where("is_active = ? AND is_department_head = ?", true, true).
+		Where("LOWER(TRIM(department)) = ?", department).
+		Order("last_name ASC, first_name ASC").
+		Find(&people).Error
+	if err != nil {
+		return nil, err
+	}
+	return people, nil
+}
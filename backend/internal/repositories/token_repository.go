@@ -9,6 +9,11 @@ import (
 type TokenRepository interface {
 	RevokeToken(token string, expiresIn time.Duration) error
 	IsTokenRevoked(token string) (bool, error)
+	// RevokeSession and IsSessionRevoked track revoked access tokens by
+	// their session ID, for credentials that carry the ID instead of the
+	// token, like signed document links.
+	RevokeSession(sessionID string, expiresIn time.Duration) error
+	IsSessionRevoked(sessionID string) (bool, error)
 }
 
 type tokenRepository struct {
@@ -29,3 +34,14 @@ func (r *tokenRepository) IsTokenRevoked(token string) (bool, error) {
 	result, err := r.client.Exists(context.Background(), key).Result()
 	return result > 0, err
 }
+
+func (r *tokenRepository) RevokeSession(sessionID string, expiresIn time.Duration) error {
+	key := "revoked:session:" + sessionID
+	return r.client.Set(context.Background(), key, "1", expiresIn).Err()
+}
+
+func (r *tokenRepository) IsSessionRevoked(sessionID string) (bool, error) {
+	key := "revoked:session:" + sessionID
+	result, err := r.client.Exists(context.Background(), key).Result()
+	return result > 0, err
+}
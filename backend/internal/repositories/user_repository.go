@@ -20,6 +20,9 @@ type UserRepository interface {
 	UpdateLastLogin(id string, t time.Time) error
 	FindActiveByEmail(email string) (*models.User, error)
 	FindActiveByID(id string) (*models.User, error)
+	FindActiveAdmins() ([]models.User, error)
+	FindDeliveryPreferences() (map[string]string, error)
+	UpdateNotificationDelivery(id string, delivery string) error
 }
 
 type UserRepositoryImpl struct {
@@ -67,3 +70,40 @@ func (r *UserRepositoryImpl) FindActiveByID(id string) (*models.User, error) {
 	}
 	return &user, nil
 }
+
+// FindActiveAdmins returns every active admin user, ordered by email.
+func (r *UserRepositoryImpl) FindActiveAdmins() ([]models.User, error) {
+	var users []models.User
+	if err := r.db.Where("role = ? AND is_active = ?", "admin", true).Order("email ASC").Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// FindDeliveryPreferences returns the notification delivery of every active
+// user who chose one, keyed by email.
+func (r *UserRepositoryImpl) FindDeliveryPreferences() (map[string]string, error) {
+	var users []models.User
+	err := r.db.Select("email", "notification_delivery").
+		Where("is_active = ? AND notification_delivery <> ''", true).
+		Find(&users).Error
+	if err != nil {
+		return nil, err
+	}
+	preferences := make(map[string]string, len(users))
+	for _, user := range users {
+		preferences[user.Email] = user.NotificationDelivery
+	}
+	return preferences, nil
+}
+
+func (r *UserRepositoryImpl) UpdateNotificationDelivery(id string, delivery string) error {
+	result := r.db.Model(&models.User{}).Where("id = ?", id).Update("notification_delivery", delivery)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
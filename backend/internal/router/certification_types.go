@@ -0,0 +1,22 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+func setupCertificationTypeRoutes(rg *gin.RouterGroup, deps *RouterDeps) {
+	certTypes := rg.Group("/certification-types")
+	{
+		certTypes.GET("", deps.CertificationTypeHandler.List)
+		certTypes.GET("/:id", deps.CertificationTypeHandler.Get)
+	}
+}
+
+func setupAdminCertificationTypeRoutes(rg *gin.RouterGroup, deps *RouterDeps) {
+	certTypes := rg.Group("/certification-types")
+	{
+		certTypes.POST("", deps.CertificationTypeHandler.Create)
+		certTypes.PUT("/:id", deps.CertificationTypeHandler.Update)
+		certTypes.DELETE("/:id", deps.CertificationTypeHandler.Delete)
+	}
+}
@@ -1,34 +1,38 @@
 package router
 
 import (
-	"certitrack/internal/config"
-	"net/http"
-
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
-func setupCertificationRoutes(rg *gin.RouterGroup, db *gorm.DB, cfg *config.Config) {
+func setupCertificationRoutes(rg *gin.RouterGroup, deps *RouterDeps) {
 	certifications := rg.Group("/certifications")
 	{
-		certifications.GET("", func(ctx *gin.Context) {
-			ctx.JSON(http.StatusOK, gin.H{"message": "Certifications"})
-		})
-		certifications.POST("", func(ctx *gin.Context) {
-			ctx.JSON(http.StatusOK, gin.H{"message": "Create Certification"})
-		})
+		certifications.GET("", deps.CertificationHandler.List)
+		certifications.POST("", deps.CertificationHandler.Create)
 
 		certRoutes := certifications.Group("/:id")
 		{
-			certRoutes.GET("", func(ctx *gin.Context) {
-				ctx.JSON(http.StatusOK, gin.H{"message": "Get Certification"})
-			})
-			certRoutes.PUT("", func(ctx *gin.Context) {
-				ctx.JSON(http.StatusOK, gin.H{"message": "Update Certification"})
-			})
-			certRoutes.DELETE("", func(ctx *gin.Context) {
-				ctx.JSON(http.StatusOK, gin.H{"message": "Delete Certification"})
-			})
+			certRoutes.GET("", deps.CertificationHandler.Get)
+			certRoutes.PUT("", deps.CertificationHandler.Update)
+			certRoutes.DELETE("", deps.Middleware.AdminMiddleware(), deps.CertificationHandler.Delete)
+			certRoutes.POST("/revoke", deps.CertificationHandler.Revoke)
+			certRoutes.POST("/activate", deps.CertificationHandler.Activate)
+			certRoutes.GET("/status-history", deps.CertificationHandler.StatusHistory)
+			certRoutes.POST("/renew", deps.CertificationHandler.Renew)
+			certRoutes.GET("/history", deps.CertificationHandler.RenewalHistory)
+			certRoutes.GET("/notification-rules", deps.NotificationRuleHandler.ForCertification)
+
+			documents := certRoutes.Group("/documents")
+			{
+				documents.GET("", deps.DocumentHandler.List)
+				documents.POST("", deps.DocumentHandler.Upload)
+				documents.GET("/:docId", deps.DocumentHandler.Download)
+				documents.DELETE("/:docId", deps.Middleware.AdminMiddleware(), deps.DocumentHandler.Delete)
+				documents.GET("/:docId/versions", deps.DocumentHandler.Versions)
+				documents.POST("/:docId/versions", deps.DocumentHandler.Replace)
+				documents.GET("/:docId/versions/:version", deps.DocumentHandler.DownloadVersion)
+				documents.DELETE("/:docId/versions/:version", deps.Middleware.AdminMiddleware(), deps.DocumentHandler.DeleteVersion)
+			}
 		}
 	}
 }
@@ -0,0 +1,18 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+func setupComplianceRoutes(rg *gin.RouterGroup, deps *RouterDeps) {
+	rg.GET("/certification-requirements", deps.CertificationRequirementHandler.List)
+	rg.GET("/compliance/gaps", deps.ComplianceHandler.Gaps)
+}
+
+func setupAdminComplianceRoutes(rg *gin.RouterGroup, deps *RouterDeps) {
+	requirements := rg.Group("/certification-requirements")
+	{
+		requirements.POST("", deps.CertificationRequirementHandler.Create)
+		requirements.DELETE("/:id", deps.CertificationRequirementHandler.Delete)
+	}
+}
@@ -0,0 +1,25 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// setupDocumentRoutes registers routes addressed by document ID alone. Routes
+// that need the owning certification live under /certifications/:id/documents.
+func setupDocumentRoutes(rg *gin.RouterGroup, deps *RouterDeps) {
+	documents := rg.Group("/documents")
+	{
+		documents.GET("/:id/thumbnail", deps.DocumentHandler.Thumbnail)
+		documents.POST("/:id/link", deps.DocumentLinkHandler.Create)
+		documents.GET("/:id/access-log", deps.Middleware.AdminMiddleware(), deps.DocumentAccessHandler.ListForDocument)
+	}
+}
+
+// setupPublicDocumentRoutes registers routes authorized by a signed link
+// instead of a bearer token.
+func setupPublicDocumentRoutes(rg *gin.RouterGroup, deps *RouterDeps) {
+	documents := rg.Group("/documents")
+	{
+		documents.GET("/:id/content", deps.DocumentLinkHandler.Content)
+	}
+}
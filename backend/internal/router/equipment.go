@@ -1,34 +1,22 @@
 package router
 
 import (
-	"certitrack/internal/config"
-	"net/http"
-
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
-func setupEquipmentRoutes(rg *gin.RouterGroup, db *gorm.DB, cfg *config.Config) {
+func setupEquipmentRoutes(rg *gin.RouterGroup, deps *RouterDeps) {
 	equipment := rg.Group("/equipment")
 	{
-		equipment.GET("", func(ctx *gin.Context) {
-			ctx.JSON(http.StatusOK, gin.H{"message": "Equipment"})
-		})
-		equipment.POST("", func(ctx *gin.Context) {
-			ctx.JSON(http.StatusOK, gin.H{"message": "Create Equipment"})
-		})
+		equipment.GET("", deps.EquipmentHandler.List)
+		equipment.POST("", deps.EquipmentHandler.Create)
 
 		equipmentRoutes := equipment.Group("/:id")
 		{
-			equipmentRoutes.GET("", func(ctx *gin.Context) {
-				ctx.JSON(http.StatusOK, gin.H{"message": "Get Equipment"})
-			})
-			equipmentRoutes.PUT("", func(ctx *gin.Context) {
-				ctx.JSON(http.StatusOK, gin.H{"message": "Update Equipment"})
-			})
-			equipmentRoutes.DELETE("", func(ctx *gin.Context) {
-				ctx.JSON(http.StatusOK, gin.H{"message": "Delete Equipment"})
-			})
+			equipmentRoutes.GET("", deps.EquipmentHandler.Get)
+			equipmentRoutes.PUT("", deps.EquipmentHandler.Update)
+			equipmentRoutes.DELETE("", deps.Middleware.AdminMiddleware(), deps.EquipmentHandler.Delete)
+			equipmentRoutes.GET("/certifications", deps.CertificationHandler.ListForEquipment)
+			equipmentRoutes.GET("/authorized-operators", deps.OperatorAuthorizationHandler.ForEquipment)
 		}
 	}
 }
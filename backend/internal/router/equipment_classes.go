@@ -0,0 +1,22 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+func setupEquipmentClassRoutes(rg *gin.RouterGroup, deps *RouterDeps) {
+	classes := rg.Group("/equipment-classes")
+	{
+		classes.GET("", deps.EquipmentClassHandler.List)
+		classes.GET("/:id", deps.EquipmentClassHandler.Get)
+	}
+}
+
+func setupAdminEquipmentClassRoutes(rg *gin.RouterGroup, deps *RouterDeps) {
+	classes := rg.Group("/equipment-classes")
+	{
+		classes.POST("", deps.EquipmentClassHandler.Create)
+		classes.PUT("/:id", deps.EquipmentClassHandler.Update)
+		classes.DELETE("/:id", deps.EquipmentClassHandler.Delete)
+	}
+}
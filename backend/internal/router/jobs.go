@@ -0,0 +1,17 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+func setupAdminJobRoutes(rg *gin.RouterGroup, deps *RouterDeps) {
+	jobs := rg.Group("/jobs")
+	{
+		jobs.POST("/expiry-sweep", deps.JobsHandler.RunExpirySweep)
+		jobs.POST("/storage-verify", deps.JobsHandler.RunStorageVerify)
+		jobs.POST("/rotate-keys", deps.JobsHandler.RunKeyRotation)
+		jobs.POST("/expiration-check", deps.JobsHandler.RunExpirationCheck)
+		jobs.POST("/outbox", deps.JobsHandler.RunOutbox)
+		jobs.POST("/digests", deps.JobsHandler.RunDigests)
+	}
+}
@@ -0,0 +1,16 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+func setupAdminNotificationRuleRoutes(rg *gin.RouterGroup, deps *RouterDeps) {
+	rules := rg.Group("/notification-rules")
+	{
+		rules.GET("", deps.NotificationRuleHandler.List)
+		rules.POST("", deps.NotificationRuleHandler.Create)
+		rules.GET("/:id", deps.NotificationRuleHandler.Get)
+		rules.PUT("/:id", deps.NotificationRuleHandler.Update)
+		rules.DELETE("/:id", deps.NotificationRuleHandler.Delete)
+	}
+}
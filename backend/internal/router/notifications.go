@@ -0,0 +1,28 @@
+package router
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+func setupAdminNotificationRoutes(rg *gin.RouterGroup, deps *RouterDeps) {
+	notifications := rg.Group("/notifications")
+	{
+		notifications.GET("", deps.NotificationHandler.List)
+		notifications.POST("/:id/requeue", deps.NotificationHandler.Requeue)
+		notifications.POST("/:id/escalations/requeue", deps.NotificationEscalationHandler.Requeue)
+	}
+
+	digests := rg.Group("/notification-digests")
+	{
+		digests.GET("", deps.NotificationDigestHandler.List)
+		digests.POST("/:id/requeue", deps.NotificationDigestHandler.Requeue)
+	}
+}
+
+func setupNotificationPreferenceRoutes(rg *gin.RouterGroup, deps *RouterDeps) {
+	preferences := rg.Group("/notification-preferences")
+	{
+		preferences.GET("", deps.NotificationDigestHandler.GetPreferences)
+		preferences.PUT("", deps.NotificationDigestHandler.UpdatePreferences)
+	}
+}
@@ -1,34 +1,23 @@
 package router
 
 import (
-	"certitrack/internal/config"
-	"net/http"
-
 	"github.com/gin-gonic/gin"
-	"gorm.io/gorm"
 )
 
-func setupPeopleRoutes(rg *gin.RouterGroup, db *gorm.DB, cfg *config.Config) {
+func setupPeopleRoutes(rg *gin.RouterGroup, deps *RouterDeps) {
 	people := rg.Group("/people")
 	{
-		people.GET("", func(ctx *gin.Context) {
-			ctx.JSON(http.StatusOK, gin.H{"message": "People"})
-		})
-		people.POST("", func(ctx *gin.Context) {
-			ctx.JSON(http.StatusOK, gin.H{"message": "Create Person"})
-		})
+		people.GET("", deps.PeopleHandler.List)
+		people.POST("", deps.PeopleHandler.Create)
 
 		personRoutes := people.Group("/:id")
 		{
-			personRoutes.GET("", func(ctx *gin.Context) {
-				ctx.JSON(http.StatusOK, gin.H{"message": "Get Person"})
-			})
-			personRoutes.PUT("", func(ctx *gin.Context) {
-				ctx.JSON(http.StatusOK, gin.H{"message": "Update Person"})
-			})
-			personRoutes.DELETE("", func(ctx *gin.Context) {
-				ctx.JSON(http.StatusOK, gin.H{"message": "Delete Person"})
-			})
+			personRoutes.GET("", deps.PeopleHandler.Get)
+			personRoutes.PUT("", deps.PeopleHandler.Update)
+			personRoutes.DELETE("", deps.Middleware.AdminMiddleware(), deps.PeopleHandler.Delete)
+			personRoutes.GET("/certifications", deps.CertificationHandler.ListForPerson)
+			personRoutes.GET("/compliance", deps.ComplianceHandler.ForPerson)
+			personRoutes.GET("/authorized-equipment", deps.OperatorAuthorizationHandler.ForPerson)
 		}
 	}
 }
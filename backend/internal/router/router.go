@@ -8,12 +8,25 @@ import (
 )
 
 type RouterDeps struct {
-	AuthHandler *handlers.AuthHandler
+	AuthHandler                     *handlers.AuthHandler
+	PeopleHandler                   *handlers.PeopleHandler
+	EquipmentHandler                *handlers.EquipmentHandler
+	EquipmentClassHandler           *handlers.EquipmentClassHandler
+	CertificationTypeHandler        *handlers.CertificationTypeHandler
+	CertificationHandler            *handlers.CertificationHandler
+	DocumentHandler                 *handlers.DocumentHandler
+	DocumentLinkHandler             *handlers.DocumentLinkHandler
+	DocumentAccessHandler           *handlers.DocumentAccessHandler
+	CertificationRequirementHandler *handlers.CertificationRequirementHandler
+	ComplianceHandler               *handlers.ComplianceHandler
+	OperatorAuthorizationHandler    *handlers.OperatorAuthorizationHandler
+	NotificationRuleHandler         *handlers.NotificationRuleHandler
+	NotificationHandler             *handlers.NotificationHandler
+	NotificationDigestHandler       *handlers.NotificationDigestHandler
+	NotificationEscalationHandler   *handlers.NotificationEscalationHandler
+	JobsHandler                     *handlers.JobsHandler
 	// TODO: Agregar los demás handlers cuando estén definidos
 	// UserHandler      *handlers.UserHandler
-	// PeopleHandler    *handlers.PeopleHandler
-	// EquipmentHandler *handlers.EquipmentHandler
-	// CertHandler      *handlers.CertificationHandler
 	Middleware *middleware.Middleware
 }
 
@@ -24,16 +37,31 @@ func SetupRouter(deps *RouterDeps, r *gin.Engine) {
 	v1 := r.Group("/api/v1")
 	{
 		setupAuthRoutes(v1, deps)
+		setupPublicDocumentRoutes(v1, deps)
 
 		protected := v1.Group("")
 		protected.Use(deps.Middleware.AuthMiddleware())
 		{
 			setupProtectedAuthRoutes(protected, deps)
+			setupPeopleRoutes(protected, deps)
+			setupEquipmentRoutes(protected, deps)
+			setupEquipmentClassRoutes(protected, deps)
+			setupCertificationTypeRoutes(protected, deps)
+			setupCertificationRoutes(protected, deps)
+			setupDocumentRoutes(protected, deps)
+			setupComplianceRoutes(protected, deps)
+			setupNotificationPreferenceRoutes(protected, deps)
 
 			adminProtected := protected.Group("")
 			adminProtected.Use(deps.Middleware.AdminMiddleware())
 			{
 				setupUserRoutes(adminProtected, deps)
+				setupAdminCertificationTypeRoutes(adminProtected, deps)
+				setupAdminEquipmentClassRoutes(adminProtected, deps)
+				setupAdminComplianceRoutes(adminProtected, deps)
+				setupAdminNotificationRuleRoutes(adminProtected, deps)
+				setupAdminNotificationRoutes(adminProtected, deps)
+				setupAdminJobRoutes(adminProtected, deps)
 			}
 		}
 	}
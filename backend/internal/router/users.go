@@ -28,6 +28,7 @@ func setupUserRoutes(rg *gin.RouterGroup, deps *RouterDeps) {
 			userRoutes.DELETE("", func(ctx *gin.Context) {
 				ctx.JSON(http.StatusOK, gin.H{"message": "Delete User"})
 			})
+			userRoutes.GET("/document-access", deps.DocumentAccessHandler.ListForUser)
 		}
 	}
 }
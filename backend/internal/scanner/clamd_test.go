@@ -0,0 +1,141 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"certitrack/internal/config"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClamd speaks enough of the clamd protocol to answer INSTREAM. It
+// replies with the given verdict for streams containing marker and OK
+// otherwise, and records the reassembled stream.
+type fakeClamd struct {
+	listener net.Listener
+	marker   string
+	verdict  string
+	received chan []byte
+}
+
+func startFakeClamd(t *testing.T, marker, verdict string) *fakeClamd {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	f := &fakeClamd{listener: ln, marker: marker, verdict: verdict, received: make(chan []byte, 1)}
+	go f.serve()
+	t.Cleanup(func() { ln.Close() })
+	return f
+}
+
+func (f *fakeClamd) serve() {
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeClamd) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	cmd, err := r.ReadString(0)
+	if err != nil || cmd != "zINSTREAM\x00" {
+		io.WriteString(conn, "UNKNOWN COMMAND\x00")
+		return
+	}
+
+	var stream bytes.Buffer
+	for {
+		var size uint32
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return
+		}
+		if size == 0 {
+			break
+		}
+		if _, err := io.CopyN(&stream, r, int64(size)); err != nil {
+			return
+		}
+	}
+	f.received <- stream.Bytes()
+
+	if strings.Contains(stream.String(), f.marker) {
+		io.WriteString(conn, "stream: "+f.verdict+"\x00")
+		return
+	}
+	io.WriteString(conn, "stream: OK\x00")
+}
+
+func (f *fakeClamd) scanner() *ClamdScanner {
+	return NewClamdScanner(config.ScannerConfig{
+		Network: "tcp",
+		Address: f.listener.Addr().String(),
+		Timeout: 5 * time.Second,
+	})
+}
+
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+func TestClamdScanner_Clean(t *testing.T) {
+	clamd := startFakeClamd(t, eicar, "Eicar-Signature FOUND")
+	// Larger than one chunk so the stream is split.
+	content := bytes.Repeat([]byte("certificate "), clamdChunkSize/6)
+
+	result, err := clamd.scanner().Scan(context.Background(), bytes.NewReader(content))
+	require.NoError(t, err)
+	require.False(t, result.Infected)
+	require.Equal(t, content, <-clamd.received)
+}
+
+func TestClamdScanner_Infected(t *testing.T) {
+	clamd := startFakeClamd(t, eicar, "Eicar-Signature FOUND")
+
+	result, err := clamd.scanner().Scan(context.Background(), strings.NewReader("%PDF-1.4 "+eicar))
+	require.NoError(t, err)
+	require.True(t, result.Infected)
+	require.Equal(t, "Eicar-Signature", result.Signature)
+}
+
+func TestClamdScanner_ErrorReply(t *testing.T) {
+	clamd := startFakeClamd(t, "big", "INSTREAM size limit exceeded. ERROR")
+
+	_, err := clamd.scanner().Scan(context.Background(), strings.NewReader("big file"))
+	require.ErrorIs(t, err, ErrScanFailed)
+}
+
+func TestClamdScanner_Unreachable(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	ln.Close()
+
+	s := NewClamdScanner(config.ScannerConfig{Network: "tcp", Address: addr, Timeout: time.Second})
+	_, err = s.Scan(context.Background(), strings.NewReader("data"))
+	require.ErrorIs(t, err, ErrScanFailed)
+}
+
+func TestParseClamdReply(t *testing.T) {
+	result, err := parseClamdReply("stream: Win.Test.EICAR_HDB-1 FOUND\x00")
+	require.NoError(t, err)
+	require.Equal(t, &Result{Infected: true, Signature: "Win.Test.EICAR_HDB-1"}, result)
+
+	result, err = parseClamdReply("stream: OK")
+	require.NoError(t, err)
+	require.False(t, result.Infected)
+
+	_, err = parseClamdReply("")
+	require.ErrorIs(t, err, ErrScanFailed)
+}
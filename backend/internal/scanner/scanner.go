@@ -0,0 +1,42 @@
+// Package scanner checks uploaded files for malware before they are stored.
+package scanner
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"certitrack/internal/config"
+)
+
+// ErrScanFailed is returned when the scanner could not reach a verdict, for
+// example because the daemon is unreachable. The file must not be trusted.
+var ErrScanFailed = errors.New("file could not be scanned")
+
+// Result is the verdict for a single file.
+type Result struct {
+	Infected  bool
+	Signature string // name of the matched signature when Infected
+}
+
+// Scanner inspects a stream and reports whether it contains malware.
+type Scanner interface {
+	Scan(ctx context.Context, r io.Reader) (*Result, error)
+}
+
+// NewScanner returns a clamd client when scanning is enabled and a scanner
+// that accepts everything otherwise.
+func NewScanner(cfg *config.Config) Scanner {
+	if !cfg.Scanner.Enabled {
+		return NoopScanner{}
+	}
+	return NewClamdScanner(cfg.Scanner)
+}
+
+// NoopScanner reports every file as clean. It is used when scanning is
+// disabled, typically in development.
+type NoopScanner struct{}
+
+func (NoopScanner) Scan(ctx context.Context, r io.Reader) (*Result, error) {
+	return &Result{}, nil
+}
@@ -2,6 +2,8 @@
 package services
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"log"
@@ -191,6 +193,16 @@ func (s *AuthServiceImpl) RevokeToken(req *LogoutRequest) (*AuthResponse, error)
 			log.Printf("Failed to revoke access token: %v", err)
 			return nil, fmt.Errorf("failed to revoke access token: %w", err)
 		}
+		// Document links created with the token carry its session ID and
+		// stay valid for up to DOCUMENT_LINK_MAX_TTL, so the session has to
+		// stay revoked at least that long.
+		if err := s.tokenRepo.RevokeSession(
+			TokenSessionID(req.AccessToken),
+			max(s.config.JWT.AccessTokenExpiry, s.config.Links.MaxTTL),
+		); err != nil {
+			log.Printf("Failed to revoke access token session: %v", err)
+			return nil, fmt.Errorf("failed to revoke access token: %w", err)
+		}
 	}
 
 	if req.RefreshToken != "" {
@@ -291,6 +303,13 @@ func (s *AuthServiceImpl) GenerateRefreshToken(user *models.User) (string, error
 	return token.SignedString([]byte(s.config.JWT.Secret))
 }
 
+// TokenSessionID identifies the session of an access token without
+// revealing the token, so it can be embedded in a document link.
+func TokenSessionID(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return base64.RawURLEncoding.EncodeToString(sum[:16])
+}
+
 func (s *AuthServiceImpl) IsTokenRevoked(tokenString string) (bool, error) {
 	return s.tokenRepo.IsTokenRevoked(tokenString)
 }
@@ -113,8 +113,9 @@ func TestLogout_Success(t *testing.T) {
 	mockClient.On("Exists", mock.Anything, mock.Anything).
 		Return(redis.NewIntResult(0, nil)).Once()
 
+	// The token itself and its session are revoked.
 	mockClient.On("Set", mock.Anything, mock.Anything, "1", mock.Anything).
-		Return(redis.NewStatusResult("OK", nil)).Once()
+		Return(redis.NewStatusResult("OK", nil)).Twice()
 
 	mockClient.On("Exists", mock.Anything, mock.Anything).
 		Return(redis.NewIntResult(1, nil)).Once()
@@ -153,10 +154,14 @@ func TestRevokeToken_AccessToken(t *testing.T) {
 	})
 
 	tokenKey := "revoked:" + loginResp.AccessToken
+	sessionKey := "revoked:session:" + services.TokenSessionID(loginResp.AccessToken)
 
 	mockClient.On("Set", mock.Anything, tokenKey, "1", mock.Anything).
 		Return(redis.NewStatusResult("OK", nil)).
 		Once()
+	mockClient.On("Set", mock.Anything, sessionKey, "1", mock.Anything).
+		Return(redis.NewStatusResult("OK", nil)).
+		Once()
 
 	logoutRes, err := service.RevokeToken(&services.LogoutRequest{
 		AccessToken:  loginResp.AccessToken,
@@ -207,11 +212,15 @@ func TestRevokeToken_BothTokens(t *testing.T) {
 
 	accessTokenKey := "revoked:" + loginResp.AccessToken
 	refreshTokenKey := "revoked:" + loginResp.RefreshToken
+	sessionKey := "revoked:session:" + services.TokenSessionID(loginResp.AccessToken)
 
 	// Configurar mocks para ambos tokens
 	mockClient.On("Set", mock.Anything, accessTokenKey, "1", mock.Anything).
 		Return(redis.NewStatusResult("OK", nil)).
 		Once()
+	mockClient.On("Set", mock.Anything, sessionKey, "1", mock.Anything).
+		Return(redis.NewStatusResult("OK", nil)).
+		Once()
 	mockClient.On("Set", mock.Anything, refreshTokenKey, "1", mock.Anything).
 		Return(redis.NewStatusResult("OK", nil)).
 		Once()
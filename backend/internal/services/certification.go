@@ -0,0 +1,331 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CertificationService interface {
+	CreateCertification(req *CreateCertificationRequest, actorID uuid.UUID) (*models.Certification, error)
+	GetCertification(id uuid.UUID) (*models.Certification, error)
+	ListCertifications(req *ListCertificationsRequest) (*CertificationListResponse, error)
+	ListPersonCertifications(personID uuid.UUID, req *ListCertificationsRequest) (*CertificationListResponse, error)
+	ListEquipmentCertifications(equipmentID uuid.UUID, req *ListCertificationsRequest) (*CertificationListResponse, error)
+	UpdateCertification(id uuid.UUID, req *UpdateCertificationRequest, actorID uuid.UUID) (*models.Certification, error)
+	DeleteCertification(id uuid.UUID) error
+	RevokeCertification(id uuid.UUID, req *RevokeCertificationRequest, actorID uuid.UUID) (*models.Certification, error)
+	ActivateCertification(id uuid.UUID, req *ActivateCertificationRequest, actorID uuid.UUID) (*models.Certification, error)
+	GetStatusHistory(id uuid.UUID) ([]models.CertificationStatusHistory, error)
+	ExpireOverdueCertifications(ctx context.Context, asOf time.Time) (int, error)
+	RenewCertification(id uuid.UUID, req *RenewCertificationRequest, actorID uuid.UUID) (*models.Certification, error)
+	GetRenewalHistory(id uuid.UUID) ([]models.Certification, error)
+}
+
+type CertificationServiceImpl struct {
+	repository    repositories.CertificationRepository
+	typeRepo      repositories.CertificationTypeRepository
+	personRepo    repositories.PersonRepository
+	equipmentRepo repositories.EquipmentRepository
+}
+
+var _ CertificationService = (*CertificationServiceImpl)(nil)
+
+type CreateCertificationRequest struct {
+	CertificationTypeID uuid.UUID  `json:"certification_type_id" binding:"required"`
+	PersonID            *uuid.UUID `json:"person_id"`
+	EquipmentID         *uuid.UUID `json:"equipment_id"`
+	CertificateNumber   string     `json:"certificate_number" binding:"omitempty,max=100"`
+	IssuingAuthority    string     `json:"issuing_authority" binding:"omitempty,max=200"`
+	IssueDate           string     `json:"issue_date" binding:"required,datetime=2006-01-02"`
+	ExpirationDate      string     `json:"expiration_date" binding:"omitempty,datetime=2006-01-02"`
+	Status              string     `json:"status" binding:"omitempty,oneof=pending active"`
+	Notes               string     `json:"notes"`
+}
+
+type UpdateCertificationRequest struct {
+	CertificationTypeID *uuid.UUID `json:"certification_type_id"`
+	CertificateNumber   *string    `json:"certificate_number" binding:"omitempty,max=100"`
+	IssuingAuthority    *string    `json:"issuing_authority" binding:"omitempty,max=200"`
+	IssueDate           *string    `json:"issue_date" binding:"omitempty,datetime=2006-01-02"`
+	ExpirationDate      *string    `json:"expiration_date" binding:"omitempty,datetime=2006-01-02"`
+	Notes               *string    `json:"notes"`
+}
+
+type ListCertificationsRequest struct {
+	PageRequest
+	Search              string `form:"search"`
+	PersonID            string `form:"personId" binding:"omitempty,uuid"`
+	EquipmentID         string `form:"equipmentId" binding:"omitempty,uuid"`
+	CertificationTypeID string `form:"certificationTypeId" binding:"omitempty,uuid"`
+	Status              string `form:"status" binding:"omitempty,oneof=pending active expired revoked superseded"`
+	ExpiringInDays      *int   `form:"expiringInDays" binding:"omitempty,min=0"`
+	SortBy              string `form:"sortBy" binding:"omitempty,oneof=expirationDate issueDate createdAt"`
+	SortOrder           string `form:"sortOrder" binding:"omitempty,oneof=asc desc"`
+}
+
+type CertificationListResponse struct {
+	Items      []models.Certification `json:"items"`
+	Pagination Pagination             `json:"pagination"`
+}
+
+var (
+	ErrCertificationNotFound      = errors.New("certification not found")
+	ErrInvalidCertificationTarget = errors.New("certification must be linked to exactly one person or one piece of equipment")
+	ErrInvalidCertificationDates  = errors.New("expiration date must be after issue date")
+	ErrExpirationDateRequired     = errors.New("expiration date is required when the certification type has no default validity period")
+	ErrInactiveCertificationType  = errors.New("certification type is inactive")
+)
+
+func NewCertificationService(
+	repository repositories.CertificationRepository,
+	typeRepo repositories.CertificationTypeRepository,
+	personRepo repositories.PersonRepository,
+	equipmentRepo repositories.EquipmentRepository,
+) *CertificationServiceImpl {
+	return &CertificationServiceImpl{
+		repository:    repository,
+		typeRepo:      typeRepo,
+		personRepo:    personRepo,
+		equipmentRepo: equipmentRepo,
+	}
+}
+
+func (s *CertificationServiceImpl) CreateCertification(req *CreateCertificationRequest, actorID uuid.UUID) (*models.Certification, error) {
+	if err := s.validateTarget(req.PersonID, req.EquipmentID); err != nil {
+		return nil, err
+	}
+
+	certType, err := s.findActiveType(req.CertificationTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	issueDate, expirationDate, err := resolveCertificationDates(req.IssueDate, req.ExpirationDate, certType)
+	if err != nil {
+		return nil, err
+	}
+
+	status := req.Status
+	if status == "" {
+		status = models.CertificationStatusActive
+	}
+
+	cert := models.Certification{
+		CertificationTypeID: certType.ID,
+		PersonID:            req.PersonID,
+		EquipmentID:         req.EquipmentID,
+		CertificateNumber:   req.CertificateNumber,
+		IssuingAuthority:    req.IssuingAuthority,
+		IssueDate:           issueDate,
+		ExpirationDate:      expirationDate,
+		Status:              status,
+		Notes:               req.Notes,
+		CreatedBy:           nullableID(actorID),
+		UpdatedBy:           nullableID(actorID),
+	}
+
+	if err := s.repository.Create(&cert); err != nil {
+		if errors.Is(err, gorm.ErrCheckConstraintViolated) {
+			return nil, ErrInvalidCertificationTarget
+		}
+		return nil, err
+	}
+
+	return s.GetCertification(cert.ID)
+}
+
+func (s *CertificationServiceImpl) GetCertification(id uuid.UUID) (*models.Certification, error) {
+	cert, err := s.repository.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCertificationNotFound
+		}
+		return nil, err
+	}
+	return cert, nil
+}
+
+func (s *CertificationServiceImpl) ListCertifications(req *ListCertificationsRequest) (*CertificationListResponse, error) {
+	page := req.toPagination()
+	certs, total, err := s.repository.List(repositories.CertificationFilter{
+		Pagination:          page,
+		Search:              req.Search,
+		PersonID:            queryID(req.PersonID),
+		EquipmentID:         queryID(req.EquipmentID),
+		CertificationTypeID: queryID(req.CertificationTypeID),
+		Status:              req.Status,
+		ExpiringWithinDays:  req.ExpiringInDays,
+		SortBy:              req.SortBy,
+		SortOrder:           req.SortOrder,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CertificationListResponse{
+		Items:      certs,
+		Pagination: newPagination(page, total),
+	}, nil
+}
+
+func (s *CertificationServiceImpl) ListPersonCertifications(personID uuid.UUID, req *ListCertificationsRequest) (*CertificationListResponse, error) {
+	if _, err := s.personRepo.FindByID(personID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPersonNotFound
+		}
+		return nil, err
+	}
+
+	req.PersonID = personID.String()
+	req.EquipmentID = ""
+	return s.ListCertifications(req)
+}
+
+func (s *CertificationServiceImpl) ListEquipmentCertifications(equipmentID uuid.UUID, req *ListCertificationsRequest) (*CertificationListResponse, error) {
+	if _, err := s.equipmentRepo.FindByID(equipmentID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEquipmentNotFound
+		}
+		return nil, err
+	}
+
+	req.EquipmentID = equipmentID.String()
+	req.PersonID = ""
+	return s.ListCertifications(req)
+}
+
+func (s *CertificationServiceImpl) UpdateCertification(id uuid.UUID, req *UpdateCertificationRequest, actorID uuid.UUID) (*models.Certification, error) {
+	cert, err := s.GetCertification(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.CertificationTypeID != nil && *req.CertificationTypeID != cert.CertificationTypeID {
+		certType, err := s.findActiveType(*req.CertificationTypeID)
+		if err != nil {
+			return nil, err
+		}
+		cert.CertificationTypeID = certType.ID
+		cert.CertificationType = certType
+	}
+	if req.CertificateNumber != nil {
+		cert.CertificateNumber = *req.CertificateNumber
+	}
+	if req.IssuingAuthority != nil {
+		cert.IssuingAuthority = *req.IssuingAuthority
+	}
+	if req.IssueDate != nil {
+		issueDate, err := parseDate(*req.IssueDate)
+		if err != nil || issueDate == nil {
+			return nil, ErrInvalidCertificationDates
+		}
+		cert.IssueDate = *issueDate
+	}
+	if req.ExpirationDate != nil {
+		expirationDate, err := parseDate(*req.ExpirationDate)
+		if err != nil || expirationDate == nil {
+			return nil, ErrInvalidCertificationDates
+		}
+		cert.ExpirationDate = *expirationDate
+	}
+	if req.Notes != nil {
+		cert.Notes = *req.Notes
+	}
+
+	if !cert.ExpirationDate.After(cert.IssueDate) {
+		return nil, ErrInvalidCertificationDates
+	}
+	cert.UpdatedBy = nullableID(actorID)
+
+	if err := s.repository.Update(cert); err != nil {
+		return nil, err
+	}
+
+	return s.GetCertification(cert.ID)
+}
+
+func (s *CertificationServiceImpl) DeleteCertification(id uuid.UUID) error {
+	if err := s.repository.Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrCertificationNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// validateTarget enforces the same rule as the check_person_or_equipment
+// constraint and makes sure the referenced record exists.
+func (s *CertificationServiceImpl) validateTarget(personID, equipmentID *uuid.UUID) error {
+	hasPerson := personID != nil && *personID != uuid.Nil
+	hasEquipment := equipmentID != nil && *equipmentID != uuid.Nil
+	if hasPerson == hasEquipment {
+		return ErrInvalidCertificationTarget
+	}
+
+	if hasPerson {
+		if _, err := s.personRepo.FindByID(*personID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrPersonNotFound
+			}
+			return err
+		}
+		return nil
+	}
+
+	if _, err := s.equipmentRepo.FindByID(*equipmentID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrEquipmentNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *CertificationServiceImpl) findActiveType(id uuid.UUID) (*models.CertificationType, error) {
+	certType, err := s.typeRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCertificationTypeNotFound
+		}
+		return nil, err
+	}
+	if !certType.IsActive {
+		return nil, ErrInactiveCertificationType
+	}
+	return certType, nil
+}
+
+// resolveCertificationDates parses the request dates and, when the expiration
+// date is omitted, derives it from the type's default validity period.
+func resolveCertificationDates(issue, expiration string, certType *models.CertificationType) (time.Time, time.Time, error) {
+	issueDate, err := parseDate(issue)
+	if err != nil || issueDate == nil {
+		return time.Time{}, time.Time{}, ErrInvalidCertificationDates
+	}
+
+	expirationDate, err := parseDate(expiration)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidCertificationDates
+	}
+	if expirationDate == nil {
+		if certType.DefaultValidityPeriod == nil || *certType.DefaultValidityPeriod <= 0 {
+			return time.Time{}, time.Time{}, ErrExpirationDateRequired
+		}
+		computed := issueDate.AddDate(0, 0, *certType.DefaultValidityPeriod)
+		expirationDate = &computed
+	}
+
+	if !expirationDate.After(*issueDate) {
+		return time.Time{}, time.Time{}, ErrInvalidCertificationDates
+	}
+
+	return *issueDate, *expirationDate, nil
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"errors"
+
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RenewCertificationRequest struct {
+	CertificateNumber string `json:"certificate_number" binding:"required,max=100"`
+	IssuingAuthority  string `json:"issuing_authority" binding:"omitempty,max=200"`
+	IssueDate         string `json:"issue_date" binding:"required,datetime=2006-01-02"`
+	ExpirationDate    string `json:"expiration_date" binding:"omitempty,datetime=2006-01-02"`
+	Notes             string `json:"notes"`
+}
+
+var ErrCertificationAlreadyRenewed = errors.New("certification has already been renewed")
+
+// RenewCertification creates an active successor for the certification and
+// marks the predecessor superseded. Superseded certifications are no longer
+// active, so expiry sweeps and reminders skip them from then on.
+func (s *CertificationServiceImpl) RenewCertification(id uuid.UUID, req *RenewCertificationRequest, actorID uuid.UUID) (*models.Certification, error) {
+	predecessor, err := s.GetCertification(id)
+	if err != nil {
+		return nil, err
+	}
+	if !predecessor.CanTransitionTo(models.CertificationStatusSuperseded) {
+		return nil, ErrInvalidStatusTransition
+	}
+
+	certType, err := s.findActiveType(predecessor.CertificationTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	issueDate, expirationDate, err := resolveCertificationDates(req.IssueDate, req.ExpirationDate, certType)
+	if err != nil {
+		return nil, err
+	}
+	if issueDate.Before(predecessor.IssueDate) {
+		return nil, ErrInvalidCertificationDates
+	}
+
+	issuingAuthority := req.IssuingAuthority
+	if issuingAuthority == "" {
+		issuingAuthority = predecessor.IssuingAuthority
+	}
+
+	successor := models.Certification{
+		ID:                  uuid.New(),
+		CertificationTypeID: certType.ID,
+		PersonID:            predecessor.PersonID,
+		EquipmentID:         predecessor.EquipmentID,
+		CertificateNumber:   req.CertificateNumber,
+		IssuingAuthority:    issuingAuthority,
+		IssueDate:           issueDate,
+		ExpirationDate:      expirationDate,
+		Status:              models.CertificationStatusActive,
+		PredecessorID:       &predecessor.ID,
+		Notes:               req.Notes,
+		CreatedBy:           nullableID(actorID),
+		UpdatedBy:           nullableID(actorID),
+	}
+	entry := &models.CertificationStatusHistory{
+		FromStatus: predecessor.Status,
+		ToStatus:   models.CertificationStatusSuperseded,
+		Reason:     "Renewed by certification " + successor.ID.String(),
+		ChangedBy:  nullableID(actorID),
+	}
+	predecessor.UpdatedBy = nullableID(actorID)
+
+	if err := s.repository.CreateRenewal(&successor, predecessor, entry); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrStaleCertificationStatus):
+			return nil, ErrInvalidStatusTransition
+		case errors.Is(err, gorm.ErrDuplicatedKey):
+			return nil, ErrCertificationAlreadyRenewed
+		}
+		return nil, err
+	}
+
+	return s.GetCertification(successor.ID)
+}
+
+// GetRenewalHistory returns the whole renewal chain the certification belongs
+// to, ordered from the original certification to the latest renewal.
+func (s *CertificationServiceImpl) GetRenewalHistory(id uuid.UUID) ([]models.Certification, error) {
+	cert, err := s.GetCertification(id)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[uuid.UUID]bool{cert.ID: true}
+	var earlier []models.Certification
+	for current := cert; current.PredecessorID != nil && !seen[*current.PredecessorID]; {
+		previous, err := s.repository.FindByID(*current.PredecessorID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				break
+			}
+			return nil, err
+		}
+		seen[previous.ID] = true
+		earlier = append(earlier, *previous)
+		current = previous
+	}
+
+	chain := make([]models.Certification, 0, len(earlier)+1)
+	for i := len(earlier) - 1; i >= 0; i-- {
+		chain = append(chain, earlier[i])
+	}
+	chain = append(chain, *cert)
+
+	for current := cert; ; {
+		next, err := s.repository.FindSuccessor(current.ID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				break
+			}
+			return nil, err
+		}
+		if seen[next.ID] {
+			break
+		}
+		seen[next.ID] = true
+		chain = append(chain, *next)
+		current = next
+	}
+
+	return chain, nil
+}
@@ -0,0 +1,89 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+)
+
+func TestRenewCertification_CreatesSuccessorAndSupersedes(t *testing.T) {
+	f := newCertificationFixture()
+	original := f.addCertification(t, models.CertificationStatusActive, "2099-01-01")
+	actorID := uuid.New()
+
+	renewal, err := f.svc.RenewCertification(original.ID, &services.RenewCertificationRequest{
+		CertificateNumber: "FL-2025-002",
+		IssueDate:         "2025-01-01",
+		ExpirationDate:    "2028-01-01",
+	}, actorID)
+	require.NoError(t, err)
+	require.Equal(t, models.CertificationStatusActive, renewal.Status)
+	require.Equal(t, original.ID, *renewal.PredecessorID)
+	require.Equal(t, *original.PersonID, *renewal.PersonID)
+	require.Equal(t, "FL-2025-002", renewal.CertificateNumber)
+
+	superseded, err := f.svc.GetCertification(original.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.CertificationStatusSuperseded, superseded.Status)
+
+	history, err := f.svc.GetStatusHistory(original.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, models.CertificationStatusSuperseded, history[0].ToStatus)
+	require.Equal(t, actorID, *history[0].ChangedBy)
+}
+
+func TestRenewCertification_RejectsSecondRenewalAndRevoked(t *testing.T) {
+	f := newCertificationFixture()
+	original := f.addCertification(t, models.CertificationStatusActive, "2099-01-01")
+	req := &services.RenewCertificationRequest{CertificateNumber: "N-2", IssueDate: "2025-01-01", ExpirationDate: "2027-01-01"}
+
+	_, err := f.svc.RenewCertification(original.ID, req, uuid.Nil)
+	require.NoError(t, err)
+	_, err = f.svc.RenewCertification(original.ID, req, uuid.Nil)
+	require.ErrorIs(t, err, services.ErrInvalidStatusTransition)
+
+	revoked := f.addCertification(t, models.CertificationStatusActive, "2099-01-01")
+	_, err = f.svc.RevokeCertification(revoked.ID, &services.RevokeCertificationRequest{Reason: "Fraud"}, uuid.Nil)
+	require.NoError(t, err)
+	_, err = f.svc.RenewCertification(revoked.ID, req, uuid.Nil)
+	require.ErrorIs(t, err, services.ErrInvalidStatusTransition)
+}
+
+func TestRenewCertification_IssueDateBeforePredecessor(t *testing.T) {
+	f := newCertificationFixture()
+	original := f.addCertification(t, models.CertificationStatusExpired, "2021-01-01")
+
+	_, err := f.svc.RenewCertification(original.ID, &services.RenewCertificationRequest{
+		CertificateNumber: "N-2",
+		IssueDate:         "2019-06-01",
+		ExpirationDate:    "2022-06-01",
+	}, uuid.Nil)
+	require.ErrorIs(t, err, services.ErrInvalidCertificationDates)
+}
+
+func TestGetRenewalHistory_WalksWholeChain(t *testing.T) {
+	f := newCertificationFixture()
+	first := f.addCertification(t, models.CertificationStatusExpired, "2021-01-01")
+	second, err := f.svc.RenewCertification(first.ID, &services.RenewCertificationRequest{
+		CertificateNumber: "N-2", IssueDate: "2021-01-01", ExpirationDate: "2023-01-01",
+	}, uuid.Nil)
+	require.NoError(t, err)
+	third, err := f.svc.RenewCertification(second.ID, &services.RenewCertificationRequest{
+		CertificateNumber: "N-3", IssueDate: "2023-01-01", ExpirationDate: "2099-01-01",
+	}, uuid.Nil)
+	require.NoError(t, err)
+
+	for _, id := range []uuid.UUID{first.ID, second.ID, third.ID} {
+		chain, err := f.svc.GetRenewalHistory(id)
+		require.NoError(t, err)
+		require.Len(t, chain, 3)
+		require.Equal(t, first.ID, chain[0].ID)
+		require.Equal(t, second.ID, chain[1].ID)
+		require.Equal(t, third.ID, chain[2].ID)
+	}
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CertificationRequirementService manages which certification types a
+// position or department requires.
+type CertificationRequirementService interface {
+	CreateRequirement(req *CreateCertificationRequirementRequest, actorID uuid.UUID) (*models.CertificationRequirement, error)
+	ListRequirements(req *ListCertificationRequirementsRequest) (*CertificationRequirementListResponse, error)
+	DeleteRequirement(id uuid.UUID) error
+}
+
+type CertificationRequirementServiceImpl struct {
+	repository repositories.CertificationRequirementRepository
+	typeRepo   repositories.CertificationTypeRepository
+}
+
+var _ CertificationRequirementService = (*CertificationRequirementServiceImpl)(nil)
+
+// CreateCertificationRequirementRequest scopes a requirement to a position, a
+// department, or a position within a department.
+type CreateCertificationRequirementRequest struct {
+	CertificationTypeID uuid.UUID `json:"certification_type_id" binding:"required"`
+	Position            string    `json:"position" binding:"omitempty,max=100"`
+	Department          string    `json:"department" binding:"omitempty,max=100"`
+}
+
+type ListCertificationRequirementsRequest struct {
+	PageRequest
+	Position            string `form:"position"`
+	Department          string `form:"department"`
+	CertificationTypeID string `form:"certificationTypeId" binding:"omitempty,uuid"`
+}
+
+type CertificationRequirementListResponse struct {
+	Items      []models.CertificationRequirement `json:"items"`
+	Pagination Pagination                        `json:"pagination"`
+}
+
+var (
+	ErrRequirementNotFound     = errors.New("certification requirement not found")
+	ErrRequirementExists       = errors.New("certification requirement already exists")
+	ErrInvalidRequirementScope = errors.New("certification requirement needs a position or a department")
+)
+
+func NewCertificationRequirementService(
+	repository repositories.CertificationRequirementRepository,
+	typeRepo repositories.CertificationTypeRepository,
+) *CertificationRequirementServiceImpl {
+	return &CertificationRequirementServiceImpl{
+		repository: repository,
+		typeRepo:   typeRepo,
+	}
+}
+
+func (s *CertificationRequirementServiceImpl) CreateRequirement(req *CreateCertificationRequirementRequest, actorID uuid.UUID) (*models.CertificationRequirement, error) {
+	position := strings.TrimSpace(req.Position)
+	department := strings.TrimSpace(req.Department)
+	if position == "" && department == "" {
+		return nil, ErrInvalidRequirementScope
+	}
+
+	certType, err := s.typeRepo.FindByID(req.CertificationTypeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCertificationTypeNotFound
+		}
+		return nil, err
+	}
+	if !certType.IsActive {
+		return nil, ErrInactiveCertificationType
+	}
+
+	requirement := models.CertificationRequirement{
+		CertificationTypeID: certType.ID,
+		Position:            position,
+		Department:          department,
+		CreatedBy:           nullableID(actorID),
+	}
+	if err := s.repository.Create(&requirement); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrRequirementExists
+		}
+		return nil, err
+	}
+
+	requirement.CertificationType = certType
+	return &requirement, nil
+}
+
+func (s *CertificationRequirementServiceImpl) ListRequirements(req *ListCertificationRequirementsRequest) (*CertificationRequirementListResponse, error) {
+	page := req.toPagination()
+	requirements, total, err := s.repository.List(repositories.CertificationRequirementFilter{
+		Pagination:          page,
+		Position:            strings.TrimSpace(req.Position),
+		Department:          strings.TrimSpace(req.Department),
+		CertificationTypeID: queryID(req.CertificationTypeID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if requirements == nil {
+		requirements = []models.CertificationRequirement{}
+	}
+
+	return &CertificationRequirementListResponse{
+		Items:      requirements,
+		Pagination: newPagination(page, total),
+	}, nil
+}
+
+func (s *CertificationRequirementServiceImpl) DeleteRequirement(id uuid.UUID) error {
+	if err := s.repository.Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrRequirementNotFound
+		}
+		return err
+	}
+	return nil
+}
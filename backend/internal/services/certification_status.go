@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+
+	"github.com/google/uuid"
+)
+
+type RevokeCertificationRequest struct {
+	Reason string `json:"reason" binding:"required,max=1000"`
+}
+
+type ActivateCertificationRequest struct {
+	Reason string `json:"reason" binding:"omitempty,max=1000"`
+}
+
+var (
+	ErrInvalidStatusTransition = errors.New("invalid certification status transition")
+	ErrCertificationExpired    = errors.New("certification expiration date has passed")
+)
+
+func (s *CertificationServiceImpl) RevokeCertification(id uuid.UUID, req *RevokeCertificationRequest, actorID uuid.UUID) (*models.Certification, error) {
+	cert, err := s.GetCertification(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.changeStatus(cert, models.CertificationStatusRevoked, req.Reason, actorID); err != nil {
+		return nil, err
+	}
+	return cert, nil
+}
+
+func (s *CertificationServiceImpl) ActivateCertification(id uuid.UUID, req *ActivateCertificationRequest, actorID uuid.UUID) (*models.Certification, error) {
+	cert, err := s.GetCertification(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if cert.DaysUntil(time.Now()) < 0 {
+		return nil, ErrCertificationExpired
+	}
+
+	if err := s.changeStatus(cert, models.CertificationStatusActive, req.Reason, actorID); err != nil {
+		return nil, err
+	}
+	return cert, nil
+}
+
+func (s *CertificationServiceImpl) GetStatusHistory(id uuid.UUID) ([]models.CertificationStatusHistory, error) {
+	if _, err := s.GetCertification(id); err != nil {
+		return nil, err
+	}
+	return s.repository.ListStatusHistory(id)
+}
+
+// ExpireOverdueCertifications moves every active certification whose
+// expiration date has passed to expired and returns how many were changed.
+// Certifications changed concurrently by someone else are skipped.
+func (s *CertificationServiceImpl) ExpireOverdueCertifications(ctx context.Context, asOf time.Time) (int, error) {
+	certs, err := s.repository.FindOverdueActive(asOf)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for i := range certs {
+		if err := ctx.Err(); err != nil {
+			return expired, err
+		}
+
+		err := s.changeStatus(&certs[i], models.CertificationStatusExpired, "Expiration date reached", uuid.Nil)
+		if errors.Is(err, ErrInvalidStatusTransition) {
+			continue
+		}
+		if err != nil {
+			return expired, err
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+
+// changeStatus validates the transition against the certification state
+// machine and persists it together with its history entry.
+func (s *CertificationServiceImpl) changeStatus(cert *models.Certification, to, reason string, actorID uuid.UUID) error {
+	if !cert.CanTransitionTo(to) {
+		return ErrInvalidStatusTransition
+	}
+
+	entry := &models.CertificationStatusHistory{
+		FromStatus: cert.Status,
+		ToStatus:   to,
+		Reason:     reason,
+		ChangedBy:  nullableID(actorID),
+	}
+	cert.UpdatedBy = nullableID(actorID)
+
+	if err := s.repository.ChangeStatus(cert, entry); err != nil {
+		if errors.Is(err, repositories.ErrStaleCertificationStatus) {
+			return ErrInvalidStatusTransition
+		}
+		return err
+	}
+
+	cert.Status = to
+	return nil
+}
@@ -0,0 +1,126 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+)
+
+func (f *certificationFixture) addCertification(t *testing.T, status, expiration string) *models.Certification {
+	t.Helper()
+	certType := f.addType(t, nil)
+	person := f.addPerson(t)
+	cert, err := f.svc.CreateCertification(&services.CreateCertificationRequest{
+		CertificationTypeID: certType.ID,
+		PersonID:            &person.ID,
+		IssueDate:           "2020-01-01",
+		ExpirationDate:      expiration,
+		Status:              status,
+	}, uuid.Nil)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestRevokeCertification_RecordsHistory(t *testing.T) {
+	f := newCertificationFixture()
+	cert := f.addCertification(t, models.CertificationStatusActive, "2099-01-01")
+	actorID := uuid.New()
+
+	revoked, err := f.svc.RevokeCertification(cert.ID, &services.RevokeCertificationRequest{Reason: "Fraudulent document"}, actorID)
+	require.NoError(t, err)
+	require.Equal(t, models.CertificationStatusRevoked, revoked.Status)
+
+	history, err := f.svc.GetStatusHistory(cert.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Equal(t, models.CertificationStatusActive, history[0].FromStatus)
+	require.Equal(t, models.CertificationStatusRevoked, history[0].ToStatus)
+	require.Equal(t, "Fraudulent document", history[0].Reason)
+	require.Equal(t, actorID, *history[0].ChangedBy)
+	require.False(t, history[0].ChangedAt.IsZero())
+}
+
+func TestRevokeCertification_TwiceIsRejected(t *testing.T) {
+	f := newCertificationFixture()
+	cert := f.addCertification(t, models.CertificationStatusActive, "2099-01-01")
+	req := &services.RevokeCertificationRequest{Reason: "Lost"}
+
+	_, err := f.svc.RevokeCertification(cert.ID, req, uuid.Nil)
+	require.NoError(t, err)
+
+	_, err = f.svc.RevokeCertification(cert.ID, req, uuid.Nil)
+	require.ErrorIs(t, err, services.ErrInvalidStatusTransition)
+}
+
+func TestActivateCertification_FromPendingAndRevoked(t *testing.T) {
+	f := newCertificationFixture()
+	cert := f.addCertification(t, models.CertificationStatusPending, "2099-01-01")
+
+	activated, err := f.svc.ActivateCertification(cert.ID, &services.ActivateCertificationRequest{}, uuid.Nil)
+	require.NoError(t, err)
+	require.Equal(t, models.CertificationStatusActive, activated.Status)
+
+	_, err = f.svc.ActivateCertification(cert.ID, &services.ActivateCertificationRequest{}, uuid.Nil)
+	require.ErrorIs(t, err, services.ErrInvalidStatusTransition)
+
+	_, err = f.svc.RevokeCertification(cert.ID, &services.RevokeCertificationRequest{Reason: "Audit"}, uuid.Nil)
+	require.NoError(t, err)
+	_, err = f.svc.ActivateCertification(cert.ID, &services.ActivateCertificationRequest{Reason: "Audit cleared"}, uuid.Nil)
+	require.NoError(t, err)
+
+	history, err := f.svc.GetStatusHistory(cert.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 3)
+}
+
+func TestActivateCertification_PastExpirationIsRejected(t *testing.T) {
+	f := newCertificationFixture()
+	cert := f.addCertification(t, models.CertificationStatusPending, "2021-01-01")
+
+	_, err := f.svc.ActivateCertification(cert.ID, &services.ActivateCertificationRequest{}, uuid.Nil)
+	require.ErrorIs(t, err, services.ErrCertificationExpired)
+}
+
+func TestGetStatusHistory_NotFound(t *testing.T) {
+	f := newCertificationFixture()
+
+	_, err := f.svc.GetStatusHistory(uuid.New())
+	require.ErrorIs(t, err, services.ErrCertificationNotFound)
+}
+
+func TestExpireOverdueCertifications_OnlyActivePastExpiration(t *testing.T) {
+	f := newCertificationFixture()
+	overdue := f.addCertification(t, models.CertificationStatusActive, "2024-05-31")
+	current := f.addCertification(t, models.CertificationStatusActive, "2024-06-01")
+	pending := f.addCertification(t, models.CertificationStatusPending, "2024-05-31")
+	asOf := time.Date(2024, 6, 1, 15, 0, 0, 0, time.UTC)
+
+	expired, err := f.svc.ExpireOverdueCertifications(context.Background(), asOf)
+	require.NoError(t, err)
+	require.Equal(t, 1, expired)
+
+	for id, status := range map[uuid.UUID]string{
+		overdue.ID: models.CertificationStatusExpired,
+		current.ID: models.CertificationStatusActive,
+		pending.ID: models.CertificationStatusPending,
+	} {
+		cert, err := f.svc.GetCertification(id)
+		require.NoError(t, err)
+		require.Equal(t, status, cert.Status)
+	}
+
+	history, err := f.svc.GetStatusHistory(overdue.ID)
+	require.NoError(t, err)
+	require.Len(t, history, 1)
+	require.Nil(t, history[0].ChangedBy)
+
+	expired, err = f.svc.ExpireOverdueCertifications(context.Background(), asOf)
+	require.NoError(t, err)
+	require.Zero(t, expired)
+}
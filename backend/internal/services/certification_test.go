@@ -0,0 +1,238 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+	"certitrack/internal/services"
+)
+
+type certificationFixture struct {
+	svc           services.CertificationService
+	repo          *repositories.MockCertificationRepository
+	typeRepo      *repositories.MockCertificationTypeRepository
+	personRepo    *repositories.MockPersonRepository
+	equipmentRepo *repositories.MockEquipmentRepository
+}
+
+func newCertificationFixture() *certificationFixture {
+	f := &certificationFixture{
+		repo:          repositories.NewMockCertificationRepository(),
+		typeRepo:      repositories.NewMockCertificationTypeRepository(),
+		personRepo:    repositories.NewMockPersonRepository(),
+		equipmentRepo: repositories.NewMockEquipmentRepository(),
+	}
+	f.svc = services.NewCertificationService(f.repo, f.typeRepo, f.personRepo, f.equipmentRepo)
+	return f
+}
+
+func (f *certificationFixture) addType(t *testing.T, validity *int) *models.CertificationType {
+	t.Helper()
+	certType := &models.CertificationType{
+		Name:                  "First Aid",
+		Category:              models.CertificationCategorySafety,
+		DefaultValidityPeriod: validity,
+		RequiresRenewal:       true,
+		IsActive:              true,
+	}
+	require.NoError(t, f.typeRepo.Create(certType))
+	return certType
+}
+
+func (f *certificationFixture) addPerson(t *testing.T) *models.Person {
+	t.Helper()
+	person := &models.Person{FirstName: "John", LastName: "Doe", IsActive: true}
+	require.NoError(t, f.personRepo.Create(person))
+	return person
+}
+
+func (f *certificationFixture) addEquipment(t *testing.T) *models.Equipment {
+	t.Helper()
+	equipment := &models.Equipment{Name: "Forklift", IsActive: true}
+	require.NoError(t, f.equipmentRepo.Create(equipment))
+	return equipment
+}
+
+func TestCreateCertification_ComputesExpirationFromValidity(t *testing.T) {
+	f := newCertificationFixture()
+	certType := f.addType(t, intPtr(730))
+	person := f.addPerson(t)
+
+	cert, err := f.svc.CreateCertification(&services.CreateCertificationRequest{
+		CertificationTypeID: certType.ID,
+		PersonID:            &person.ID,
+		IssueDate:           "2024-01-01",
+	}, uuid.New())
+
+	require.NoError(t, err)
+	require.Equal(t, "2025-12-31", cert.ExpirationDate.Format(services.DateLayout))
+	require.Equal(t, models.CertificationStatusActive, cert.Status)
+}
+
+func TestCreateCertification_RequiresExpirationWithoutValidity(t *testing.T) {
+	f := newCertificationFixture()
+	certType := f.addType(t, nil)
+	person := f.addPerson(t)
+
+	_, err := f.svc.CreateCertification(&services.CreateCertificationRequest{
+		CertificationTypeID: certType.ID,
+		PersonID:            &person.ID,
+		IssueDate:           "2024-01-01",
+	}, uuid.Nil)
+
+	require.ErrorIs(t, err, services.ErrExpirationDateRequired)
+}
+
+func TestCreateCertification_RejectsExpirationBeforeIssue(t *testing.T) {
+	f := newCertificationFixture()
+	certType := f.addType(t, nil)
+	person := f.addPerson(t)
+
+	_, err := f.svc.CreateCertification(&services.CreateCertificationRequest{
+		CertificationTypeID: certType.ID,
+		PersonID:            &person.ID,
+		IssueDate:           "2024-01-01",
+		ExpirationDate:      "2023-12-31",
+	}, uuid.Nil)
+
+	require.ErrorIs(t, err, services.ErrInvalidCertificationDates)
+}
+
+func TestCreateCertification_RequiresExactlyOneTarget(t *testing.T) {
+	f := newCertificationFixture()
+	certType := f.addType(t, intPtr(365))
+	person := f.addPerson(t)
+	equipment := f.addEquipment(t)
+
+	_, err := f.svc.CreateCertification(&services.CreateCertificationRequest{
+		CertificationTypeID: certType.ID,
+		PersonID:            &person.ID,
+		EquipmentID:         &equipment.ID,
+		IssueDate:           "2024-01-01",
+	}, uuid.Nil)
+	require.ErrorIs(t, err, services.ErrInvalidCertificationTarget)
+
+	_, err = f.svc.CreateCertification(&services.CreateCertificationRequest{
+		CertificationTypeID: certType.ID,
+		IssueDate:           "2024-01-01",
+	}, uuid.Nil)
+	require.ErrorIs(t, err, services.ErrInvalidCertificationTarget)
+}
+
+func TestCreateCertification_UnknownTargetAndType(t *testing.T) {
+	f := newCertificationFixture()
+	certType := f.addType(t, intPtr(365))
+	missing := uuid.New()
+
+	_, err := f.svc.CreateCertification(&services.CreateCertificationRequest{
+		CertificationTypeID: certType.ID,
+		EquipmentID:         &missing,
+		IssueDate:           "2024-01-01",
+	}, uuid.Nil)
+	require.ErrorIs(t, err, services.ErrEquipmentNotFound)
+
+	person := f.addPerson(t)
+	_, err = f.svc.CreateCertification(&services.CreateCertificationRequest{
+		CertificationTypeID: uuid.New(),
+		PersonID:            &person.ID,
+		IssueDate:           "2024-01-01",
+	}, uuid.Nil)
+	require.ErrorIs(t, err, services.ErrCertificationTypeNotFound)
+}
+
+func TestUpdateCertification_RejectsInvertedDates(t *testing.T) {
+	f := newCertificationFixture()
+	certType := f.addType(t, intPtr(365))
+	equipment := f.addEquipment(t)
+	cert, err := f.svc.CreateCertification(&services.CreateCertificationRequest{
+		CertificationTypeID: certType.ID,
+		EquipmentID:         &equipment.ID,
+		IssueDate:           "2024-01-01",
+	}, uuid.Nil)
+	require.NoError(t, err)
+
+	issue := "2026-01-01"
+	_, err = f.svc.UpdateCertification(cert.ID, &services.UpdateCertificationRequest{IssueDate: &issue}, uuid.Nil)
+	require.ErrorIs(t, err, services.ErrInvalidCertificationDates)
+
+	notes := "Annual inspection"
+	updated, err := f.svc.UpdateCertification(cert.ID, &services.UpdateCertificationRequest{Notes: &notes}, uuid.Nil)
+	require.NoError(t, err)
+	require.Equal(t, "Annual inspection", updated.Notes)
+}
+
+// racingCertificationRepository runs afterLoad once, right after the next
+// certification is loaded, to simulate a request that overlaps it.
+type racingCertificationRepository struct {
+	*repositories.MockCertificationRepository
+	afterLoad func()
+}
+
+func (r *racingCertificationRepository) FindByID(id uuid.UUID) (*models.Certification, error) {
+	cert, err := r.MockCertificationRepository.FindByID(id)
+	if r.afterLoad != nil {
+		afterLoad := r.afterLoad
+		r.afterLoad = nil
+		afterLoad()
+	}
+	return cert, err
+}
+
+func TestUpdateCertification_KeepsConcurrentStatusChange(t *testing.T) {
+	f := newCertificationFixture()
+	repo := &racingCertificationRepository{MockCertificationRepository: f.repo}
+	svc := services.NewCertificationService(repo, f.typeRepo, f.personRepo, f.equipmentRepo)
+	certType := f.addType(t, intPtr(365))
+	person := f.addPerson(t)
+	cert, err := svc.CreateCertification(&services.CreateCertificationRequest{
+		CertificationTypeID: certType.ID,
+		PersonID:            &person.ID,
+		IssueDate:           "2024-01-01",
+	}, uuid.Nil)
+	require.NoError(t, err)
+
+	repo.afterLoad = func() {
+		require.NoError(t, f.repo.ChangeStatus(cert, &models.CertificationStatusHistory{
+			FromStatus: models.CertificationStatusActive,
+			ToStatus:   models.CertificationStatusRevoked,
+		}))
+	}
+	notes := "Annual inspection"
+	updated, err := svc.UpdateCertification(cert.ID, &services.UpdateCertificationRequest{Notes: &notes}, uuid.Nil)
+	require.NoError(t, err)
+	require.Equal(t, "Annual inspection", updated.Notes)
+	require.Equal(t, models.CertificationStatusRevoked, updated.Status)
+}
+
+func TestListPersonCertifications_ScopesToPerson(t *testing.T) {
+	f := newCertificationFixture()
+	certType := f.addType(t, intPtr(365))
+	first := f.addPerson(t)
+	second := f.addPerson(t)
+	for _, person := range []*models.Person{first, first, second} {
+		_, err := f.svc.CreateCertification(&services.CreateCertificationRequest{
+			CertificationTypeID: certType.ID,
+			PersonID:            &person.ID,
+			IssueDate:           "2024-01-01",
+		}, uuid.Nil)
+		require.NoError(t, err)
+	}
+
+	resp, err := f.svc.ListPersonCertifications(first.ID, &services.ListCertificationsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 2)
+
+	_, err = f.svc.ListPersonCertifications(uuid.New(), &services.ListCertificationsRequest{})
+	require.ErrorIs(t, err, services.ErrPersonNotFound)
+}
+
+func TestDeleteCertification_NotFound(t *testing.T) {
+	f := newCertificationFixture()
+
+	err := f.svc.DeleteCertification(uuid.New())
+	require.ErrorIs(t, err, services.ErrCertificationNotFound)
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"errors"
+
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CertificationTypeService interface {
+	CreateType(req *CreateCertificationTypeRequest, actorID uuid.UUID) (*models.CertificationType, error)
+	GetType(id uuid.UUID) (*models.CertificationType, error)
+	ListTypes(req *ListCertificationTypesRequest) (*CertificationTypeListResponse, error)
+	UpdateType(id uuid.UUID, req *UpdateCertificationTypeRequest) (*models.CertificationType, error)
+	DeleteType(id uuid.UUID) error
+}
+
+type CertificationTypeServiceImpl struct {
+	repository repositories.CertificationTypeRepository
+}
+
+var _ CertificationTypeService = (*CertificationTypeServiceImpl)(nil)
+
+type CreateCertificationTypeRequest struct {
+	Name                  string `json:"name" binding:"required,max=200"`
+	Description           string `json:"description"`
+	Category              string `json:"category" binding:"required,oneof=safety professional equipment"`
+	DefaultValidityPeriod *int   `json:"default_validity_period" binding:"omitempty,min=1"`
+	RequiresRenewal       *bool  `json:"requires_renewal"`
+}
+
+type UpdateCertificationTypeRequest struct {
+	Name                  *string `json:"name" binding:"omitempty,min=1,max=200"`
+	Description           *string `json:"description"`
+	Category              *string `json:"category" binding:"omitempty,oneof=safety professional equipment"`
+	DefaultValidityPeriod *int    `json:"default_validity_period" binding:"omitempty,min=0"`
+	RequiresRenewal       *bool   `json:"requires_renewal"`
+	IsActive              *bool   `json:"is_active"`
+}
+
+type ListCertificationTypesRequest struct {
+	PageRequest
+	Category string `form:"category" binding:"omitempty,oneof=safety professional equipment"`
+	IsActive *bool  `form:"isActive"`
+}
+
+type CertificationTypeListResponse struct {
+	Items      []models.CertificationType `json:"items"`
+	Pagination Pagination                 `json:"pagination"`
+}
+
+var (
+	ErrCertificationTypeNotFound = errors.New("certification type not found")
+	ErrCertificationTypeInUse    = errors.New("certification type is used by existing certifications")
+)
+
+func NewCertificationTypeService(repository repositories.CertificationTypeRepository) *CertificationTypeServiceImpl {
+	return &CertificationTypeServiceImpl{
+		repository: repository,
+	}
+}
+
+func (s *CertificationTypeServiceImpl) CreateType(req *CreateCertificationTypeRequest, actorID uuid.UUID) (*models.CertificationType, error) {
+	certType := models.CertificationType{
+		Name:                  req.Name,
+		Description:           req.Description,
+		Category:              req.Category,
+		DefaultValidityPeriod: req.DefaultValidityPeriod,
+		RequiresRenewal:       true,
+		IsActive:              true,
+		CreatedBy:             nullableID(actorID),
+	}
+	if req.RequiresRenewal != nil {
+		certType.RequiresRenewal = *req.RequiresRenewal
+	}
+
+	if err := s.repository.Create(&certType); err != nil {
+		return nil, err
+	}
+
+	return &certType, nil
+}
+
+func (s *CertificationTypeServiceImpl) GetType(id uuid.UUID) (*models.CertificationType, error) {
+	certType, err := s.repository.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCertificationTypeNotFound
+		}
+		return nil, err
+	}
+	return certType, nil
+}
+
+func (s *CertificationTypeServiceImpl) ListTypes(req *ListCertificationTypesRequest) (*CertificationTypeListResponse, error) {
+	page := req.toPagination()
+	types, total, err := s.repository.List(repositories.CertificationTypeFilter{
+		Pagination: page,
+		Category:   req.Category,
+		IsActive:   req.IsActive,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CertificationTypeListResponse{
+		Items:      types,
+		Pagination: newPagination(page, total),
+	}, nil
+}
+
+func (s *CertificationTypeServiceImpl) UpdateType(id uuid.UUID, req *UpdateCertificationTypeRequest) (*models.CertificationType, error) {
+	certType, err := s.GetType(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		certType.Name = *req.Name
+	}
+	if req.Description != nil {
+		certType.Description = *req.Description
+	}
+	if req.Category != nil {
+		certType.Category = *req.Category
+	}
+	if req.DefaultValidityPeriod != nil {
+		// Zero clears the default so expiration dates must be given explicitly.
+		if *req.DefaultValidityPeriod == 0 {
+			certType.DefaultValidityPeriod = nil
+		} else {
+			certType.DefaultValidityPeriod = req.DefaultValidityPeriod
+		}
+	}
+	if req.RequiresRenewal != nil {
+		certType.RequiresRenewal = *req.RequiresRenewal
+	}
+	if req.IsActive != nil {
+		certType.IsActive = *req.IsActive
+	}
+
+	if err := s.repository.Update(certType); err != nil {
+		return nil, err
+	}
+
+	return certType, nil
+}
+
+// DeleteType removes an unused type. Types that certifications still reference
+// cannot be deleted; they should be deactivated through UpdateType instead.
+func (s *CertificationTypeServiceImpl) DeleteType(id uuid.UUID) error {
+	if err := s.repository.Delete(id); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return ErrCertificationTypeNotFound
+		case errors.Is(err, gorm.ErrForeignKeyViolated):
+			return ErrCertificationTypeInUse
+		default:
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+	"certitrack/internal/services"
+)
+
+func newCertificationTypeService() (services.CertificationTypeService, *repositories.MockCertificationTypeRepository) {
+	repo := repositories.NewMockCertificationTypeRepository()
+	return services.NewCertificationTypeService(repo), repo
+}
+
+func intPtr(i int) *int { return &i }
+
+func boolPtr(b bool) *bool { return &b }
+
+func TestCreateCertificationType_DefaultsToRenewable(t *testing.T) {
+	svc, _ := newCertificationTypeService()
+	actorID := uuid.New()
+
+	certType, err := svc.CreateType(&services.CreateCertificationTypeRequest{
+		Name:                  "First Aid Certification",
+		Category:              models.CertificationCategorySafety,
+		DefaultValidityPeriod: intPtr(730),
+	}, actorID)
+
+	require.NoError(t, err)
+	require.True(t, certType.RequiresRenewal)
+	require.True(t, certType.IsActive)
+	require.Equal(t, 730, *certType.DefaultValidityPeriod)
+	require.Equal(t, actorID, *certType.CreatedBy)
+}
+
+func TestCreateCertificationType_NonRenewable(t *testing.T) {
+	svc, _ := newCertificationTypeService()
+
+	certType, err := svc.CreateType(&services.CreateCertificationTypeRequest{
+		Name:            "Induction",
+		Category:        models.CertificationCategoryProfessional,
+		RequiresRenewal: boolPtr(false),
+	}, uuid.Nil)
+
+	require.NoError(t, err)
+	require.False(t, certType.RequiresRenewal)
+	require.Nil(t, certType.DefaultValidityPeriod)
+}
+
+func TestUpdateCertificationType_DeactivateAndClearValidity(t *testing.T) {
+	svc, _ := newCertificationTypeService()
+	certType, err := svc.CreateType(&services.CreateCertificationTypeRequest{
+		Name:                  "Calibration",
+		Category:              models.CertificationCategoryEquipment,
+		DefaultValidityPeriod: intPtr(365),
+	}, uuid.Nil)
+	require.NoError(t, err)
+
+	updated, err := svc.UpdateType(certType.ID, &services.UpdateCertificationTypeRequest{
+		DefaultValidityPeriod: intPtr(0),
+		IsActive:              boolPtr(false),
+	})
+	require.NoError(t, err)
+	require.Nil(t, updated.DefaultValidityPeriod)
+	require.False(t, updated.IsActive)
+}
+
+func TestDeleteCertificationType(t *testing.T) {
+	svc, repo := newCertificationTypeService()
+	certType, err := svc.CreateType(&services.CreateCertificationTypeRequest{Name: "Fire Safety", Category: models.CertificationCategorySafety}, uuid.Nil)
+	require.NoError(t, err)
+
+	t.Run("in use", func(t *testing.T) {
+		repo.DeleteErr = gorm.ErrForeignKeyViolated
+		defer func() { repo.DeleteErr = nil }()
+
+		require.ErrorIs(t, svc.DeleteType(certType.ID), services.ErrCertificationTypeInUse)
+	})
+
+	t.Run("unused", func(t *testing.T) {
+		require.NoError(t, svc.DeleteType(certType.ID))
+		_, err := svc.GetType(certType.ID)
+		require.ErrorIs(t, err, services.ErrCertificationTypeNotFound)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		require.ErrorIs(t, svc.DeleteType(uuid.New()), services.ErrCertificationTypeNotFound)
+	})
+}
+
+func TestListCertificationTypes_FiltersByCategory(t *testing.T) {
+	svc, _ := newCertificationTypeService()
+	for _, category := range []string{models.CertificationCategorySafety, models.CertificationCategorySafety, models.CertificationCategoryEquipment} {
+		_, err := svc.CreateType(&services.CreateCertificationTypeRequest{Name: category, Category: category}, uuid.Nil)
+		require.NoError(t, err)
+	}
+
+	resp, err := svc.ListTypes(&services.ListCertificationTypesRequest{Category: models.CertificationCategorySafety})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 2)
+}
@@ -0,0 +1,268 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Compliance status of a single required certification type.
+const (
+	ComplianceStatusCompliant = "compliant"
+	ComplianceStatusExpired   = "expired"
+	ComplianceStatusMissing   = "missing"
+)
+
+// ComplianceService compares what people hold against what their position
+// and department require.
+type ComplianceService interface {
+	GetPersonCompliance(personID uuid.UUID) (*PersonCompliance, error)
+	ListGaps(req *ListComplianceGapsRequest) (*ComplianceGapListResponse, error)
+}
+
+type ComplianceServiceImpl struct {
+	requirementRepo repositories.CertificationRequirementRepository
+	personRepo      repositories.PersonRepository
+	certRepo        repositories.CertificationRepository
+}
+
+var _ ComplianceService = (*ComplianceServiceImpl)(nil)
+
+// RequirementScope is the position and department a requirement applies to;
+// an empty field matches any value.
+type RequirementScope struct {
+	Position   string `json:"position"`
+	Department string `json:"department"`
+}
+
+// RequirementCompliance is the state of one certification type a person is
+// required to hold. Certification is the valid certification when compliant
+// and the most recently expired one when expired.
+type RequirementCompliance struct {
+	CertificationType *models.CertificationType `json:"certificationType"`
+	RequiredBy        []RequirementScope        `json:"requiredBy"`
+	Status            string                    `json:"status"`
+	Certification     *models.Certification     `json:"certification,omitempty"`
+}
+
+type PersonCompliance struct {
+	Person       *models.Person          `json:"person"`
+	Compliant    bool                    `json:"compliant"`
+	Requirements []RequirementCompliance `json:"requirements"`
+}
+
+// ComplianceGap is a required certification a person is missing or holds
+// only in expired form.
+type ComplianceGap struct {
+	Person            *models.Person            `json:"person"`
+	CertificationType *models.CertificationType `json:"certificationType"`
+	RequiredBy        []RequirementScope        `json:"requiredBy"`
+	Status            string                    `json:"status"`
+	Certification     *models.Certification     `json:"certification,omitempty"`
+}
+
+type ListComplianceGapsRequest struct {
+	PageRequest
+	Department          string `form:"department"`
+	Position            string `form:"position"`
+	CertificationTypeID string `form:"certificationTypeId" binding:"omitempty,uuid"`
+	Status              string `form:"status" binding:"omitempty,oneof=missing expired"`
+}
+
+type ComplianceGapListResponse struct {
+	Items      []ComplianceGap `json:"items"`
+	Pagination Pagination      `json:"pagination"`
+}
+
+func NewComplianceService(
+	requirementRepo repositories.CertificationRequirementRepository,
+	personRepo repositories.PersonRepository,
+	certRepo repositories.CertificationRepository,
+) *ComplianceServiceImpl {
+	return &ComplianceServiceImpl{
+		requirementRepo: requirementRepo,
+		personRepo:      personRepo,
+		certRepo:        certRepo,
+	}
+}
+
+// GetPersonCompliance lists every certification type the person's position
+// and department require, with whether the person holds it.
+func (s *ComplianceServiceImpl) GetPersonCompliance(personID uuid.UUID) (*PersonCompliance, error) {
+	person, err := s.personRepo.FindByID(personID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPersonNotFound
+		}
+		return nil, err
+	}
+
+	requirements, err := s.requirementRepo.FindForActiveTypes()
+	if err != nil {
+		return nil, err
+	}
+	certs, err := s.certRepo.FindForPeople([]uuid.UUID{person.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	results := evaluateCompliance(person, requirements, certs, time.Now())
+	compliance := &PersonCompliance{
+		Person:       person,
+		Compliant:    true,
+		Requirements: results,
+	}
+	for _, r := range results {
+		if r.Status != ComplianceStatusCompliant {
+			compliance.Compliant = false
+		}
+	}
+	return compliance, nil
+}
+
+// ListGaps returns the missing and expired required certifications of every
+// active person, ordered by person and then by certification type.
+func (s *ComplianceServiceImpl) ListGaps(req *ListComplianceGapsRequest) (*ComplianceGapListResponse, error) {
+	requirements, err := s.requirementRepo.FindForActiveTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	var positions, departments []string
+	for _, r := range requirements {
+		if r.Position != "" {
+			positions = append(positions, strings.ToLower(r.Position))
+		}
+		if r.Department != "" {
+			departments = append(departments, strings.ToLower(r.Department))
+		}
+	}
+	people, err := s.personRepo.FindActiveInScopes(positions, departments)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []models.Person
+	for _, p := range people {
+		if req.Department != "" && !strings.EqualFold(strings.TrimSpace(p.Department), strings.TrimSpace(req.Department)) {
+			continue
+		}
+		if req.Position != "" && !strings.EqualFold(strings.TrimSpace(p.Position), strings.TrimSpace(req.Position)) {
+			continue
+		}
+		filtered = append(filtered, p)
+	}
+
+	ids := make([]uuid.UUID, len(filtered))
+	for i, p := range filtered {
+		ids[i] = p.ID
+	}
+	certs, err := s.certRepo.FindForPeople(ids)
+	if err != nil {
+		return nil, err
+	}
+	certsByPerson := make(map[uuid.UUID][]models.Certification)
+	for _, c := range certs {
+		certsByPerson[*c.PersonID] = append(certsByPerson[*c.PersonID], c)
+	}
+
+	typeID := queryID(req.CertificationTypeID)
+	now := time.Now()
+	gaps := []ComplianceGap{}
+	for i := range filtered {
+		person := &filtered[i]
+		for _, r := range evaluateCompliance(person, requirements, certsByPerson[person.ID], now) {
+			if r.Status == ComplianceStatusCompliant {
+				continue
+			}
+			if req.Status != "" && r.Status != req.Status {
+				continue
+			}
+			if typeID != nil && r.CertificationType.ID != *typeID {
+				continue
+			}
+			gaps = append(gaps, ComplianceGap{
+				Person:            person,
+				CertificationType: r.CertificationType,
+				RequiredBy:        r.RequiredBy,
+				Status:            r.Status,
+				Certification:     r.Certification,
+			})
+		}
+	}
+
+	items, pagination := pageOf(gaps, req.PageRequest)
+	return &ComplianceGapListResponse{
+		Items:      items,
+		Pagination: pagination,
+	}, nil
+}
+
+// evaluateCompliance checks each certification type required of the person
+// against the certifications they hold. A type is compliant when an active
+// certification has not yet passed its expiration date, and expired when the
+// person only has lapsed ones. Pending, revoked and superseded certifications
+// do not count.
+func evaluateCompliance(person *models.Person, requirements []models.CertificationRequirement, certs []models.Certification, now time.Time) []RequirementCompliance {
+	byType := make(map[uuid.UUID]*RequirementCompliance)
+	var results []*RequirementCompliance
+	for i := range requirements {
+		r := &requirements[i]
+		if !r.AppliesTo(person) {
+			continue
+		}
+		scope := RequirementScope{Position: r.Position, Department: r.Department}
+		if existing, ok := byType[r.CertificationTypeID]; ok {
+			existing.RequiredBy = append(existing.RequiredBy, scope)
+			continue
+		}
+		result := &RequirementCompliance{
+			CertificationType: r.CertificationType,
+			RequiredBy:        []RequirementScope{scope},
+			Status:            ComplianceStatusMissing,
+		}
+		byType[r.CertificationTypeID] = result
+		results = append(results, result)
+	}
+
+	for i := range certs {
+		cert := &certs[i]
+		result, ok := byType[cert.CertificationTypeID]
+		if !ok {
+			continue
+		}
+		switch {
+		case cert.Status == models.CertificationStatusActive && cert.DaysUntil(now) >= 0:
+			if result.Status != ComplianceStatusCompliant || cert.ExpirationDate.After(result.Certification.ExpirationDate) {
+				result.Status = ComplianceStatusCompliant
+				result.Certification = cert
+			}
+		case cert.Status == models.CertificationStatusActive, cert.Status == models.CertificationStatusExpired:
+			switch result.Status {
+			case ComplianceStatusMissing:
+				result.Status = ComplianceStatusExpired
+				result.Certification = cert
+			case ComplianceStatusExpired:
+				if cert.ExpirationDate.After(result.Certification.ExpirationDate) {
+					result.Certification = cert
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].CertificationType.Name < results[j].CertificationType.Name
+	})
+	compliance := make([]RequirementCompliance, len(results))
+	for i, r := range results {
+		compliance[i] = *r
+	}
+	return compliance
+}
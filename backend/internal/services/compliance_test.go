@@ -0,0 +1,255 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+	"certitrack/internal/services"
+)
+
+type complianceFixture struct {
+	requirements services.CertificationRequirementService
+	compliance   services.ComplianceService
+	repo         *repositories.MockCertificationRequirementRepository
+	typeRepo     *repositories.MockCertificationTypeRepository
+	personRepo   *repositories.MockPersonRepository
+	certRepo     *repositories.MockCertificationRepository
+}
+
+func newComplianceFixture() *complianceFixture {
+	f := &complianceFixture{
+		typeRepo:   repositories.NewMockCertificationTypeRepository(),
+		personRepo: repositories.NewMockPersonRepository(),
+		certRepo:   repositories.NewMockCertificationRepository(),
+	}
+	f.repo = repositories.NewMockCertificationRequirementRepository(f.typeRepo)
+	f.requirements = services.NewCertificationRequirementService(f.repo, f.typeRepo)
+	f.compliance = services.NewComplianceService(f.repo, f.personRepo, f.certRepo)
+	return f
+}
+
+func (f *complianceFixture) addType(t *testing.T, name string) *models.CertificationType {
+	t.Helper()
+	certType := &models.CertificationType{Name: name, Category: models.CertificationCategorySafety, IsActive: true}
+	require.NoError(t, f.typeRepo.Create(certType))
+	return certType
+}
+
+func (f *complianceFixture) addPerson(t *testing.T, last, position, department string) *models.Person {
+	t.Helper()
+	person := &models.Person{FirstName: "Alex", LastName: last, Position: position, Department: department, IsActive: true}
+	require.NoError(t, f.personRepo.Create(person))
+	return person
+}
+
+func (f *complianceFixture) require(t *testing.T, certType *models.CertificationType, position, department string) {
+	t.Helper()
+	_, err := f.requirements.CreateRequirement(&services.CreateCertificationRequirementRequest{
+		CertificationTypeID: certType.ID,
+		Position:            position,
+		Department:          department,
+	}, uuid.New())
+	require.NoError(t, err)
+}
+
+func (f *complianceFixture) addCert(t *testing.T, person *models.Person, certType *models.CertificationType, status string, expiresInDays int) *models.Certification {
+	t.Helper()
+	expiration := time.Now().UTC().AddDate(0, 0, expiresInDays)
+	cert := &models.Certification{
+		CertificationTypeID: certType.ID,
+		PersonID:            &person.ID,
+		IssueDate:           expiration.AddDate(-1, 0, 0),
+		ExpirationDate:      expiration,
+		Status:              status,
+	}
+	require.NoError(t, f.certRepo.Create(cert))
+	return cert
+}
+
+func statusByType(compliance *services.PersonCompliance) map[string]string {
+	statuses := make(map[string]string)
+	for _, r := range compliance.Requirements {
+		statuses[r.CertificationType.Name] = r.Status
+	}
+	return statuses
+}
+
+func TestPersonCompliance_ReportsMissingAndExpired(t *testing.T) {
+	f := newComplianceFixture()
+	electrical := f.addType(t, "Electrical Safety L2")
+	firstAid := f.addType(t, "First Aid")
+	forklift := f.addType(t, "Forklift")
+	f.require(t, electrical, "Electrician", "")
+	f.require(t, firstAid, "Electrician", "")
+	f.require(t, firstAid, "", "Maintenance")
+	f.require(t, forklift, "", "Warehouse")
+
+	person := f.addPerson(t, "Smith", "electrician", "Maintenance")
+	f.addCert(t, person, electrical, models.CertificationStatusActive, 30)
+	f.addCert(t, person, firstAid, models.CertificationStatusExpired, -10)
+
+	compliance, err := f.compliance.GetPersonCompliance(person.ID)
+
+	require.NoError(t, err)
+	require.False(t, compliance.Compliant)
+	require.Equal(t, map[string]string{
+		"Electrical Safety L2": services.ComplianceStatusCompliant,
+		"First Aid":            services.ComplianceStatusExpired,
+	}, statusByType(compliance))
+	require.Len(t, compliance.Requirements[1].RequiredBy, 2, "required by both position and department")
+}
+
+func TestPersonCompliance_OnlyValidCertificationsCount(t *testing.T) {
+	f := newComplianceFixture()
+	firstAid := f.addType(t, "First Aid")
+	f.require(t, firstAid, "Electrician", "")
+
+	overdue := f.addPerson(t, "Overdue", "Electrician", "")
+	f.addCert(t, overdue, firstAid, models.CertificationStatusActive, -1)
+	revoked := f.addPerson(t, "Revoked", "Electrician", "")
+	f.addCert(t, revoked, firstAid, models.CertificationStatusRevoked, 100)
+	renewed := f.addPerson(t, "Renewed", "Electrician", "")
+	f.addCert(t, renewed, firstAid, models.CertificationStatusSuperseded, -5)
+	current := f.addCert(t, renewed, firstAid, models.CertificationStatusActive, 0)
+
+	for person, want := range map[*models.Person]string{
+		overdue: services.ComplianceStatusExpired,
+		revoked: services.ComplianceStatusMissing,
+		renewed: services.ComplianceStatusCompliant,
+	} {
+		compliance, err := f.compliance.GetPersonCompliance(person.ID)
+		require.NoError(t, err)
+		require.Equal(t, want, compliance.Requirements[0].Status, person.LastName)
+	}
+
+	compliance, err := f.compliance.GetPersonCompliance(renewed.ID)
+	require.NoError(t, err)
+	require.True(t, compliance.Compliant)
+	require.Equal(t, current.ID, compliance.Requirements[0].Certification.ID)
+}
+
+func TestPersonCompliance_IgnoresInactiveTypes(t *testing.T) {
+	f := newComplianceFixture()
+	retired := f.addType(t, "Retired Course")
+	f.require(t, retired, "Electrician", "")
+	retired.IsActive = false
+	require.NoError(t, f.typeRepo.Update(retired))
+	person := f.addPerson(t, "Smith", "Electrician", "")
+
+	compliance, err := f.compliance.GetPersonCompliance(person.ID)
+
+	require.NoError(t, err)
+	require.True(t, compliance.Compliant)
+	require.Empty(t, compliance.Requirements)
+}
+
+func TestPersonCompliance_UnknownPerson(t *testing.T) {
+	f := newComplianceFixture()
+
+	_, err := f.compliance.GetPersonCompliance(uuid.New())
+
+	require.ErrorIs(t, err, services.ErrPersonNotFound)
+}
+
+func TestListGaps_AcrossPeople(t *testing.T) {
+	f := newComplianceFixture()
+	electrical := f.addType(t, "Electrical Safety L2")
+	firstAid := f.addType(t, "First Aid")
+	f.require(t, electrical, "Electrician", "")
+	f.require(t, firstAid, "", "Maintenance")
+
+	compliant := f.addPerson(t, "Adams", "Electrician", "Maintenance")
+	f.addCert(t, compliant, electrical, models.CertificationStatusActive, 10)
+	f.addCert(t, compliant, firstAid, models.CertificationStatusActive, 10)
+	lapsed := f.addPerson(t, "Baker", "Electrician", "Maintenance")
+	f.addCert(t, lapsed, electrical, models.CertificationStatusExpired, -3)
+	newcomer := f.addPerson(t, "Clark", "Technician", "maintenance")
+	f.addPerson(t, "Davis", "Accountant", "Finance")
+	leaver := f.addPerson(t, "Evans", "Electrician", "")
+	leaver.IsActive = false
+	require.NoError(t, f.personRepo.Update(leaver))
+
+	gaps, err := f.compliance.ListGaps(&services.ListComplianceGapsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), gaps.Pagination.Total)
+
+	type gap struct{ person, certType, status string }
+	var got []gap
+	for _, g := range gaps.Items {
+		got = append(got, gap{g.Person.LastName, g.CertificationType.Name, g.Status})
+	}
+	require.Equal(t, []gap{
+		{"Baker", "Electrical Safety L2", services.ComplianceStatusExpired},
+		{"Baker", "First Aid", services.ComplianceStatusMissing},
+		{"Clark", "First Aid", services.ComplianceStatusMissing},
+	}, got)
+
+	expired, err := f.compliance.ListGaps(&services.ListComplianceGapsRequest{Status: services.ComplianceStatusExpired})
+	require.NoError(t, err)
+	require.Len(t, expired.Items, 1)
+	require.Equal(t, lapsed.ID, expired.Items[0].Person.ID)
+
+	byPosition, err := f.compliance.ListGaps(&services.ListComplianceGapsRequest{Position: "technician"})
+	require.NoError(t, err)
+	require.Len(t, byPosition.Items, 1)
+	require.Equal(t, newcomer.ID, byPosition.Items[0].Person.ID)
+
+	byType, err := f.compliance.ListGaps(&services.ListComplianceGapsRequest{CertificationTypeID: electrical.ID.String()})
+	require.NoError(t, err)
+	require.Len(t, byType.Items, 1)
+
+	paged, err := f.compliance.ListGaps(&services.ListComplianceGapsRequest{PageRequest: services.PageRequest{Page: 2, Limit: 2}})
+	require.NoError(t, err)
+	require.Len(t, paged.Items, 1)
+	require.Equal(t, 2, paged.Pagination.TotalPages)
+}
+
+func TestCreateRequirement_Validation(t *testing.T) {
+	f := newComplianceFixture()
+	firstAid := f.addType(t, "First Aid")
+	inactive := f.addType(t, "Old Course")
+	inactive.IsActive = false
+	require.NoError(t, f.typeRepo.Update(inactive))
+
+	requirement, err := f.requirements.CreateRequirement(&services.CreateCertificationRequirementRequest{
+		CertificationTypeID: firstAid.ID,
+		Position:            "  Electrician ",
+	}, uuid.New())
+	require.NoError(t, err)
+	require.Equal(t, "Electrician", requirement.Position)
+	require.Equal(t, "First Aid", requirement.CertificationType.Name)
+
+	for req, want := range map[*services.CreateCertificationRequirementRequest]error{
+		{CertificationTypeID: firstAid.ID, Position: "Electrician"}: services.ErrRequirementExists,
+		{CertificationTypeID: firstAid.ID, Position: " "}:           services.ErrInvalidRequirementScope,
+		{CertificationTypeID: uuid.New(), Department: "Warehouse"}:  services.ErrCertificationTypeNotFound,
+		{CertificationTypeID: inactive.ID, Department: "Warehouse"}: services.ErrInactiveCertificationType,
+	} {
+		_, err := f.requirements.CreateRequirement(req, uuid.Nil)
+		require.ErrorIs(t, err, want)
+	}
+}
+
+func TestListAndDeleteRequirements(t *testing.T) {
+	f := newComplianceFixture()
+	firstAid := f.addType(t, "First Aid")
+	f.require(t, firstAid, "Electrician", "")
+	f.require(t, firstAid, "", "Warehouse")
+
+	list, err := f.requirements.ListRequirements(&services.ListCertificationRequirementsRequest{Department: "warehouse"})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+
+	require.NoError(t, f.requirements.DeleteRequirement(list.Items[0].ID))
+	require.ErrorIs(t, f.requirements.DeleteRequirement(list.Items[0].ID), services.ErrRequirementNotFound)
+
+	list, err = f.requirements.ListRequirements(&services.ListCertificationRequirementsRequest{})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	require.Equal(t, "Electrician", list.Items[0].Position)
+}
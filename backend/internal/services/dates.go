@@ -0,0 +1,20 @@
+package services
+
+import (
+	"fmt"
+	"time"
+)
+
+// DateLayout is the format used for calendar dates in request payloads.
+const DateLayout = "2006-01-02"
+
+func parseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(DateLayout, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q: expected YYYY-MM-DD", value)
+	}
+	return &t, nil
+}
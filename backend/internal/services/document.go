@@ -0,0 +1,472 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"time"
+
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+	"certitrack/internal/scanner"
+	"certitrack/internal/storage"
+	"certitrack/internal/thumbnail"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type DocumentService interface {
+	UploadDocument(certID uuid.UUID, input *UploadDocumentInput, actorID uuid.UUID) (*models.CertificationDocument, error)
+	ListDocuments(certID uuid.UUID) ([]models.CertificationDocument, error)
+	OpenDocument(certID, docID uuid.UUID, access *AccessInfo) (*models.CertificationDocument, io.ReadCloser, error)
+	DeleteDocument(certID, docID uuid.UUID) error
+	ReplaceDocument(certID, docID uuid.UUID, input *UploadDocumentInput, actorID uuid.UUID) (*models.CertificationDocument, error)
+	ListVersions(certID, docID uuid.UUID) ([]models.CertificationDocumentVersion, error)
+	OpenVersion(certID, docID uuid.UUID, version int, access *AccessInfo) (*models.CertificationDocumentVersion, io.ReadCloser, error)
+	DeleteVersion(certID, docID uuid.UUID, version int) error
+	VerifyStorage(ctx context.Context) (*StorageVerifyReport, error)
+	GetThumbnail(docID uuid.UUID, access *AccessInfo) (*DocumentThumbnail, error)
+}
+
+// ThumbnailQueue schedules thumbnail generation for stored content.
+type ThumbnailQueue interface {
+	Enqueue(contentHash, mimeType string)
+}
+
+type DocumentServiceImpl struct {
+	repository repositories.CertificationDocumentRepository
+	certRepo   repositories.CertificationRepository
+	auditRepo  repositories.AuditLogRepository
+	accessRepo repositories.DocumentAccessLogRepository
+	store      storage.BlobStore
+	limits     *storage.Limits
+	scanner    scanner.Scanner
+	thumbnails ThumbnailQueue
+}
+
+var _ DocumentService = (*DocumentServiceImpl)(nil)
+
+// UploadDocumentInput describes an uploaded file independently of how it was
+// received.
+type UploadDocumentInput struct {
+	FileName    string
+	Size        int64
+	Description string
+	Content     storage.Content
+}
+
+var (
+	ErrDocumentNotFound        = errors.New("document not found")
+	ErrDocumentTooLarge        = errors.New("document exceeds the maximum allowed size")
+	ErrDocumentTypeNotAllowed  = errors.New("document file type is not allowed")
+	ErrDocumentEmpty           = errors.New("document is empty")
+	ErrDocumentContentInvalid  = errors.New("document content does not match its file type")
+	ErrDocumentInfected        = errors.New("document failed the malware scan")
+	ErrDocumentScanFailed      = errors.New("document could not be scanned")
+	ErrDocumentVersionNotFound = errors.New("document version not found")
+	ErrCurrentDocumentVersion  = errors.New("the current document version cannot be deleted")
+	ErrDocumentVersionConflict = errors.New("document was replaced concurrently")
+	ErrDocumentCorrupted       = errors.New("document failed its integrity check")
+	ErrDocumentAccessNotLogged = errors.New("document access could not be recorded")
+)
+
+func NewDocumentService(
+	repository repositories.CertificationDocumentRepository,
+	certRepo repositories.CertificationRepository,
+	auditRepo repositories.AuditLogRepository,
+	accessRepo repositories.DocumentAccessLogRepository,
+	store storage.BlobStore,
+	limits *storage.Limits,
+	scanner scanner.Scanner,
+	thumbnails ThumbnailQueue,
+) *DocumentServiceImpl {
+	return &DocumentServiceImpl{
+		repository: repository,
+		certRepo:   certRepo,
+		auditRepo:  auditRepo,
+		accessRepo: accessRepo,
+		store:      store,
+		limits:     limits,
+		scanner:    scanner,
+		thumbnails: thumbnails,
+	}
+}
+
+func (s *DocumentServiceImpl) UploadDocument(certID uuid.UUID, input *UploadDocumentInput, actorID uuid.UUID) (*models.CertificationDocument, error) {
+	mimeType, err := s.validateUpload(input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureCertification(certID); err != nil {
+		return nil, err
+	}
+
+	doc := models.CertificationDocument{
+		ID:              uuid.New(),
+		CertificationID: certID,
+		FileName:        filepath.Base(input.FileName),
+		MimeType:        mimeType,
+		Description:     input.Description,
+		UploadedAt:      time.Now(),
+		UploadedBy:      nullableID(actorID),
+		Version:         1,
+	}
+	if err := s.ingest(&doc, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Create(&doc); err != nil {
+		s.releaseObject(doc.FilePath, doc.ContentHash)
+		return nil, err
+	}
+	s.thumbnails.Enqueue(doc.ContentHash, doc.MimeType)
+
+	return &doc, nil
+}
+
+// ReplaceDocument uploads a new version of a document. The previous file is
+// archived and stays downloadable through the version endpoints.
+func (s *DocumentServiceImpl) ReplaceDocument(certID, docID uuid.UUID, input *UploadDocumentInput, actorID uuid.UUID) (*models.CertificationDocument, error) {
+	mimeType, err := s.validateUpload(input)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := s.findDocument(certID, docID)
+	if err != nil {
+		return nil, err
+	}
+
+	archived := doc.CurrentVersion()
+	archived.Current = false
+
+	doc.FileName = filepath.Base(input.FileName)
+	doc.MimeType = mimeType
+	doc.UploadedAt = time.Now()
+	doc.UploadedBy = nullableID(actorID)
+	doc.Version++
+	if input.Description != "" {
+		doc.Description = input.Description
+	}
+	if err := s.ingest(doc, input); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.ReplaceCurrent(doc, &archived); err != nil {
+		s.releaseObject(doc.FilePath, doc.ContentHash)
+		if errors.Is(err, repositories.ErrStaleDocumentVersion) {
+			return nil, ErrDocumentVersionConflict
+		}
+		return nil, err
+	}
+	s.thumbnails.Enqueue(doc.ContentHash, doc.MimeType)
+
+	return doc, nil
+}
+
+func (s *DocumentServiceImpl) ListDocuments(certID uuid.UUID) ([]models.CertificationDocument, error) {
+	if err := s.ensureCertification(certID); err != nil {
+		return nil, err
+	}
+	return s.repository.ListByCertification(certID)
+}
+
+// OpenDocument returns the document metadata and its content, recording the
+// access. The caller must close the reader.
+func (s *DocumentServiceImpl) OpenDocument(certID, docID uuid.UUID, access *AccessInfo) (*models.CertificationDocument, io.ReadCloser, error) {
+	doc, err := s.findDocument(certID, docID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.openVerified(doc.FilePath, doc.ContentHash)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return nil, nil, ErrDocumentNotFound
+		}
+		return nil, nil, err
+	}
+	if err := s.recordAccess(doc, doc.Version, models.DocumentAccessDownload, access); err != nil {
+		content.Close()
+		return nil, nil, err
+	}
+	return doc, content, nil
+}
+
+func (s *DocumentServiceImpl) DeleteDocument(certID, docID uuid.UUID) error {
+	doc, err := s.findDocument(certID, docID)
+	if err != nil {
+		return err
+	}
+
+	archived, err := s.repository.ListVersions(doc.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repository.Delete(doc.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrDocumentNotFound
+		}
+		return err
+	}
+
+	s.releaseObject(doc.FilePath, doc.ContentHash)
+	for _, v := range archived {
+		s.releaseObject(v.FilePath, v.ContentHash)
+	}
+	return nil
+}
+
+// ListVersions returns every version of a document, oldest first, ending
+// with the current one.
+func (s *DocumentServiceImpl) ListVersions(certID, docID uuid.UUID) ([]models.CertificationDocumentVersion, error) {
+	doc, err := s.findDocument(certID, docID)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := s.repository.ListVersions(doc.ID)
+	if err != nil {
+		return nil, err
+	}
+	return append(versions, doc.CurrentVersion()), nil
+}
+
+// OpenVersion returns a version's metadata and content, recording the
+// access. The caller must close the reader.
+func (s *DocumentServiceImpl) OpenVersion(certID, docID uuid.UUID, version int, access *AccessInfo) (*models.CertificationDocumentVersion, io.ReadCloser, error) {
+	v, err := s.findVersion(certID, docID, version)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.openVerified(v.FilePath, v.ContentHash)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return nil, nil, ErrDocumentVersionNotFound
+		}
+		return nil, nil, err
+	}
+	doc := &models.CertificationDocument{ID: docID, CertificationID: certID}
+	if err := s.recordAccess(doc, v.Version, models.DocumentAccessDownload, access); err != nil {
+		content.Close()
+		return nil, nil, err
+	}
+	return v, content, nil
+}
+
+// DeleteVersion removes an archived version. The current version can only
+// go away with the whole document.
+func (s *DocumentServiceImpl) DeleteVersion(certID, docID uuid.UUID, version int) error {
+	v, err := s.findVersion(certID, docID, version)
+	if err != nil {
+		return err
+	}
+	if v.Current {
+		return ErrCurrentDocumentVersion
+	}
+
+	if err := s.repository.DeleteVersion(v.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrDocumentVersionNotFound
+		}
+		return err
+	}
+
+	s.releaseObject(v.FilePath, v.ContentHash)
+	return nil
+}
+
+// validateUpload checks the upload against the limits and its content
+// against its extension, returning the detected MIME type.
+func (s *DocumentServiceImpl) validateUpload(input *UploadDocumentInput) (string, error) {
+	if err := s.limits.Check(input.FileName, input.Size); err != nil {
+		return "", translateStorageError(err)
+	}
+	mimeType, err := storage.Sniff(input.FileName, input.Content, input.Size)
+	if err != nil {
+		return "", translateStorageError(err)
+	}
+	return mimeType, nil
+}
+
+// ingest stores a validated upload for doc and sets its FilePath, FileSize
+// and ContentHash. The upload stays in quarantine until the scanner clears
+// it, so an unscanned file is never reachable through a document key. Files
+// are stored under their SHA-256, so identical uploads share one object.
+func (s *DocumentServiceImpl) ingest(doc *models.CertificationDocument, input *UploadDocumentInput) error {
+	quarantineKey := fmt.Sprintf("quarantine/%s%s", uuid.New(), storage.Ext(input.FileName))
+	hash := sha256.New()
+	size, err := s.store.Save(quarantineKey, io.TeeReader(input.Content, hash), s.limits.MaxSize)
+	if err != nil {
+		return translateStorageError(err)
+	}
+	defer s.removeObject(quarantineKey)
+	doc.FileSize = size
+	doc.ContentHash = hex.EncodeToString(hash.Sum(nil))
+
+	if err := s.scan(doc, quarantineKey); err != nil {
+		return err
+	}
+
+	doc.FilePath = storage.ContentKey(doc.ContentHash)
+	exists, err := s.store.Exists(doc.FilePath)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	return s.promote(quarantineKey, doc.FilePath)
+}
+
+// scan runs the stored upload through the scanner. Infected uploads are
+// recorded in the audit log and rejected.
+func (s *DocumentServiceImpl) scan(doc *models.CertificationDocument, key string) error {
+	content, err := s.store.Open(key)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	result, err := s.scanner.Scan(context.Background(), content)
+	if err != nil {
+		log.Printf("Failed to scan document %s: %v", doc.ID, err)
+		return ErrDocumentScanFailed
+	}
+	if !result.Infected {
+		return nil
+	}
+
+	entry := &models.AuditLog{
+		RecordTable: doc.TableName(),
+		RecordID:    doc.ID,
+		Action:      models.AuditActionVirusDetected,
+		NewValues: models.JSONMap{
+			"certificationId": doc.CertificationID,
+			"fileName":        doc.FileName,
+			"fileSize":        doc.FileSize,
+			"mimeType":        doc.MimeType,
+			"signature":       result.Signature,
+		},
+		UserID: doc.UploadedBy,
+	}
+	if err := s.auditRepo.Create(entry); err != nil {
+		log.Printf("Failed to audit infected document %s (%s): %v", doc.ID, result.Signature, err)
+	}
+	return ErrDocumentInfected
+}
+
+// promote copies a cleared upload from quarantine to its permanent key.
+func (s *DocumentServiceImpl) promote(from, to string) error {
+	content, err := s.store.Open(from)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	_, err = s.store.Save(to, content, s.limits.MaxSize)
+	return err
+}
+
+func (s *DocumentServiceImpl) ensureCertification(certID uuid.UUID) error {
+	if _, err := s.certRepo.FindByID(certID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrCertificationNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// findDocument loads a document and checks that it belongs to the
+// certification in the URL.
+func (s *DocumentServiceImpl) findDocument(certID, docID uuid.UUID) (*models.CertificationDocument, error) {
+	doc, err := s.repository.FindByID(docID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDocumentNotFound
+		}
+		return nil, err
+	}
+	if doc.CertificationID != certID {
+		return nil, ErrDocumentNotFound
+	}
+	return doc, nil
+}
+
+func (s *DocumentServiceImpl) findVersion(certID, docID uuid.UUID, version int) (*models.CertificationDocumentVersion, error) {
+	doc, err := s.findDocument(certID, docID)
+	if err != nil {
+		return nil, err
+	}
+	if version == doc.Version {
+		current := doc.CurrentVersion()
+		return &current, nil
+	}
+
+	v, err := s.repository.FindVersion(doc.ID, version)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDocumentVersionNotFound
+		}
+		return nil, err
+	}
+	return v, nil
+}
+
+// openVerified opens a stored file and checks it against its recorded hash.
+func (s *DocumentServiceImpl) openVerified(key, sha string) (io.ReadCloser, error) {
+	content, err := storage.OpenVerified(s.store, key, sha)
+	if errors.Is(err, storage.ErrChecksumMismatch) {
+		log.Printf("Integrity check failed for stored document %s", key)
+		return nil, ErrDocumentCorrupted
+	}
+	return content, err
+}
+
+// releaseObject deletes a stored file once no document or version refers to
+// it any more. Identical uploads share a file, so it may still be in use. An
+// upload of the same content racing with the removal can lose its file; the
+// storage verify job reports that as missing.
+func (s *DocumentServiceImpl) releaseObject(key, contentHash string) {
+	refs, err := s.repository.CountReferences(key)
+	if err != nil {
+		log.Printf("Failed to count references to stored document %s: %v", key, err)
+		return
+	}
+	if refs == 0 {
+		s.removeObject(key)
+		if contentHash != "" {
+			s.removeObject(thumbnail.Key(contentHash))
+		}
+	}
+}
+
+// removeObject deletes a stored file on a best-effort basis; an orphaned file
+// is preferable to failing a request whose database change already happened.
+func (s *DocumentServiceImpl) removeObject(key string) {
+	if err := s.store.Delete(key); err != nil {
+		log.Printf("Failed to remove stored document %s: %v", key, err)
+	}
+}
+
+func translateStorageError(err error) error {
+	switch {
+	case errors.Is(err, storage.ErrFileTooLarge):
+		return ErrDocumentTooLarge
+	case errors.Is(err, storage.ErrExtensionNotAllowed):
+		return ErrDocumentTypeNotAllowed
+	case errors.Is(err, storage.ErrEmptyFile):
+		return ErrDocumentEmpty
+	case errors.Is(err, storage.ErrUnrecognizedContent), errors.Is(err, storage.ErrContentMismatch):
+		return ErrDocumentContentInvalid
+	}
+	return err
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"log"
+	"time"
+
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+
+	"github.com/google/uuid"
+)
+
+// maxUserAgentLength matches the user_agent column.
+const maxUserAgentLength = 512
+
+// AccessInfo identifies who is reading a document and from where.
+type AccessInfo struct {
+	UserID    uuid.UUID
+	IPAddress string
+	UserAgent string
+	// Via is how the request was authorized; empty means a user session.
+	Via string
+}
+
+// recordAccess logs a read of a document before any content is handed out.
+// A read that cannot be logged is refused rather than left unaudited.
+func (s *DocumentServiceImpl) recordAccess(doc *models.CertificationDocument, version int, action string, access *AccessInfo) error {
+	via := access.Via
+	if via == "" {
+		via = models.DocumentAccessViaSession
+	}
+	userAgent := access.UserAgent
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+
+	entry := &models.DocumentAccessLog{
+		DocumentID:      doc.ID,
+		CertificationID: doc.CertificationID,
+		Version:         version,
+		UserID:          nullableID(access.UserID),
+		Action:          action,
+		Via:             via,
+		IPAddress:       access.IPAddress,
+		UserAgent:       userAgent,
+		AccessedAt:      time.Now(),
+	}
+	if err := s.accessRepo.Create(entry); err != nil {
+		log.Printf("Failed to record access to document %s: %v", doc.ID, err)
+		return ErrDocumentAccessNotLogged
+	}
+	return nil
+}
+
+// DocumentAccessService answers audit queries over the document access log.
+type DocumentAccessService interface {
+	ListDocumentAccess(docID uuid.UUID, req *PageRequest) (*DocumentAccessListResponse, error)
+	ListUserAccess(userID uuid.UUID, req *PageRequest) (*DocumentAccessListResponse, error)
+}
+
+type DocumentAccessServiceImpl struct {
+	repository repositories.DocumentAccessLogRepository
+}
+
+var _ DocumentAccessService = (*DocumentAccessServiceImpl)(nil)
+
+type DocumentAccessListResponse struct {
+	Items      []models.DocumentAccessLog `json:"items"`
+	Pagination Pagination                 `json:"pagination"`
+}
+
+func NewDocumentAccessService(repository repositories.DocumentAccessLogRepository) *DocumentAccessServiceImpl {
+	return &DocumentAccessServiceImpl{
+		repository: repository,
+	}
+}
+
+// ListDocumentAccess returns the reads of a document, most recent first.
+// Entries outlive the document, so a deleted document still has a history.
+func (s *DocumentAccessServiceImpl) ListDocumentAccess(docID uuid.UUID, req *PageRequest) (*DocumentAccessListResponse, error) {
+	return s.list(repositories.DocumentAccessFilter{DocumentID: &docID}, req)
+}
+
+// ListUserAccess returns the documents a user has read, most recent first,
+// including reads through links the user created.
+func (s *DocumentAccessServiceImpl) ListUserAccess(userID uuid.UUID, req *PageRequest) (*DocumentAccessListResponse, error) {
+	return s.list(repositories.DocumentAccessFilter{UserID: &userID}, req)
+}
+
+func (s *DocumentAccessServiceImpl) list(filter repositories.DocumentAccessFilter, req *PageRequest) (*DocumentAccessListResponse, error) {
+	filter.Pagination = req.toPagination()
+	entries, total, err := s.repository.List(filter)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []models.DocumentAccessLog{}
+	}
+	return &DocumentAccessListResponse{
+		Items:      entries,
+		Pagination: newPagination(filter.Pagination, total),
+	}, nil
+}
@@ -0,0 +1,138 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"certitrack/internal/config"
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+)
+
+func TestOpenDocument_RecordsAccess(t *testing.T) {
+	f := newDocumentFixture(t)
+	cert := addStoredCertification(t, f.certRepo)
+	doc, err := f.svc.UploadDocument(cert.ID, uploadInput("certificate.pdf", "%PDF-1.4 scan"), uuid.Nil)
+	require.NoError(t, err)
+
+	_, content, err := f.svc.OpenDocument(cert.ID, doc.ID, reader)
+	require.NoError(t, err)
+	require.NoError(t, content.Close())
+
+	entries := f.accessRepo.All()
+	require.Len(t, entries, 1)
+	entry := entries[0]
+	require.Equal(t, doc.ID, entry.DocumentID)
+	require.Equal(t, cert.ID, entry.CertificationID)
+	require.Equal(t, reader.UserID, *entry.UserID)
+	require.Equal(t, models.DocumentAccessDownload, entry.Action)
+	require.Equal(t, models.DocumentAccessViaSession, entry.Via)
+	require.Equal(t, "203.0.113.7", entry.IPAddress)
+	require.Equal(t, "test-agent", entry.UserAgent)
+}
+
+func TestOpenDocument_RefusedWhenAccessCannotBeLogged(t *testing.T) {
+	f := newDocumentFixture(t)
+	cert := addStoredCertification(t, f.certRepo)
+	doc, err := f.svc.UploadDocument(cert.ID, uploadInput("certificate.pdf", "%PDF-1.4 scan"), uuid.Nil)
+	require.NoError(t, err)
+	f.accessRepo.CreateErr = errors.New("database unavailable")
+
+	_, content, err := f.svc.OpenDocument(cert.ID, doc.ID, reader)
+	require.ErrorIs(t, err, services.ErrDocumentAccessNotLogged)
+	require.Nil(t, content)
+
+	_, _, err = f.svc.OpenVersion(cert.ID, doc.ID, 1, reader)
+	require.ErrorIs(t, err, services.ErrDocumentAccessNotLogged)
+}
+
+func TestDocumentLink_AccessLoggedAgainstCreator(t *testing.T) {
+	f := newLinkFixture(t)
+	link, err := f.links.CreateLink(f.doc.ID, &services.CreateDocumentLinkRequest{}, f.creator.ID, linkSession)
+	require.NoError(t, err)
+
+	_, content, err := f.links.OpenLink(f.doc.ID, linkQuery(t, link, f.doc.ID), visitor)
+	require.NoError(t, err)
+	require.NoError(t, content.Close())
+
+	entries := f.accessRepo.All()
+	require.Len(t, entries, 1)
+	require.Equal(t, f.creator.ID, *entries[0].UserID)
+	require.Equal(t, models.DocumentAccessViaLink, entries[0].Via)
+	require.Equal(t, "198.51.100.4", entries[0].IPAddress)
+}
+
+func TestDocumentLink_RevokedWhenCreatorDeactivated(t *testing.T) {
+	f := newLinkFixture(t)
+	link, err := f.links.CreateLink(f.doc.ID, &services.CreateDocumentLinkRequest{}, f.creator.ID, linkSession)
+	require.NoError(t, err)
+
+	f.creator.IsActive = false
+
+	_, _, err = f.links.OpenLink(f.doc.ID, linkQuery(t, link, f.doc.ID), visitor)
+	require.ErrorIs(t, err, services.ErrDocumentLinkRevoked)
+	require.Empty(t, f.accessRepo.All())
+}
+
+func TestDocumentLink_RefusedOnceSessionRevoked(t *testing.T) {
+	f := newLinkFixture(t)
+	link, err := f.links.CreateLink(f.doc.ID, &services.CreateDocumentLinkRequest{}, f.creator.ID, linkSession)
+	require.NoError(t, err)
+	query := linkQuery(t, link, f.doc.ID)
+
+	auth := services.NewAuthService(&config.Config{}, f.users, f.tokens)
+	_, err = auth.Logout(&services.LogoutRequest{AccessToken: creatorAccessToken})
+	require.NoError(t, err)
+
+	_, _, err = f.links.OpenLink(f.doc.ID, query, visitor)
+	require.ErrorIs(t, err, services.ErrDocumentLinkRevoked)
+	require.Empty(t, f.accessRepo.All())
+
+	// The session is signed, so it cannot be swapped for another one.
+	query.Set("sid", services.TokenSessionID("other-access-token"))
+	_, _, err = f.links.OpenLink(f.doc.ID, query, visitor)
+	require.ErrorIs(t, err, services.ErrDocumentLinkInvalid)
+}
+
+func TestDocumentAccessService_ListsByDocumentAndUser(t *testing.T) {
+	f := newDocumentFixture(t)
+	cert := addStoredCertification(t, f.certRepo)
+	first, err := f.svc.UploadDocument(cert.ID, uploadInput("first.pdf", "%PDF-1.4 one"), uuid.Nil)
+	require.NoError(t, err)
+	second, err := f.svc.UploadDocument(cert.ID, uploadInput("second.pdf", "%PDF-1.4 two"), uuid.Nil)
+	require.NoError(t, err)
+
+	other := &services.AccessInfo{UserID: uuid.New()}
+	for _, read := range []struct {
+		docID  uuid.UUID
+		access *services.AccessInfo
+	}{
+		{first.ID, reader},
+		{first.ID, other},
+		{second.ID, reader},
+	} {
+		_, content, err := f.svc.OpenDocument(cert.ID, read.docID, read.access)
+		require.NoError(t, err)
+		require.NoError(t, content.Close())
+	}
+
+	access := services.NewDocumentAccessService(f.accessRepo)
+
+	byDoc, err := access.ListDocumentAccess(first.ID, &services.PageRequest{})
+	require.NoError(t, err)
+	require.Len(t, byDoc.Items, 2)
+	require.Equal(t, int64(2), byDoc.Pagination.Total)
+
+	byUser, err := access.ListUserAccess(reader.UserID, &services.PageRequest{Page: 1, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, byUser.Items, 1)
+	require.Equal(t, int64(2), byUser.Pagination.Total)
+
+	none, err := access.ListUserAccess(uuid.New(), &services.PageRequest{})
+	require.NoError(t, err)
+	require.NotNil(t, none.Items)
+	require.Empty(t, none.Items)
+}
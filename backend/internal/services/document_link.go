@@ -0,0 +1,218 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"certitrack/internal/config"
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DocumentLinkService issues and redeems signed download links, which grant
+// access to a document's current version without an Authorization header.
+// A link carries the user who created it and the session of their access
+// token: reads through it are logged against that user, and it stops working
+// when the user is deactivated or the token is revoked.
+type DocumentLinkService interface {
+	CreateLink(docID uuid.UUID, req *CreateDocumentLinkRequest, actorID uuid.UUID, sessionID string) (*DocumentLink, error)
+	OpenLink(docID uuid.UUID, query url.Values, access *AccessInfo) (*models.CertificationDocument, io.ReadCloser, error)
+}
+
+type DocumentLinkServiceImpl struct {
+	repository repositories.CertificationDocumentRepository
+	documents  DocumentService
+	nonces     repositories.LinkNonceRepository
+	userRepo   repositories.UserRepository
+	tokenRepo  repositories.TokenRepository
+	key        []byte
+	baseURL    string
+	defaultTTL time.Duration
+	maxTTL     time.Duration
+}
+
+var _ DocumentLinkService = (*DocumentLinkServiceImpl)(nil)
+
+type CreateDocumentLinkRequest struct {
+	// ExpiresIn is the link lifetime in seconds; zero uses DOCUMENT_LINK_TTL.
+	ExpiresIn int  `json:"expiresIn" binding:"omitempty,min=1"`
+	SingleUse bool `json:"singleUse"`
+}
+
+type DocumentLink struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	SingleUse bool      `json:"singleUse"`
+}
+
+var (
+	ErrDocumentLinkTTLTooLong = errors.New("document link lifetime exceeds the maximum")
+	ErrDocumentLinkInvalid    = errors.New("document link is invalid")
+	ErrDocumentLinkExpired    = errors.New("document link has expired")
+	ErrDocumentLinkUsed       = errors.New("document link has already been used")
+	ErrDocumentLinkRevoked    = errors.New("document link creator or session is no longer active")
+)
+
+func NewDocumentLinkService(
+	repository repositories.CertificationDocumentRepository,
+	documents DocumentService,
+	nonces repositories.LinkNonceRepository,
+	userRepo repositories.UserRepository,
+	tokenRepo repositories.TokenRepository,
+	cfg *config.Config,
+) *DocumentLinkServiceImpl {
+	return &DocumentLinkServiceImpl{
+		repository: repository,
+		documents:  documents,
+		nonces:     nonces,
+		userRepo:   userRepo,
+		tokenRepo:  tokenRepo,
+		key:        linkSigningKey(cfg),
+		baseURL:    strings.TrimRight(cfg.App.APIURL, "/"),
+		defaultTTL: cfg.Links.DefaultTTL,
+		maxTTL:     cfg.Links.MaxTTL,
+	}
+}
+
+// linkSigningKey returns the dedicated secret, or a key derived from the JWT
+// secret so a link signature can never double as a token signature.
+func linkSigningKey(cfg *config.Config) []byte {
+	if cfg.Links.Secret != "" {
+		return []byte(cfg.Links.Secret)
+	}
+	mac := hmac.New(sha256.New, []byte(cfg.JWT.Secret))
+	mac.Write([]byte("certitrack document links"))
+	return mac.Sum(nil)
+}
+
+// CreateLink signs a URL for the document's content endpoint. sessionID is
+// the TokenSessionID of the access token the link was requested with.
+func (s *DocumentLinkServiceImpl) CreateLink(docID uuid.UUID, req *CreateDocumentLinkRequest, actorID uuid.UUID, sessionID string) (*DocumentLink, error) {
+	ttl := s.defaultTTL
+	if req.ExpiresIn > 0 {
+		ttl = time.Duration(req.ExpiresIn) * time.Second
+	}
+	if ttl > s.maxTTL {
+		return nil, ErrDocumentLinkTTLTooLong
+	}
+
+	if _, err := s.repository.FindByID(docID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDocumentNotFound
+		}
+		return nil, err
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(ttl).Truncate(time.Second)
+	query := url.Values{}
+	query.Set("by", actorID.String())
+	query.Set("sid", sessionID)
+	query.Set("expires", strconv.FormatInt(expiresAt.Unix(), 10))
+	query.Set("nonce", base64.RawURLEncoding.EncodeToString(nonce))
+	if req.SingleUse {
+		query.Set("once", "1")
+	}
+	query.Set("sig", s.sign(docID, query))
+
+	return &DocumentLink{
+		URL:       fmt.Sprintf("%s/api/v1/documents/%s/content?%s", s.baseURL, docID, query.Encode()),
+		ExpiresAt: expiresAt,
+		SingleUse: req.SingleUse,
+	}, nil
+}
+
+// OpenLink checks the link's signature, expiry, creator and session and
+// returns the current version of the document. The caller must close the
+// reader.
+func (s *DocumentLinkServiceImpl) OpenLink(docID uuid.UUID, query url.Values, access *AccessInfo) (*models.CertificationDocument, io.ReadCloser, error) {
+	sig, err := base64.RawURLEncoding.DecodeString(query.Get("sig"))
+	if err != nil || !hmac.Equal(sig, s.rawSignature(docID, query)) {
+		return nil, nil, ErrDocumentLinkInvalid
+	}
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil {
+		return nil, nil, ErrDocumentLinkInvalid
+	}
+	expiresAt := time.Unix(expires, 0)
+	if !time.Now().Before(expiresAt) {
+		return nil, nil, ErrDocumentLinkExpired
+	}
+	creatorID, err := uuid.Parse(query.Get("by"))
+	if err != nil {
+		return nil, nil, ErrDocumentLinkInvalid
+	}
+	// Like the auth middleware, refuse whenever the creator cannot be
+	// confirmed as an active user.
+	if _, err := s.userRepo.FindActiveByID(creatorID.String()); err != nil {
+		return nil, nil, ErrDocumentLinkRevoked
+	}
+	sessionID := query.Get("sid")
+	if sessionID == "" {
+		return nil, nil, ErrDocumentLinkInvalid
+	}
+	revoked, err := s.tokenRepo.IsSessionRevoked(sessionID)
+	if err != nil {
+		log.Printf("Failed to check session of document link for %s: %v", docID, err)
+		return nil, nil, err
+	}
+	if revoked {
+		return nil, nil, ErrDocumentLinkRevoked
+	}
+
+	doc, err := s.repository.FindByID(docID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrDocumentNotFound
+		}
+		return nil, nil, err
+	}
+	// Spend a single-use link before opening, so the access log never shows
+	// a read that was then refused.
+	if query.Get("once") == "1" {
+		first, err := s.nonces.Claim(query.Get("nonce"), time.Until(expiresAt))
+		if err != nil {
+			log.Printf("Failed to record use of document link for %s: %v", docID, err)
+			return nil, nil, err
+		}
+		if !first {
+			return nil, nil, ErrDocumentLinkUsed
+		}
+	}
+
+	return s.documents.OpenDocument(doc.CertificationID, docID, &AccessInfo{
+		UserID:    creatorID,
+		IPAddress: access.IPAddress,
+		UserAgent: access.UserAgent,
+		Via:       models.DocumentAccessViaLink,
+	})
+}
+
+func (s *DocumentLinkServiceImpl) sign(docID uuid.UUID, query url.Values) string {
+	return base64.RawURLEncoding.EncodeToString(s.rawSignature(docID, query))
+}
+
+// rawSignature covers the document and every link parameter, so none of
+// them can be altered without invalidating the link.
+func (s *DocumentLinkServiceImpl) rawSignature(docID uuid.UUID, query url.Values) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%s\n%s\n%s", docID, query.Get("by"), query.Get("sid"), query.Get("expires"), query.Get("nonce"), query.Get("once"))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,163 @@
+package services_test
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"certitrack/internal/config"
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+	"certitrack/internal/services"
+)
+
+type linkFixture struct {
+	*documentFixture
+	links   services.DocumentLinkService
+	nonces  *repositories.MockLinkNonceRepository
+	users   *repositories.MockUserRepository
+	tokens  *repositories.MockTokenRepository
+	creator *models.User
+	doc     *models.CertificationDocument
+}
+
+func newLinkFixture(t *testing.T) *linkFixture {
+	t.Helper()
+	f := &linkFixture{
+		documentFixture: newDocumentFixture(t),
+		nonces:          repositories.NewMockLinkNonceRepository(),
+		users:           repositories.NewMockUserRepository(),
+		tokens:          repositories.NewMockTokenRepository(),
+		creator:         &models.User{IsActive: true},
+	}
+	require.NoError(t, f.users.CreateUser(f.creator))
+	cfg := &config.Config{
+		App:   config.AppConfig{APIURL: "https://api.example.com/"},
+		JWT:   config.JWTConfig{Secret: "test-jwt-secret-key-minimum-32-characters"},
+		Links: config.DocumentLinkConfig{DefaultTTL: time.Minute, MaxTTL: time.Hour},
+	}
+	f.links = services.NewDocumentLinkService(f.docRepo, f.svc, f.nonces, f.users, f.tokens, cfg)
+
+	cert := addStoredCertification(t, f.certRepo)
+	doc, err := f.svc.UploadDocument(cert.ID, uploadInput("certificate.pdf", "%PDF-1.4 scan"), uuid.Nil)
+	require.NoError(t, err)
+	f.doc = doc
+	return f
+}
+
+// creatorAccessToken stands in for the access token links are created with.
+const creatorAccessToken = "creator-access-token"
+
+var linkSession = services.TokenSessionID(creatorAccessToken)
+
+// visitor is an unauthenticated client following a link.
+var visitor = &services.AccessInfo{IPAddress: "198.51.100.4", UserAgent: "mail-client"}
+
+// linkQuery returns the query string of a generated link, checking that it
+// points at the document's content endpoint.
+func linkQuery(t *testing.T, link *services.DocumentLink, docID uuid.UUID) url.Values {
+	t.Helper()
+	u, err := url.Parse(link.URL)
+	require.NoError(t, err)
+	require.Equal(t, "https://api.example.com/api/v1/documents/"+docID.String()+"/content", u.Scheme+"://"+u.Host+u.Path)
+	return u.Query()
+}
+
+func TestDocumentLink_OpensUntilExpiry(t *testing.T) {
+	f := newLinkFixture(t)
+
+	link, err := f.links.CreateLink(f.doc.ID, &services.CreateDocumentLinkRequest{}, f.creator.ID, linkSession)
+	require.NoError(t, err)
+	require.WithinDuration(t, time.Now().Add(time.Minute), link.ExpiresAt, 2*time.Second)
+	query := linkQuery(t, link, f.doc.ID)
+
+	for i := 0; i < 2; i++ {
+		doc, content, err := f.links.OpenLink(f.doc.ID, query, visitor)
+		require.NoError(t, err)
+		require.NoError(t, content.Close())
+		require.Equal(t, f.doc.ID, doc.ID)
+	}
+}
+
+func TestDocumentLink_Expired(t *testing.T) {
+	f := newLinkFixture(t)
+	link, err := f.links.CreateLink(f.doc.ID, &services.CreateDocumentLinkRequest{ExpiresIn: 1}, f.creator.ID, linkSession)
+	require.NoError(t, err)
+	query := linkQuery(t, link, f.doc.ID)
+
+	time.Sleep(time.Until(link.ExpiresAt))
+
+	_, _, err = f.links.OpenLink(f.doc.ID, query, visitor)
+	require.ErrorIs(t, err, services.ErrDocumentLinkExpired)
+}
+
+func TestDocumentLink_RejectsTampering(t *testing.T) {
+	f := newLinkFixture(t)
+	link, err := f.links.CreateLink(f.doc.ID, &services.CreateDocumentLinkRequest{SingleUse: true}, f.creator.ID, linkSession)
+	require.NoError(t, err)
+	query := linkQuery(t, link, f.doc.ID)
+
+	extended := url.Values{}
+	for k, v := range query {
+		extended[k] = v
+	}
+	extended.Set("expires", "9999999999")
+	_, _, err = f.links.OpenLink(f.doc.ID, extended, visitor)
+	require.ErrorIs(t, err, services.ErrDocumentLinkInvalid)
+
+	reusable := url.Values{}
+	for k, v := range query {
+		reusable[k] = v
+	}
+	reusable.Del("once")
+	_, _, err = f.links.OpenLink(f.doc.ID, reusable, visitor)
+	require.ErrorIs(t, err, services.ErrDocumentLinkInvalid)
+
+	_, _, err = f.links.OpenLink(uuid.New(), query, visitor)
+	require.ErrorIs(t, err, services.ErrDocumentLinkInvalid)
+
+	_, _, err = f.links.OpenLink(f.doc.ID, url.Values{}, visitor)
+	require.ErrorIs(t, err, services.ErrDocumentLinkInvalid)
+}
+
+func TestDocumentLink_SingleUse(t *testing.T) {
+	f := newLinkFixture(t)
+	link, err := f.links.CreateLink(f.doc.ID, &services.CreateDocumentLinkRequest{SingleUse: true}, f.creator.ID, linkSession)
+	require.NoError(t, err)
+	require.True(t, link.SingleUse)
+	query := linkQuery(t, link, f.doc.ID)
+
+	_, content, err := f.links.OpenLink(f.doc.ID, query, visitor)
+	require.NoError(t, err)
+	require.NoError(t, content.Close())
+
+	_, _, err = f.links.OpenLink(f.doc.ID, query, visitor)
+	require.ErrorIs(t, err, services.ErrDocumentLinkUsed)
+}
+
+func TestDocumentLink_CreateValidation(t *testing.T) {
+	f := newLinkFixture(t)
+
+	_, err := f.links.CreateLink(f.doc.ID, &services.CreateDocumentLinkRequest{ExpiresIn: 2 * 60 * 60}, f.creator.ID, linkSession)
+	require.ErrorIs(t, err, services.ErrDocumentLinkTTLTooLong)
+
+	_, err = f.links.CreateLink(uuid.New(), &services.CreateDocumentLinkRequest{}, f.creator.ID, linkSession)
+	require.ErrorIs(t, err, services.ErrDocumentNotFound)
+}
+
+func TestDocumentLink_SignedWithDedicatedSecret(t *testing.T) {
+	f := newLinkFixture(t)
+	link, err := f.links.CreateLink(f.doc.ID, &services.CreateDocumentLinkRequest{}, f.creator.ID, linkSession)
+	require.NoError(t, err)
+
+	other := services.NewDocumentLinkService(f.docRepo, f.svc, f.nonces, f.users, f.tokens, &config.Config{
+		JWT:   config.JWTConfig{Secret: "test-jwt-secret-key-minimum-32-characters"},
+		Links: config.DocumentLinkConfig{Secret: strings.Repeat("s", 32), DefaultTTL: time.Minute, MaxTTL: time.Hour},
+	})
+	_, _, err = other.OpenLink(f.doc.ID, linkQuery(t, link, f.doc.ID), visitor)
+	require.ErrorIs(t, err, services.ErrDocumentLinkInvalid)
+}
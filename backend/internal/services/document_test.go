@@ -0,0 +1,407 @@
+package services_test
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"certitrack/internal/config"
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+	"certitrack/internal/scanner"
+	"certitrack/internal/services"
+	"certitrack/internal/storage"
+)
+
+// fakeScanner flags any content containing "EICAR" as infected.
+type fakeScanner struct {
+	err     error
+	scanned int
+}
+
+func (f *fakeScanner) Scan(ctx context.Context, r io.Reader) (*scanner.Result, error) {
+	f.scanned++
+	if f.err != nil {
+		return nil, f.err
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if strings.Contains(string(data), "EICAR") {
+		return &scanner.Result{Infected: true, Signature: "Eicar-Signature"}, nil
+	}
+	return &scanner.Result{}, nil
+}
+
+// recordingQueue remembers thumbnail requests instead of processing them.
+type recordingQueue struct {
+	hashes []string
+}
+
+func (q *recordingQueue) Enqueue(contentHash, mimeType string) {
+	q.hashes = append(q.hashes, contentHash)
+}
+
+// reader is the session the tests read documents as.
+var reader = &services.AccessInfo{UserID: uuid.New(), IPAddress: "203.0.113.7", UserAgent: "test-agent"}
+
+type documentFixture struct {
+	svc        services.DocumentService
+	docRepo    *repositories.MockCertificationDocumentRepository
+	certRepo   *repositories.MockCertificationRepository
+	auditRepo  *repositories.MockAuditLogRepository
+	accessRepo *repositories.MockDocumentAccessLogRepository
+	scanner    *fakeScanner
+	thumbnails *recordingQueue
+	store      storage.BlobStore
+	root       string
+}
+
+func newDocumentFixture(t *testing.T) *documentFixture {
+	t.Helper()
+	cfg := &config.Config{Storage: config.StorageConfig{
+		Root:        t.TempDir(),
+		MaxSizeMB:   1,
+		AllowedExts: []string{".pdf", ".png"},
+	}}
+	store, err := storage.NewLocalStore(cfg)
+	require.NoError(t, err)
+
+	f := &documentFixture{
+		docRepo:    repositories.NewMockCertificationDocumentRepository(),
+		certRepo:   repositories.NewMockCertificationRepository(),
+		auditRepo:  repositories.NewMockAuditLogRepository(),
+		accessRepo: repositories.NewMockDocumentAccessLogRepository(),
+		scanner:    &fakeScanner{},
+		thumbnails: &recordingQueue{},
+		store:      store,
+		root:       cfg.Storage.Root,
+	}
+	f.svc = services.NewDocumentService(f.docRepo, f.certRepo, f.auditRepo, f.accessRepo, store, storage.NewLimits(cfg), f.scanner, f.thumbnails)
+	return f
+}
+
+func addStoredCertification(t *testing.T, repo *repositories.MockCertificationRepository) *models.Certification {
+	t.Helper()
+	cert := &models.Certification{Status: models.CertificationStatusActive}
+	require.NoError(t, repo.Create(cert))
+	return cert
+}
+
+func uploadInput(name, content string) *services.UploadDocumentInput {
+	return &services.UploadDocumentInput{
+		FileName: name,
+		Size:     int64(len(content)),
+		Content:  strings.NewReader(content),
+	}
+}
+
+func TestUploadDocument_StoresAndDownloads(t *testing.T) {
+	f := newDocumentFixture(t)
+	cert := addStoredCertification(t, f.certRepo)
+	actorID := uuid.New()
+
+	doc, err := f.svc.UploadDocument(cert.ID, uploadInput("certificate.pdf", "%PDF-1.4 scan"), actorID)
+	require.NoError(t, err)
+	require.Equal(t, "certificate.pdf", doc.FileName)
+	require.Equal(t, "application/pdf", doc.MimeType)
+	require.Equal(t, int64(13), doc.FileSize)
+	require.Equal(t, actorID, *doc.UploadedBy)
+
+	stored, content, err := f.svc.OpenDocument(cert.ID, doc.ID, reader)
+	require.NoError(t, err)
+	defer content.Close()
+	data, err := io.ReadAll(content)
+	require.NoError(t, err)
+	require.Equal(t, "%PDF-1.4 scan", string(data))
+	require.Equal(t, doc.ID, stored.ID)
+}
+
+func TestUploadDocument_EnforcesLimits(t *testing.T) {
+	f := newDocumentFixture(t)
+	cert := addStoredCertification(t, f.certRepo)
+
+	_, err := f.svc.UploadDocument(cert.ID, uploadInput("payload.exe", "MZ"), uuid.Nil)
+	require.ErrorIs(t, err, services.ErrDocumentTypeNotAllowed)
+
+	_, err = f.svc.UploadDocument(cert.ID, &services.UploadDocumentInput{
+		FileName: "huge.pdf",
+		Size:     2 << 20,
+		Content:  strings.NewReader("x"),
+	}, uuid.Nil)
+	require.ErrorIs(t, err, services.ErrDocumentTooLarge)
+
+	// A declared size that understates the real content is caught while saving.
+	_, err = f.svc.UploadDocument(cert.ID, &services.UploadDocumentInput{
+		FileName: "lying.pdf",
+		Size:     10,
+		Content:  strings.NewReader("%PDF-" + strings.Repeat("x", 2<<20)),
+	}, uuid.Nil)
+	require.ErrorIs(t, err, services.ErrDocumentTooLarge)
+
+	docs, err := f.svc.ListDocuments(cert.ID)
+	require.NoError(t, err)
+	require.Empty(t, docs)
+}
+
+func TestUploadDocument_RejectsMismatchedContent(t *testing.T) {
+	f := newDocumentFixture(t)
+	cert := addStoredCertification(t, f.certRepo)
+
+	_, err := f.svc.UploadDocument(cert.ID, uploadInput("invoice.pdf", "MZ\x90\x00 renamed executable"), uuid.Nil)
+	require.ErrorIs(t, err, services.ErrDocumentContentInvalid)
+
+	_, err = f.svc.UploadDocument(cert.ID, uploadInput("scan.pdf", "\x89PNG\r\n\x1a\n image"), uuid.Nil)
+	require.ErrorIs(t, err, services.ErrDocumentContentInvalid)
+
+	doc, err := f.svc.UploadDocument(cert.ID, uploadInput("scan.png", "\x89PNG\r\n\x1a\n image"), uuid.Nil)
+	require.NoError(t, err)
+	require.Equal(t, "image/png", doc.MimeType)
+}
+
+func TestUploadDocument_UnknownCertification(t *testing.T) {
+	f := newDocumentFixture(t)
+
+	_, err := f.svc.UploadDocument(uuid.New(), uploadInput("certificate.pdf", "%PDF-1.4"), uuid.Nil)
+	require.ErrorIs(t, err, services.ErrCertificationNotFound)
+}
+
+func TestDeleteDocument_ChecksOwnershipAndRemovesFile(t *testing.T) {
+	f := newDocumentFixture(t)
+	cert := addStoredCertification(t, f.certRepo)
+	other := addStoredCertification(t, f.certRepo)
+	doc, err := f.svc.UploadDocument(cert.ID, uploadInput("certificate.pdf", "%PDF-1.4"), uuid.Nil)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, f.svc.DeleteDocument(other.ID, doc.ID), services.ErrDocumentNotFound)
+
+	require.NoError(t, f.svc.DeleteDocument(cert.ID, doc.ID))
+	_, _, err = f.svc.OpenDocument(cert.ID, doc.ID, reader)
+	require.ErrorIs(t, err, services.ErrDocumentNotFound)
+}
+
+func TestUploadDocument_RejectsInfectedFileAndAudits(t *testing.T) {
+	f := newDocumentFixture(t)
+	cert := addStoredCertification(t, f.certRepo)
+	actorID := uuid.New()
+
+	_, err := f.svc.UploadDocument(cert.ID, uploadInput("certificate.pdf", "%PDF-1.4 EICAR"), actorID)
+	require.ErrorIs(t, err, services.ErrDocumentInfected)
+	require.Equal(t, 1, f.scanner.scanned)
+
+	docs, err := f.svc.ListDocuments(cert.ID)
+	require.NoError(t, err)
+	require.Empty(t, docs)
+	requireNoStoredFiles(t, f.root)
+
+	entries := f.auditRepo.All()
+	require.Len(t, entries, 1)
+	require.Equal(t, models.AuditActionVirusDetected, entries[0].Action)
+	require.Equal(t, "certification_documents", entries[0].RecordTable)
+	require.Equal(t, actorID, *entries[0].UserID)
+	require.Equal(t, "Eicar-Signature", entries[0].NewValues["signature"])
+	require.Equal(t, "certificate.pdf", entries[0].NewValues["fileName"])
+}
+
+func TestUploadDocument_ScannerUnavailable(t *testing.T) {
+	f := newDocumentFixture(t)
+	cert := addStoredCertification(t, f.certRepo)
+	f.scanner.err = scanner.ErrScanFailed
+
+	_, err := f.svc.UploadDocument(cert.ID, uploadInput("certificate.pdf", "%PDF-1.4"), uuid.Nil)
+	require.ErrorIs(t, err, services.ErrDocumentScanFailed)
+	requireNoStoredFiles(t, f.root)
+	require.Empty(t, f.auditRepo.All())
+}
+
+func TestUploadDocument_ClearsQuarantine(t *testing.T) {
+	f := newDocumentFixture(t)
+	cert := addStoredCertification(t, f.certRepo)
+
+	_, err := f.svc.UploadDocument(cert.ID, uploadInput("certificate.pdf", "%PDF-1.4"), uuid.Nil)
+	require.NoError(t, err)
+
+	entries, err := os.ReadDir(filepath.Join(f.root, "quarantine"))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func requireNoStoredFiles(t *testing.T, root string) {
+	t.Helper()
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			t.Errorf("unexpected stored file %s", path)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func readVersion(t *testing.T, svc services.DocumentService, certID, docID uuid.UUID, version int) string {
+	t.Helper()
+	_, content, err := svc.OpenVersion(certID, docID, version, reader)
+	require.NoError(t, err)
+	defer content.Close()
+	data, err := io.ReadAll(content)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestReplaceDocument_KeepsPreviousVersions(t *testing.T) {
+	f := newDocumentFixture(t)
+	cert := addStoredCertification(t, f.certRepo)
+	doc, err := f.svc.UploadDocument(cert.ID, uploadInput("scan.pdf", "%PDF-1.4 first"), uuid.Nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, doc.Version)
+
+	replacer := uuid.New()
+	replaced, err := f.svc.ReplaceDocument(cert.ID, doc.ID, uploadInput("rescan.png", "\x89PNG\r\n\x1a\n second"), replacer)
+	require.NoError(t, err)
+	require.Equal(t, 2, replaced.Version)
+	require.Equal(t, "rescan.png", replaced.FileName)
+	require.Equal(t, "image/png", replaced.MimeType)
+	require.Equal(t, replacer, *replaced.UploadedBy)
+
+	versions, err := f.svc.ListVersions(cert.ID, doc.ID)
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	require.Equal(t, 1, versions[0].Version)
+	require.Equal(t, "scan.pdf", versions[0].FileName)
+	require.False(t, versions[0].Current)
+	require.Equal(t, 2, versions[1].Version)
+	require.True(t, versions[1].Current)
+
+	require.Equal(t, "%PDF-1.4 first", readVersion(t, f.svc, cert.ID, doc.ID, 1))
+	require.Equal(t, "\x89PNG\r\n\x1a\n second", readVersion(t, f.svc, cert.ID, doc.ID, 2))
+
+	_, current, err := f.svc.OpenDocument(cert.ID, doc.ID, reader)
+	require.NoError(t, err)
+	data, err := io.ReadAll(current)
+	require.NoError(t, err)
+	current.Close()
+	require.Equal(t, "\x89PNG\r\n\x1a\n second", string(data))
+}
+
+func TestReplaceDocument_RejectedUploadKeepsCurrentVersion(t *testing.T) {
+	f := newDocumentFixture(t)
+	cert := addStoredCertification(t, f.certRepo)
+	doc, err := f.svc.UploadDocument(cert.ID, uploadInput("scan.pdf", "%PDF-1.4 first"), uuid.Nil)
+	require.NoError(t, err)
+
+	_, err = f.svc.ReplaceDocument(cert.ID, doc.ID, uploadInput("scan.pdf", "%PDF-1.4 EICAR"), uuid.Nil)
+	require.ErrorIs(t, err, services.ErrDocumentInfected)
+
+	versions, err := f.svc.ListVersions(cert.ID, doc.ID)
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+	require.Equal(t, "%PDF-1.4 first", readVersion(t, f.svc, cert.ID, doc.ID, 1))
+}
+
+func TestDeleteVersion(t *testing.T) {
+	f := newDocumentFixture(t)
+	cert := addStoredCertification(t, f.certRepo)
+	doc, err := f.svc.UploadDocument(cert.ID, uploadInput("scan.pdf", "%PDF-1.4 first"), uuid.Nil)
+	require.NoError(t, err)
+	_, err = f.svc.ReplaceDocument(cert.ID, doc.ID, uploadInput("scan.pdf", "%PDF-1.4 second"), uuid.Nil)
+	require.NoError(t, err)
+
+	require.ErrorIs(t, f.svc.DeleteVersion(cert.ID, doc.ID, 2), services.ErrCurrentDocumentVersion)
+	require.ErrorIs(t, f.svc.DeleteVersion(cert.ID, doc.ID, 5), services.ErrDocumentVersionNotFound)
+
+	require.NoError(t, f.svc.DeleteVersion(cert.ID, doc.ID, 1))
+	_, _, err = f.svc.OpenVersion(cert.ID, doc.ID, 1, reader)
+	require.ErrorIs(t, err, services.ErrDocumentVersionNotFound)
+
+	versions, err := f.svc.ListVersions(cert.ID, doc.ID)
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+	require.Equal(t, 2, versions[0].Version)
+}
+
+func TestDeleteDocument_RemovesAllVersions(t *testing.T) {
+	f := newDocumentFixture(t)
+	cert := addStoredCertification(t, f.certRepo)
+	doc, err := f.svc.UploadDocument(cert.ID, uploadInput("scan.pdf", "%PDF-1.4 first"), uuid.Nil)
+	require.NoError(t, err)
+	_, err = f.svc.ReplaceDocument(cert.ID, doc.ID, uploadInput("scan.pdf", "%PDF-1.4 second"), uuid.Nil)
+	require.NoError(t, err)
+
+	require.NoError(t, f.svc.DeleteDocument(cert.ID, doc.ID))
+	requireNoStoredFiles(t, f.root)
+}
+
+func TestUploadDocument_DeduplicatesIdenticalContent(t *testing.T) {
+	f := newDocumentFixture(t)
+	cert := addStoredCertification(t, f.certRepo)
+	other := addStoredCertification(t, f.certRepo)
+
+	first, err := f.svc.UploadDocument(cert.ID, uploadInput("training.pdf", "%PDF-1.4 attendance"), uuid.Nil)
+	require.NoError(t, err)
+	second, err := f.svc.UploadDocument(other.ID, uploadInput("copy.pdf", "%PDF-1.4 attendance"), uuid.Nil)
+	require.NoError(t, err)
+
+	require.Len(t, first.ContentHash, 64)
+	require.Equal(t, first.ContentHash, second.ContentHash)
+	require.Equal(t, storage.ContentKey(first.ContentHash), first.FilePath)
+	require.Equal(t, first.FilePath, second.FilePath)
+
+	// The shared file survives until its last reference is deleted.
+	require.NoError(t, f.svc.DeleteDocument(cert.ID, first.ID))
+	_, content, err := f.svc.OpenDocument(other.ID, second.ID, reader)
+	require.NoError(t, err)
+	content.Close()
+
+	require.NoError(t, f.svc.DeleteDocument(other.ID, second.ID))
+	requireNoStoredFiles(t, f.root)
+}
+
+func TestOpenDocument_DetectsTampering(t *testing.T) {
+	f := newDocumentFixture(t)
+	cert := addStoredCertification(t, f.certRepo)
+	doc, err := f.svc.UploadDocument(cert.ID, uploadInput("certificate.pdf", "%PDF-1.4 original"), uuid.Nil)
+	require.NoError(t, err)
+
+	path := filepath.Join(f.root, filepath.FromSlash(doc.FilePath))
+	require.NoError(t, os.WriteFile(path, []byte("%PDF-1.4 altered!"), 0o600))
+
+	_, _, err = f.svc.OpenDocument(cert.ID, doc.ID, reader)
+	require.ErrorIs(t, err, services.ErrDocumentCorrupted)
+}
+
+func TestVerifyStorage_ReportsMissingAndCorruptedFiles(t *testing.T) {
+	f := newDocumentFixture(t)
+	cert := addStoredCertification(t, f.certRepo)
+	intact, err := f.svc.UploadDocument(cert.ID, uploadInput("a.pdf", "%PDF-1.4 intact"), uuid.Nil)
+	require.NoError(t, err)
+	_, err = f.svc.UploadDocument(cert.ID, uploadInput("b.pdf", "%PDF-1.4 intact"), uuid.Nil)
+	require.NoError(t, err)
+	corrupted, err := f.svc.UploadDocument(cert.ID, uploadInput("c.pdf", "%PDF-1.4 corrupted"), uuid.Nil)
+	require.NoError(t, err)
+	missing, err := f.svc.UploadDocument(cert.ID, uploadInput("d.pdf", "%PDF-1.4 missing"), uuid.Nil)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(f.root, filepath.FromSlash(corrupted.FilePath)), []byte("garbage"), 0o600))
+	require.NoError(t, os.Remove(filepath.Join(f.root, filepath.FromSlash(missing.FilePath))))
+
+	report, err := f.svc.VerifyStorage(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, report.Checked, "the shared file is checked once")
+	require.False(t, report.Healthy())
+
+	require.Len(t, report.Missing, 1)
+	require.Equal(t, []uuid.UUID{missing.ID}, report.Missing[0].DocumentIDs)
+	require.Len(t, report.Corrupted, 1)
+	require.Equal(t, corrupted.ContentHash, report.Corrupted[0].ContentHash)
+	require.Equal(t, []uuid.UUID{corrupted.ID}, report.Corrupted[0].DocumentIDs)
+	require.NotEqual(t, intact.FilePath, report.Corrupted[0].FilePath)
+}
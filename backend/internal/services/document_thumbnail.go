@@ -0,0 +1,74 @@
+package services
+
+import (
+	"errors"
+	"io"
+
+	"certitrack/internal/models"
+	"certitrack/internal/storage"
+	"certitrack/internal/thumbnail"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DocumentThumbnail is a preview image for a document's current version.
+type DocumentThumbnail struct {
+	Data        []byte
+	ContentType string
+	// ETag identifies the image; empty while the real thumbnail is pending.
+	ETag string
+	// Pending is set when a placeholder stands in for a thumbnail that is
+	// still being generated, so clients should not cache it.
+	Pending bool
+}
+
+// GetThumbnail returns the thumbnail of a document's current version. Image
+// documents whose thumbnail is missing are queued for generation and get a
+// placeholder in the meantime; other types always get a placeholder. Only
+// real thumbnails are recorded as an access, since placeholders show nothing
+// of the document.
+func (s *DocumentServiceImpl) GetThumbnail(docID uuid.UUID, access *AccessInfo) (*DocumentThumbnail, error) {
+	doc, err := s.repository.FindByID(docID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDocumentNotFound
+		}
+		return nil, err
+	}
+
+	if !thumbnail.Supports(doc.MimeType) || doc.ContentHash == "" {
+		return &DocumentThumbnail{
+			Data:        thumbnail.Placeholder(doc.MimeType),
+			ContentType: thumbnail.PlaceholderContentType,
+			ETag:        `"placeholder-` + doc.MimeType + `"`,
+		}, nil
+	}
+
+	r, err := s.store.Open(thumbnail.Key(doc.ContentHash))
+	if errors.Is(err, storage.ErrObjectNotFound) {
+		s.thumbnails.Enqueue(doc.ContentHash, doc.MimeType)
+		return &DocumentThumbnail{
+			Data:        thumbnail.Placeholder(doc.MimeType),
+			ContentType: thumbnail.PlaceholderContentType,
+			Pending:     true,
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.recordAccess(doc, doc.Version, models.DocumentAccessThumbnail, access); err != nil {
+		return nil, err
+	}
+	return &DocumentThumbnail{
+		Data:        data,
+		ContentType: thumbnail.ContentType,
+		ETag:        `"` + doc.ContentHash + `"`,
+	}, nil
+}
@@ -0,0 +1,81 @@
+package services_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"certitrack/internal/config"
+	"certitrack/internal/services"
+	"certitrack/internal/thumbnail"
+)
+
+func pngUpload(t *testing.T, name string) *services.UploadDocumentInput {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 120, 80))))
+	return &services.UploadDocumentInput{
+		FileName: name,
+		Size:     int64(buf.Len()),
+		Content:  bytes.NewReader(buf.Bytes()),
+	}
+}
+
+func TestGetThumbnail_ImageIsQueuedThenServed(t *testing.T) {
+	f := newDocumentFixture(t)
+	cert := addStoredCertification(t, f.certRepo)
+	doc, err := f.svc.UploadDocument(cert.ID, pngUpload(t, "badge.png"), uuid.Nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{doc.ContentHash}, f.thumbnails.hashes, "upload queues a thumbnail")
+
+	thumb, err := f.svc.GetThumbnail(doc.ID, reader)
+	require.NoError(t, err)
+	require.True(t, thumb.Pending)
+	require.Equal(t, thumbnail.PlaceholderContentType, thumb.ContentType)
+	require.Len(t, f.thumbnails.hashes, 2, "a missing thumbnail is queued again")
+
+	worker := thumbnail.NewWorker(f.store, &config.Config{Thumbnails: config.ThumbnailConfig{Size: 64}})
+	require.NoError(t, worker.Process(doc.ContentHash, doc.MimeType))
+
+	thumb, err = f.svc.GetThumbnail(doc.ID, reader)
+	require.NoError(t, err)
+	require.False(t, thumb.Pending)
+	require.Equal(t, thumbnail.ContentType, thumb.ContentType)
+	require.Equal(t, `"`+doc.ContentHash+`"`, thumb.ETag)
+}
+
+func TestGetThumbnail_PDFPlaceholder(t *testing.T) {
+	f := newDocumentFixture(t)
+	cert := addStoredCertification(t, f.certRepo)
+	doc, err := f.svc.UploadDocument(cert.ID, uploadInput("certificate.pdf", "%PDF-1.4"), uuid.Nil)
+	require.NoError(t, err)
+
+	thumb, err := f.svc.GetThumbnail(doc.ID, reader)
+	require.NoError(t, err)
+	require.False(t, thumb.Pending)
+	require.Equal(t, thumbnail.Placeholder("application/pdf"), thumb.Data)
+	require.NotEmpty(t, thumb.ETag)
+}
+
+func TestGetThumbnail_UnknownDocument(t *testing.T) {
+	f := newDocumentFixture(t)
+
+	_, err := f.svc.GetThumbnail(uuid.New(), reader)
+	require.ErrorIs(t, err, services.ErrDocumentNotFound)
+}
+
+func TestDeleteDocument_RemovesThumbnail(t *testing.T) {
+	f := newDocumentFixture(t)
+	cert := addStoredCertification(t, f.certRepo)
+	doc, err := f.svc.UploadDocument(cert.ID, pngUpload(t, "badge.png"), uuid.Nil)
+	require.NoError(t, err)
+	worker := thumbnail.NewWorker(f.store, &config.Config{Thumbnails: config.ThumbnailConfig{Size: 64}})
+	require.NoError(t, worker.Process(doc.ContentHash, doc.MimeType))
+
+	require.NoError(t, f.svc.DeleteDocument(cert.ID, doc.ID))
+	requireNoStoredFiles(t, f.root)
+}
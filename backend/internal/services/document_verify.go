@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"certitrack/internal/storage"
+
+	"github.com/google/uuid"
+)
+
+// StorageVerifyReport summarises a pass over every stored document file.
+type StorageVerifyReport struct {
+	Checked   int            `json:"checked"`
+	Unhashed  int            `json:"unhashed"` // stored before hashes were recorded; only checked for presence
+	Missing   []StorageIssue `json:"missing"`
+	Corrupted []StorageIssue `json:"corrupted"`
+}
+
+// StorageIssue is a stored file that is missing or no longer matches its
+// hash, with the documents that refer to it.
+type StorageIssue struct {
+	FilePath    string      `json:"filePath"`
+	ContentHash string      `json:"sha256,omitempty"`
+	DocumentIDs []uuid.UUID `json:"documentIds"`
+}
+
+// Healthy reports whether the pass found no missing or corrupted files.
+func (r *StorageVerifyReport) Healthy() bool {
+	return len(r.Missing) == 0 && len(r.Corrupted) == 0
+}
+
+// VerifyStorage re-reads every stored file and checks it against the hash
+// recorded on the documents that refer to it. Shared files are read once.
+func (s *DocumentServiceImpl) VerifyStorage(ctx context.Context) (*StorageVerifyReport, error) {
+	objects, err := s.repository.ListStoredObjects()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &StorageVerifyReport{
+		Missing:   []StorageIssue{},
+		Corrupted: []StorageIssue{},
+	}
+
+	// objects are ordered by file path, so references to the same file are
+	// adjacent.
+	for i := 0; i < len(objects); {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		issue := StorageIssue{FilePath: objects[i].FilePath, ContentHash: objects[i].ContentHash}
+		seen := map[uuid.UUID]bool{}
+		for ; i < len(objects) && objects[i].FilePath == issue.FilePath; i++ {
+			if !seen[objects[i].DocumentID] {
+				seen[objects[i].DocumentID] = true
+				issue.DocumentIDs = append(issue.DocumentIDs, objects[i].DocumentID)
+			}
+		}
+		report.Checked++
+
+		if issue.ContentHash == "" {
+			report.Unhashed++
+			exists, err := s.store.Exists(issue.FilePath)
+			if err != nil {
+				return report, err
+			}
+			if !exists {
+				report.Missing = append(report.Missing, issue)
+			}
+			continue
+		}
+
+		sum, err := storage.Checksum(s.store, issue.FilePath)
+		switch {
+		case errors.Is(err, storage.ErrObjectNotFound):
+			report.Missing = append(report.Missing, issue)
+		case errors.Is(err, storage.ErrChecksumMismatch):
+			// Encrypted objects fail authentication before a hash is produced.
+			report.Corrupted = append(report.Corrupted, issue)
+		case err != nil:
+			return report, err
+		case sum != issue.ContentHash:
+			report.Corrupted = append(report.Corrupted, issue)
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,292 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type EquipmentService interface {
+	CreateEquipment(req *CreateEquipmentRequest, actorID uuid.UUID) (*models.Equipment, error)
+	GetEquipment(id uuid.UUID) (*models.Equipment, error)
+	ListEquipment(req *ListEquipmentRequest) (*EquipmentListResponse, error)
+	UpdateEquipment(id uuid.UUID, req *UpdateEquipmentRequest, actorID uuid.UUID) (*models.Equipment, error)
+	DeactivateEquipment(id uuid.UUID, actorID uuid.UUID) error
+}
+
+type EquipmentServiceImpl struct {
+	repository repositories.EquipmentRepository
+	classRepo  repositories.EquipmentClassRepository
+	certRepo   repositories.CertificationRepository
+}
+
+var _ EquipmentService = (*EquipmentServiceImpl)(nil)
+
+type CreateEquipmentRequest struct {
+	AssetNumber  string     `json:"asset_number" binding:"required,max=100"`
+	Name         string     `json:"name" binding:"required,max=200"`
+	Description  string     `json:"description"`
+	Manufacturer string     `json:"manufacturer" binding:"omitempty,max=100"`
+	Model        string     `json:"model" binding:"omitempty,max=100"`
+	SerialNumber string     `json:"serial_number" binding:"omitempty,max=100"`
+	Location     string     `json:"location" binding:"omitempty,max=200"`
+	PurchaseDate string     `json:"purchase_date" binding:"omitempty,datetime=2006-01-02"`
+	ClassID      *uuid.UUID `json:"class_id"`
+}
+
+type UpdateEquipmentRequest struct {
+	AssetNumber  *string `json:"asset_number" binding:"omitempty,min=1,max=100"`
+	Name         *string `json:"name" binding:"omitempty,min=1,max=200"`
+	Description  *string `json:"description"`
+	Manufacturer *string `json:"manufacturer" binding:"omitempty,max=100"`
+	Model        *string `json:"model" binding:"omitempty,max=100"`
+	SerialNumber *string `json:"serial_number" binding:"omitempty,max=100"`
+	Location     *string `json:"location" binding:"omitempty,max=200"`
+	PurchaseDate *string `json:"purchase_date" binding:"omitempty,datetime=2006-01-02"`
+	// ClassID assigns the equipment to a class; an empty string removes it.
+	ClassID  *string `json:"class_id"`
+	IsActive *bool   `json:"is_active"`
+}
+
+type ListEquipmentRequest struct {
+	PageRequest
+	Search           string `form:"search"`
+	Location         string `form:"location"`
+	Manufacturer     string `form:"manufacturer"`
+	IsActive         *bool  `form:"isActive"`
+	ClassID          string `form:"classId" binding:"omitempty,uuid"`
+	ComplianceStatus string `form:"complianceStatus" binding:"omitempty,oneof=compliant at_risk non_compliant"`
+}
+
+type EquipmentListResponse struct {
+	Items      []models.Equipment `json:"items"`
+	Pagination Pagination         `json:"pagination"`
+}
+
+var (
+	ErrEquipmentNotFound    = errors.New("equipment not found")
+	ErrAssetNumberExists    = errors.New("equipment with this asset number already exists")
+	ErrInvalidEquipmentData = errors.New("invalid equipment data")
+)
+
+func NewEquipmentService(
+	repository repositories.EquipmentRepository,
+	classRepo repositories.EquipmentClassRepository,
+	certRepo repositories.CertificationRepository,
+) *EquipmentServiceImpl {
+	return &EquipmentServiceImpl{
+		repository: repository,
+		classRepo:  classRepo,
+		certRepo:   certRepo,
+	}
+}
+
+func (s *EquipmentServiceImpl) CreateEquipment(req *CreateEquipmentRequest, actorID uuid.UUID) (*models.Equipment, error) {
+	if s.repository.AssetNumberExists(req.AssetNumber, uuid.Nil) {
+		return nil, ErrAssetNumberExists
+	}
+
+	purchaseDate, err := parseDate(req.PurchaseDate)
+	if err != nil {
+		return nil, ErrInvalidEquipmentData
+	}
+	if req.ClassID != nil {
+		if err := s.ensureClassExists(*req.ClassID); err != nil {
+			return nil, err
+		}
+	}
+
+	equipment := models.Equipment{
+		AssetNumber:  req.AssetNumber,
+		Name:         req.Name,
+		Description:  req.Description,
+		Manufacturer: req.Manufacturer,
+		Model:        req.Model,
+		SerialNumber: req.SerialNumber,
+		Location:     req.Location,
+		PurchaseDate: purchaseDate,
+		ClassID:      req.ClassID,
+		IsActive:     true,
+		CreatedBy:    nullableID(actorID),
+		UpdatedBy:    nullableID(actorID),
+	}
+
+	if err := s.repository.Create(&equipment); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrAssetNumberExists
+		}
+		return nil, err
+	}
+
+	return s.GetEquipment(equipment.ID)
+}
+
+// GetEquipment returns the equipment with its compliance status.
+func (s *EquipmentServiceImpl) GetEquipment(id uuid.UUID) (*models.Equipment, error) {
+	equipment, err := s.find(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachCompliance([]*models.Equipment{equipment}); err != nil {
+		return nil, err
+	}
+	return equipment, nil
+}
+
+func (s *EquipmentServiceImpl) find(id uuid.UUID) (*models.Equipment, error) {
+	equipment, err := s.repository.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEquipmentNotFound
+		}
+		return nil, err
+	}
+	return equipment, nil
+}
+
+func (s *EquipmentServiceImpl) ListEquipment(req *ListEquipmentRequest) (*EquipmentListResponse, error) {
+	page := req.toPagination()
+	equipment, total, err := s.repository.List(repositories.EquipmentFilter{
+		Pagination:       page,
+		Search:           req.Search,
+		Location:         req.Location,
+		Manufacturer:     req.Manufacturer,
+		IsActive:         req.IsActive,
+		ClassID:          queryID(req.ClassID),
+		ComplianceStatus: req.ComplianceStatus,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*models.Equipment, len(equipment))
+	for i := range equipment {
+		items[i] = &equipment[i]
+	}
+	if err := s.attachCompliance(items); err != nil {
+		return nil, err
+	}
+
+	return &EquipmentListResponse{
+		Items:      equipment,
+		Pagination: newPagination(page, total),
+	}, nil
+}
+
+func (s *EquipmentServiceImpl) UpdateEquipment(id uuid.UUID, req *UpdateEquipmentRequest, actorID uuid.UUID) (*models.Equipment, error) {
+	equipment, err := s.find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.AssetNumber != nil && *req.AssetNumber != equipment.AssetNumber {
+		if s.repository.AssetNumberExists(*req.AssetNumber, equipment.ID) {
+			return nil, ErrAssetNumberExists
+		}
+		equipment.AssetNumber = *req.AssetNumber
+	}
+	if req.Name != nil {
+		equipment.Name = *req.Name
+	}
+	if req.Description != nil {
+		equipment.Description = *req.Description
+	}
+	if req.Manufacturer != nil {
+		equipment.Manufacturer = *req.Manufacturer
+	}
+	if req.Model != nil {
+		equipment.Model = *req.Model
+	}
+	if req.SerialNumber != nil {
+		equipment.SerialNumber = *req.SerialNumber
+	}
+	if req.Location != nil {
+		equipment.Location = *req.Location
+	}
+	if req.PurchaseDate != nil {
+		purchaseDate, err := parseDate(*req.PurchaseDate)
+		if err != nil {
+			return nil, ErrInvalidEquipmentData
+		}
+		equipment.PurchaseDate = purchaseDate
+	}
+	if req.ClassID != nil {
+		if *req.ClassID == "" {
+			equipment.ClassID = nil
+		} else {
+			classID, err := uuid.Parse(*req.ClassID)
+			if err != nil {
+				return nil, ErrInvalidEquipmentData
+			}
+			if err := s.ensureClassExists(classID); err != nil {
+				return nil, err
+			}
+			equipment.ClassID = &classID
+		}
+	}
+	if req.IsActive != nil {
+		equipment.IsActive = *req.IsActive
+	}
+	equipment.UpdatedBy = nullableID(actorID)
+
+	if err := s.repository.Update(equipment); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrAssetNumberExists
+		}
+		return nil, err
+	}
+
+	return s.GetEquipment(equipment.ID)
+}
+
+// DeactivateEquipment performs a soft delete, keeping the asset's
+// certification history available.
+func (s *EquipmentServiceImpl) DeactivateEquipment(id uuid.UUID, actorID uuid.UUID) error {
+	equipment, err := s.find(id)
+	if err != nil {
+		return err
+	}
+
+	equipment.IsActive = false
+	equipment.UpdatedBy = nullableID(actorID)
+
+	return s.repository.Update(equipment)
+}
+
+func (s *EquipmentServiceImpl) ensureClassExists(id uuid.UUID) error {
+	if _, err := s.classRepo.FindByID(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrEquipmentClassNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// attachCompliance evaluates each piece of equipment against its class,
+// loading the certifications of all of them at once.
+func (s *EquipmentServiceImpl) attachCompliance(equipment []*models.Equipment) error {
+	ids := make([]uuid.UUID, len(equipment))
+	for i, e := range equipment {
+		ids[i] = e.ID
+	}
+	certs, err := s.certRepo.FindForEquipment(ids)
+	if err != nil {
+		return err
+	}
+	byEquipment := make(map[uuid.UUID][]models.Certification)
+	for _, c := range certs {
+		byEquipment[*c.EquipmentID] = append(byEquipment[*c.EquipmentID], c)
+	}
+
+	now := time.Now()
+	for _, e := range equipment {
+		e.Compliance = e.Class.EvaluateCompliance(byEquipment[e.ID], now)
+	}
+	return nil
+}
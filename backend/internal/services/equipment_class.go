@@ -0,0 +1,211 @@
+package services
+
+import (
+	"errors"
+
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// EquipmentClassService manages equipment classes, the certification types
+// each class requires and those its operators must hold.
+type EquipmentClassService interface {
+	CreateClass(req *CreateEquipmentClassRequest, actorID uuid.UUID) (*models.EquipmentClass, error)
+	GetClass(id uuid.UUID) (*models.EquipmentClass, error)
+	ListClasses(req *PageRequest) (*EquipmentClassListResponse, error)
+	UpdateClass(id uuid.UUID, req *UpdateEquipmentClassRequest) (*models.EquipmentClass, error)
+	DeleteClass(id uuid.UUID) error
+}
+
+type EquipmentClassServiceImpl struct {
+	repository repositories.EquipmentClassRepository
+	typeRepo   repositories.CertificationTypeRepository
+}
+
+var _ EquipmentClassService = (*EquipmentClassServiceImpl)(nil)
+
+type CreateEquipmentClassRequest struct {
+	Name        string `json:"name" binding:"required,max=100"`
+	Description string `json:"description"`
+	// AtRiskDays defaults to models.DefaultAtRiskDays.
+	AtRiskDays      *int        `json:"at_risk_days" binding:"omitempty,min=0,max=365"`
+	RequiredTypeIDs []uuid.UUID `json:"required_type_ids"`
+	OperatorTypeIDs []uuid.UUID `json:"operator_type_ids"`
+}
+
+type UpdateEquipmentClassRequest struct {
+	Name        *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Description *string `json:"description"`
+	AtRiskDays  *int    `json:"at_risk_days" binding:"omitempty,min=0,max=365"`
+	// RequiredTypeIDs and OperatorTypeIDs replace the types when present.
+	RequiredTypeIDs *[]uuid.UUID `json:"required_type_ids"`
+	OperatorTypeIDs *[]uuid.UUID `json:"operator_type_ids"`
+}
+
+type EquipmentClassListResponse struct {
+	Items      []models.EquipmentClass `json:"items"`
+	Pagination Pagination              `json:"pagination"`
+}
+
+var (
+	ErrEquipmentClassNotFound = errors.New("equipment class not found")
+	ErrEquipmentClassExists   = errors.New("equipment class with this name already exists")
+	ErrEquipmentClassInUse    = errors.New("equipment class is assigned to equipment")
+)
+
+func NewEquipmentClassService(
+	repository repositories.EquipmentClassRepository,
+	typeRepo repositories.CertificationTypeRepository,
+) *EquipmentClassServiceImpl {
+	return &EquipmentClassServiceImpl{
+		repository: repository,
+		typeRepo:   typeRepo,
+	}
+}
+
+func (s *EquipmentClassServiceImpl) CreateClass(req *CreateEquipmentClassRequest, actorID uuid.UUID) (*models.EquipmentClass, error) {
+	requiredTypes, err := s.findTypes(req.RequiredTypeIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+	operatorTypes, err := s.findTypes(req.OperatorTypeIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	class := models.EquipmentClass{
+		Name:          req.Name,
+		Description:   req.Description,
+		AtRiskDays:    models.DefaultAtRiskDays,
+		RequiredTypes: requiredTypes,
+		OperatorTypes: operatorTypes,
+		CreatedBy:     nullableID(actorID),
+	}
+	if req.AtRiskDays != nil {
+		class.AtRiskDays = *req.AtRiskDays
+	}
+
+	if err := s.repository.Create(&class); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrEquipmentClassExists
+		}
+		return nil, err
+	}
+
+	return &class, nil
+}
+
+func (s *EquipmentClassServiceImpl) GetClass(id uuid.UUID) (*models.EquipmentClass, error) {
+	class, err := s.repository.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEquipmentClassNotFound
+		}
+		return nil, err
+	}
+	return class, nil
+}
+
+func (s *EquipmentClassServiceImpl) ListClasses(req *PageRequest) (*EquipmentClassListResponse, error) {
+	page := req.toPagination()
+	classes, total, err := s.repository.List(page)
+	if err != nil {
+		return nil, err
+	}
+	if classes == nil {
+		classes = []models.EquipmentClass{}
+	}
+
+	return &EquipmentClassListResponse{
+		Items:      classes,
+		Pagination: newPagination(page, total),
+	}, nil
+}
+
+func (s *EquipmentClassServiceImpl) UpdateClass(id uuid.UUID, req *UpdateEquipmentClassRequest) (*models.EquipmentClass, error) {
+	class, err := s.GetClass(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		class.Name = *req.Name
+	}
+	if req.Description != nil {
+		class.Description = *req.Description
+	}
+	if req.AtRiskDays != nil {
+		class.AtRiskDays = *req.AtRiskDays
+	}
+	if req.RequiredTypeIDs != nil {
+		requiredTypes, err := s.findTypes(*req.RequiredTypeIDs, class.RequiredTypes)
+		if err != nil {
+			return nil, err
+		}
+		class.RequiredTypes = requiredTypes
+	}
+	if req.OperatorTypeIDs != nil {
+		operatorTypes, err := s.findTypes(*req.OperatorTypeIDs, class.OperatorTypes)
+		if err != nil {
+			return nil, err
+		}
+		class.OperatorTypes = operatorTypes
+	}
+
+	if err := s.repository.Update(class); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrEquipmentClassExists
+		}
+		return nil, err
+	}
+
+	return class, nil
+}
+
+// DeleteClass removes a class no equipment is assigned to.
+func (s *EquipmentClassServiceImpl) DeleteClass(id uuid.UUID) error {
+	if err := s.repository.Delete(id); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return ErrEquipmentClassNotFound
+		case errors.Is(err, gorm.ErrForeignKeyViolated):
+			return ErrEquipmentClassInUse
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+// findTypes loads certification types by ID, ignoring duplicates. Only active
+// types can be newly added; types in current may stay after being deactivated.
+func (s *EquipmentClassServiceImpl) findTypes(ids []uuid.UUID, current []models.CertificationType) ([]models.CertificationType, error) {
+	types := []models.CertificationType{}
+	seen := make(map[uuid.UUID]bool)
+	kept := make(map[uuid.UUID]bool, len(current))
+	for _, t := range current {
+		kept[t.ID] = true
+	}
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		certType, err := s.typeRepo.FindByID(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrCertificationTypeNotFound
+			}
+			return nil, err
+		}
+		if !certType.IsActive && !kept[id] {
+			return nil, ErrInactiveCertificationType
+		}
+		types = append(types, *certType)
+	}
+	return types, nil
+}
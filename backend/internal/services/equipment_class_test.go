@@ -0,0 +1,211 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+	"certitrack/internal/services"
+)
+
+type equipmentClassFixture struct {
+	classes       services.EquipmentClassService
+	equipment     services.EquipmentService
+	authorization services.OperatorAuthorizationService
+	classRepo     *repositories.MockEquipmentClassRepository
+	typeRepo      *repositories.MockCertificationTypeRepository
+	certRepo      *repositories.MockCertificationRepository
+	personRepo    *repositories.MockPersonRepository
+}
+
+func newEquipmentClassFixture() *equipmentClassFixture {
+	f := &equipmentClassFixture{
+		typeRepo:   repositories.NewMockCertificationTypeRepository(),
+		personRepo: repositories.NewMockPersonRepository(),
+	}
+	f.certRepo = repositories.NewMockCertificationRepository().WithPeople(f.personRepo)
+	f.classRepo = repositories.NewMockEquipmentClassRepository(f.typeRepo)
+	repo := repositories.NewMockEquipmentRepository().WithCompliance(f.classRepo, f.certRepo)
+	f.classes = services.NewEquipmentClassService(f.classRepo, f.typeRepo)
+	f.equipment = services.NewEquipmentService(repo, f.classRepo, f.certRepo)
+	f.authorization = services.NewOperatorAuthorizationService(repo, f.classRepo, f.personRepo, f.certRepo)
+	return f
+}
+
+func (f *equipmentClassFixture) addType(t *testing.T, name string) *models.CertificationType {
+	t.Helper()
+	certType := &models.CertificationType{Name: name, Category: models.CertificationCategoryEquipment, IsActive: true}
+	require.NoError(t, f.typeRepo.Create(certType))
+	return certType
+}
+
+func (f *equipmentClassFixture) addClass(t *testing.T, name string, types ...*models.CertificationType) *models.EquipmentClass {
+	t.Helper()
+	ids := make([]uuid.UUID, 0, len(types))
+	for _, certType := range types {
+		ids = append(ids, certType.ID)
+	}
+	class, err := f.classes.CreateClass(&services.CreateEquipmentClassRequest{Name: name, RequiredTypeIDs: ids}, uuid.New())
+	require.NoError(t, err)
+	return class
+}
+
+func (f *equipmentClassFixture) addEquipment(t *testing.T, assetNumber string, class *models.EquipmentClass) *models.Equipment {
+	t.Helper()
+	req := &services.CreateEquipmentRequest{AssetNumber: assetNumber, Name: "Asset " + assetNumber}
+	if class != nil {
+		req.ClassID = &class.ID
+	}
+	equipment, err := f.equipment.CreateEquipment(req, uuid.New())
+	require.NoError(t, err)
+	return equipment
+}
+
+func (f *equipmentClassFixture) addCert(t *testing.T, equipment *models.Equipment, certType *models.CertificationType, status string, expiresInDays int) {
+	t.Helper()
+	expiration := time.Now().UTC().AddDate(0, 0, expiresInDays)
+	require.NoError(t, f.certRepo.Create(&models.Certification{
+		CertificationTypeID: certType.ID,
+		EquipmentID:         &equipment.ID,
+		IssueDate:           expiration.AddDate(-1, 0, 0),
+		ExpirationDate:      expiration,
+		Status:              status,
+	}))
+}
+
+func TestCreateEquipmentClass_DefaultsAtRiskDays(t *testing.T) {
+	f := newEquipmentClassFixture()
+	loadTest := f.addType(t, "Load Test")
+
+	class := f.addClass(t, "Cranes", loadTest, loadTest)
+	require.Equal(t, models.DefaultAtRiskDays, class.AtRiskDays)
+	require.Len(t, class.RequiredTypes, 1)
+}
+
+func TestCreateEquipmentClass_DuplicateName(t *testing.T) {
+	f := newEquipmentClassFixture()
+	f.addClass(t, "Cranes")
+
+	_, err := f.classes.CreateClass(&services.CreateEquipmentClassRequest{Name: "Cranes"}, uuid.New())
+	require.ErrorIs(t, err, services.ErrEquipmentClassExists)
+}
+
+func TestCreateEquipmentClass_RejectsUnknownAndInactiveTypes(t *testing.T) {
+	f := newEquipmentClassFixture()
+	inactive := &models.CertificationType{Name: "Retired", Category: models.CertificationCategoryEquipment}
+	require.NoError(t, f.typeRepo.Create(inactive))
+
+	_, err := f.classes.CreateClass(&services.CreateEquipmentClassRequest{Name: "A", RequiredTypeIDs: []uuid.UUID{uuid.New()}}, uuid.New())
+	require.ErrorIs(t, err, services.ErrCertificationTypeNotFound)
+
+	_, err = f.classes.CreateClass(&services.CreateEquipmentClassRequest{Name: "B", RequiredTypeIDs: []uuid.UUID{inactive.ID}}, uuid.New())
+	require.ErrorIs(t, err, services.ErrInactiveCertificationType)
+}
+
+func TestDeleteEquipmentClass_InUse(t *testing.T) {
+	f := newEquipmentClassFixture()
+	class := f.addClass(t, "Cranes")
+	f.classRepo.DeleteErr = gorm.ErrForeignKeyViolated
+
+	require.ErrorIs(t, f.classes.DeleteClass(class.ID), services.ErrEquipmentClassInUse)
+}
+
+func TestCreateEquipment_UnknownClass(t *testing.T) {
+	f := newEquipmentClassFixture()
+	missing := uuid.New()
+
+	_, err := f.equipment.CreateEquipment(&services.CreateEquipmentRequest{AssetNumber: "EQ-1", Name: "Crane", ClassID: &missing}, uuid.New())
+	require.ErrorIs(t, err, services.ErrEquipmentClassNotFound)
+}
+
+func TestGetEquipment_ComplianceStatus(t *testing.T) {
+	f := newEquipmentClassFixture()
+	loadTest := f.addType(t, "Load Test")
+	calibration := f.addType(t, "Calibration")
+	class := f.addClass(t, "Cranes", loadTest, calibration)
+
+	unclassified := f.addEquipment(t, "EQ-0", nil)
+	compliant := f.addEquipment(t, "EQ-1", class)
+	f.addCert(t, compliant, loadTest, models.CertificationStatusActive, 200)
+	f.addCert(t, compliant, calibration, models.CertificationStatusActive, 90)
+	atRisk := f.addEquipment(t, "EQ-2", class)
+	f.addCert(t, atRisk, loadTest, models.CertificationStatusActive, 200)
+	f.addCert(t, atRisk, calibration, models.CertificationStatusActive, 10)
+	nonCompliant := f.addEquipment(t, "EQ-3", class)
+	f.addCert(t, nonCompliant, loadTest, models.CertificationStatusActive, 10)
+	f.addCert(t, nonCompliant, calibration, models.CertificationStatusRevoked, 200)
+
+	cases := map[uuid.UUID]string{
+		unclassified.ID: models.EquipmentCompliant,
+		compliant.ID:    models.EquipmentCompliant,
+		atRisk.ID:       models.EquipmentAtRisk,
+		nonCompliant.ID: models.EquipmentNonCompliant,
+	}
+	for id, want := range cases {
+		equipment, err := f.equipment.GetEquipment(id)
+		require.NoError(t, err)
+		require.Equal(t, want, equipment.Compliance.Status, equipment.AssetNumber)
+	}
+}
+
+func TestGetEquipment_IgnoresInactiveRequiredTypes(t *testing.T) {
+	f := newEquipmentClassFixture()
+	pressureTest := f.addType(t, "Pressure Test")
+	class := f.addClass(t, "Vessels", pressureTest)
+	equipment := f.addEquipment(t, "EQ-1", class)
+	require.Equal(t, models.EquipmentNonCompliant, equipment.Compliance.Status)
+
+	pressureTest.IsActive = false
+	require.NoError(t, f.typeRepo.Update(pressureTest))
+
+	equipment, err := f.equipment.GetEquipment(equipment.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.EquipmentCompliant, equipment.Compliance.Status)
+	require.Empty(t, equipment.Compliance.Requirements)
+}
+
+func TestListEquipment_FiltersByComplianceStatus(t *testing.T) {
+	f := newEquipmentClassFixture()
+	calibration := f.addType(t, "Calibration")
+	class := f.addClass(t, "Gauges", calibration)
+	f.addEquipment(t, "EQ-1", nil)
+	atRisk := f.addEquipment(t, "EQ-2", class)
+	f.addCert(t, atRisk, calibration, models.CertificationStatusActive, 5)
+	f.addEquipment(t, "EQ-3", class)
+
+	resp, err := f.equipment.ListEquipment(&services.ListEquipmentRequest{ComplianceStatus: models.EquipmentAtRisk})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 1)
+	require.Equal(t, "EQ-2", resp.Items[0].AssetNumber)
+	require.Equal(t, models.EquipmentAtRisk, resp.Items[0].Compliance.Status)
+
+	resp, err = f.equipment.ListEquipment(&services.ListEquipmentRequest{ComplianceStatus: models.EquipmentCompliant})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 1)
+	require.Equal(t, "EQ-1", resp.Items[0].AssetNumber)
+
+	resp, err = f.equipment.ListEquipment(&services.ListEquipmentRequest{ClassID: class.ID.String()})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), resp.Pagination.Total)
+}
+
+func TestUpdateEquipment_ClassID(t *testing.T) {
+	f := newEquipmentClassFixture()
+	class := f.addClass(t, "Cranes", f.addType(t, "Load Test"))
+	equipment := f.addEquipment(t, "EQ-1", class)
+
+	invalid := "not-a-uuid"
+	_, err := f.equipment.UpdateEquipment(equipment.ID, &services.UpdateEquipmentRequest{ClassID: &invalid}, uuid.New())
+	require.ErrorIs(t, err, services.ErrInvalidEquipmentData)
+
+	clear := ""
+	updated, err := f.equipment.UpdateEquipment(equipment.ID, &services.UpdateEquipmentRequest{ClassID: &clear}, uuid.New())
+	require.NoError(t, err)
+	require.Nil(t, updated.ClassID)
+	require.Equal(t, models.EquipmentCompliant, updated.Compliance.Status)
+}
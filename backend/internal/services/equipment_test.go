@@ -0,0 +1,106 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"certitrack/internal/repositories"
+	"certitrack/internal/services"
+)
+
+func newEquipmentService() (services.EquipmentService, *repositories.MockEquipmentRepository) {
+	classRepo := repositories.NewMockEquipmentClassRepository(repositories.NewMockCertificationTypeRepository())
+	certRepo := repositories.NewMockCertificationRepository()
+	repo := repositories.NewMockEquipmentRepository().WithCompliance(classRepo, certRepo)
+	return services.NewEquipmentService(repo, classRepo, certRepo), repo
+}
+
+func TestCreateEquipment_Success(t *testing.T) {
+	svc, _ := newEquipmentService()
+	actorID := uuid.New()
+
+	equipment, err := svc.CreateEquipment(&services.CreateEquipmentRequest{
+		AssetNumber:  "EQ-001",
+		Name:         "Forklift #1",
+		Manufacturer: "Toyota",
+		PurchaseDate: "2022-01-15",
+	}, actorID)
+
+	require.NoError(t, err)
+	require.Equal(t, "EQ-001", equipment.AssetNumber)
+	require.True(t, equipment.IsActive)
+	require.Equal(t, actorID, *equipment.CreatedBy)
+	require.Equal(t, "2022-01-15", equipment.PurchaseDate.Format(services.DateLayout))
+}
+
+func TestCreateEquipment_DuplicateAssetNumber(t *testing.T) {
+	svc, _ := newEquipmentService()
+	req := &services.CreateEquipmentRequest{AssetNumber: "EQ-001", Name: "Forklift #1"}
+
+	_, err := svc.CreateEquipment(req, uuid.Nil)
+	require.NoError(t, err)
+
+	_, err = svc.CreateEquipment(req, uuid.Nil)
+	require.ErrorIs(t, err, services.ErrAssetNumberExists)
+}
+
+func TestCreateEquipment_UniqueViolationFromDatabase(t *testing.T) {
+	svc, repo := newEquipmentService()
+	repo.CreateErr = gorm.ErrDuplicatedKey
+
+	_, err := svc.CreateEquipment(&services.CreateEquipmentRequest{AssetNumber: "EQ-001", Name: "Crane"}, uuid.Nil)
+	require.ErrorIs(t, err, services.ErrAssetNumberExists)
+}
+
+func TestUpdateEquipment_AssetNumberTakenByOther(t *testing.T) {
+	svc, _ := newEquipmentService()
+	_, err := svc.CreateEquipment(&services.CreateEquipmentRequest{AssetNumber: "EQ-001", Name: "A"}, uuid.Nil)
+	require.NoError(t, err)
+	other, err := svc.CreateEquipment(&services.CreateEquipmentRequest{AssetNumber: "EQ-002", Name: "B"}, uuid.Nil)
+	require.NoError(t, err)
+
+	taken := "EQ-001"
+	_, err = svc.UpdateEquipment(other.ID, &services.UpdateEquipmentRequest{AssetNumber: &taken}, uuid.Nil)
+	require.ErrorIs(t, err, services.ErrAssetNumberExists)
+}
+
+func TestGetEquipment_NotFound(t *testing.T) {
+	svc, _ := newEquipmentService()
+
+	_, err := svc.GetEquipment(uuid.New())
+	require.ErrorIs(t, err, services.ErrEquipmentNotFound)
+}
+
+func TestDeactivateEquipment_SoftDeletes(t *testing.T) {
+	svc, repo := newEquipmentService()
+	equipment, err := svc.CreateEquipment(&services.CreateEquipmentRequest{AssetNumber: "EQ-001", Name: "A"}, uuid.Nil)
+	require.NoError(t, err)
+
+	require.NoError(t, svc.DeactivateEquipment(equipment.ID, uuid.New()))
+
+	stored, err := repo.FindByID(equipment.ID)
+	require.NoError(t, err)
+	require.False(t, stored.IsActive)
+}
+
+func TestListEquipment_FiltersByLocationAndManufacturer(t *testing.T) {
+	svc, _ := newEquipmentService()
+	assets := []services.CreateEquipmentRequest{
+		{AssetNumber: "EQ-1", Name: "Forklift", Manufacturer: "Toyota", Location: "Warehouse A"},
+		{AssetNumber: "EQ-2", Name: "Forklift", Manufacturer: "Linde", Location: "Warehouse A"},
+		{AssetNumber: "EQ-3", Name: "Crane", Manufacturer: "Toyota", Location: "Yard"},
+	}
+	for i := range assets {
+		_, err := svc.CreateEquipment(&assets[i], uuid.Nil)
+		require.NoError(t, err)
+	}
+
+	resp, err := svc.ListEquipment(&services.ListEquipmentRequest{Location: "Warehouse A", Manufacturer: "Toyota"})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 1)
+	require.Equal(t, "EQ-1", resp.Items[0].AssetNumber)
+	require.Equal(t, int64(1), resp.Pagination.Total)
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"log"
+	"slices"
+	"time"
+
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+
+	"github.com/google/uuid"
+)
+
+// maxReminderDays matches the largest days_before_expiration a rule accepts,
+// so certifications further out than this cannot have a reminder due.
+const maxReminderDays = 730
+
+// NotificationService schedules expiration reminders for certifications
+// into the outbox, which delivers them.
+type NotificationService interface {
+	ScheduleExpirationReminders(ctx context.Context, asOf time.Time) (*ReminderScheduleReport, error)
+}
+
+type NotificationServiceImpl struct {
+	repository repositories.NotificationRepository
+	certRepo   repositories.CertificationRepository
+	typeRepo   repositories.CertificationTypeRepository
+	userRepo   repositories.UserRepository
+	rules      NotificationRuleService
+}
+
+var _ NotificationService = (*NotificationServiceImpl)(nil)
+
+// ReminderScheduleReport summarizes one run of the expiration check.
+type ReminderScheduleReport struct {
+	// Checked is the number of active certifications close enough to their
+	// expiration date to have a reminder due.
+	Checked          int `json:"checked"`
+	Scheduled        int `json:"scheduled"`
+	AlreadyScheduled int `json:"alreadyScheduled"`
+	// NoRecipients counts due reminders that had nobody to go to. They are
+	// scheduled by a later run once a recipient has an email address.
+	NoRecipients int `json:"noRecipients"`
+}
+
+func NewNotificationService(
+	repository repositories.NotificationRepository,
+	certRepo repositories.CertificationRepository,
+	typeRepo repositories.CertificationTypeRepository,
+	userRepo repositories.UserRepository,
+	rules NotificationRuleService,
+) *NotificationServiceImpl {
+	return &NotificationServiceImpl{
+		repository: repository,
+		certRepo:   certRepo,
+		typeRepo:   typeRepo,
+		userRepo:   userRepo,
+		rules:      rules,
+	}
+}
+
+// ScheduleExpirationReminders checks every active certification against its
+// effective notification rules and schedules the reminder for the threshold
+// it has crossed. A reminder is scheduled at most once per certification,
+// rule and threshold, however often or wherever the check runs.
+//
+// Recipients who get the rule's reminders in digests, by the rule's setting
+// or their own, are left to the digest builder. A certification that
+// expires before next week's digest is reminded by email right away.
+func (s *NotificationServiceImpl) ScheduleExpirationReminders(ctx context.Context, asOf time.Time) (*ReminderScheduleReport, error) {
+	report := &ReminderScheduleReport{}
+
+	certs, err := s.certRepo.FindActiveExpiringBetween(asOf, asOf.AddDate(0, 0, maxReminderDays))
+	if err != nil {
+		return report, err
+	}
+	admins, err := activeAdminEmails(s.userRepo)
+	if err != nil {
+		return report, err
+	}
+	preferences, err := s.userRepo.FindDeliveryPreferences()
+	if err != nil {
+		return report, err
+	}
+	nextDigest := models.DigestPeriodStart(asOf).AddDate(0, 0, 7)
+
+	rulesByType := make(map[uuid.UUID][]models.NotificationRule)
+	for i := range certs {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		cert := &certs[i]
+		report.Checked++
+
+		rules, ok := rulesByType[cert.CertificationTypeID]
+		if !ok {
+			if rules, err = s.resolveRules(cert.CertificationTypeID); err != nil {
+				return report, err
+			}
+			rulesByType[cert.CertificationTypeID] = rules
+		}
+		rule, due := models.CrossedNotificationRule(rules, cert.DaysUntil(asOf))
+		if !due {
+			continue
+		}
+
+		recipients := reminderRecipients(cert, rule.RecipientRoles, admins)
+		if len(recipients) == 0 {
+			log.Printf("No recipients for the %d-day reminder of certification %s", rule.DaysBeforeExpiration, cert.ID)
+			report.NoRecipients++
+			continue
+		}
+
+		immediate, digest := recipients, models.StringList{}
+		if !cert.ExpirationDate.Before(nextDigest) {
+			immediate, digest = splitByDelivery(recipients, rule.Delivery, preferences)
+		}
+		status := models.NotificationStatusPending
+		if len(immediate) == 0 {
+			status = models.NotificationStatusDigest
+		}
+
+		scheduledFor := cert.ExpirationDate.AddDate(0, 0, -rule.DaysBeforeExpiration)
+		inserted, err := s.repository.Schedule(&models.Notification{
+			CertificationID:      cert.ID,
+			NotificationRuleID:   rule.ID,
+			DaysBeforeExpiration: rule.DaysBeforeExpiration,
+			EmailTemplate:        rule.EmailTemplate,
+			Recipients:           immediate,
+			DigestRecipients:     digest,
+			DeliveredTo:          models.StringList{},
+			Status:               status,
+			ScheduledFor:         scheduledFor,
+			NextAttemptAt:        scheduledFor,
+		})
+		if err != nil {
+			return report, err
+		}
+		if inserted {
+			report.Scheduled++
+		} else {
+			report.AlreadyScheduled++
+		}
+	}
+
+	return report, nil
+}
+
+func (s *NotificationServiceImpl) resolveRules(typeID uuid.UUID) ([]models.NotificationRule, error) {
+	certType, err := s.typeRepo.FindByID(typeID)
+	if err != nil {
+		return nil, err
+	}
+	effective, err := s.rules.ResolveRules(certType)
+	if err != nil {
+		return nil, err
+	}
+	return effective.Rules, nil
+}
+
+// activeAdminEmails returns the email addresses of the active admins.
+func activeAdminEmails(userRepo repositories.UserRepository) ([]string, error) {
+	admins, err := userRepo.FindActiveAdmins()
+	if err != nil {
+		return nil, err
+	}
+	emails := make([]string, len(admins))
+	for i, admin := range admins {
+		emails[i] = admin.Email
+	}
+	return emails, nil
+}
+
+// reminderRecipients resolves a rule's recipient roles to email addresses.
+// Equipment has no holder to remind, so its holder reminders go to the
+// admins instead.
+func reminderRecipients(cert *models.Certification, roles models.StringList, admins []string) models.StringList {
+	result := models.StringList{}
+	add := func(emails ...string) {
+		for _, email := range emails {
+			if email != "" && !slices.Contains(result, email) {
+				result = append(result, email)
+			}
+		}
+	}
+
+	for _, role := range roles {
+		switch {
+		case role == models.RecipientAdmin:
+			add(admins...)
+		case role == models.RecipientHolder && cert.Person != nil:
+			add(cert.Person.Email)
+		case role == models.RecipientHolder && cert.EquipmentID != nil:
+			add(admins...)
+		}
+	}
+	return result
+}
@@ -0,0 +1,317 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"certitrack/internal/config"
+	"certitrack/internal/mailer"
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// digestTemplate is the template weekly digests are rendered with.
+const digestTemplate = "expiration_digest.html"
+
+// NotificationDigestService sends the recipients who get their reminders in
+// digests one email a week listing them all, and manages every user's own
+// choice of delivery.
+type NotificationDigestService interface {
+	BuildDigests(ctx context.Context, asOf time.Time) (*DigestBuildReport, error)
+	DeliverDue(ctx context.Context, now time.Time) (*DeliveryReport, error)
+	ListDigests(req *ListNotificationDigestsRequest) (*NotificationDigestListResponse, error)
+	RequeueDigest(id uuid.UUID) (*models.NotificationDigest, error)
+	GetPreferences(userID uuid.UUID) (*NotificationPreferences, error)
+	UpdatePreferences(userID uuid.UUID, req *UpdateNotificationPreferencesRequest) (*NotificationPreferences, error)
+}
+
+type NotificationDigestServiceImpl struct {
+	digestRepo repositories.NotificationDigestRepository
+	userRepo   repositories.UserRepository
+	sender     outboxSender
+}
+
+var _ NotificationDigestService = (*NotificationDigestServiceImpl)(nil)
+
+// DigestBuildReport summarizes one run of the digest builder.
+type DigestBuildReport struct {
+	// Recipients is the number of recipients with reminders waiting for a
+	// digest.
+	Recipients int `json:"recipients"`
+	Built      int `json:"built"`
+	// Deferred counts recipients who already had their digest this week.
+	// Their reminders go into next week's.
+	Deferred int `json:"deferred"`
+}
+
+type ListNotificationDigestsRequest struct {
+	PageRequest
+	Status    string `form:"status" binding:"omitempty,oneof=pending sent dead cancelled"`
+	Recipient string `form:"recipient" binding:"omitempty,email"`
+}
+
+type NotificationDigestListResponse struct {
+	Items      []models.NotificationDigest `json:"items"`
+	Pagination Pagination                  `json:"pagination"`
+}
+
+// NotificationPreferences are a user's own notification settings. An empty
+// delivery follows the notification rules.
+type NotificationPreferences struct {
+	Delivery string `json:"delivery"`
+}
+
+type UpdateNotificationPreferencesRequest struct {
+	Delivery string `json:"delivery" binding:"omitempty,oneof=immediate digest"`
+}
+
+// ExpirationDigestEmail is the data passed to the digest template. Items
+// are ordered by expiration date.
+type ExpirationDigestEmail struct {
+	WeekOf string
+	Items  []ExpirationReminderEmail
+}
+
+var ErrDigestNotFound = errors.New("notification digest not found")
+
+func NewNotificationDigestService(
+	digestRepo repositories.NotificationDigestRepository,
+	userRepo repositories.UserRepository,
+	mailer mailer.Mailer,
+	cfg *config.Config,
+) *NotificationDigestServiceImpl {
+	return &NotificationDigestServiceImpl{
+		digestRepo: digestRepo,
+		userRepo:   userRepo,
+		sender:     outboxSender{mailer: mailer, config: cfg.Outbox},
+	}
+}
+
+// BuildDigests collects the reminders waiting for each digest recipient into
+// that recipient's digest for the week of asOf, to be sent by the outbox
+// right away. A recipient gets at most one digest a week, so reminders that
+// come in after it was built wait for the next one.
+func (s *NotificationDigestServiceImpl) BuildDigests(ctx context.Context, asOf time.Time) (*DigestBuildReport, error) {
+	report := &DigestBuildReport{}
+
+	items, err := s.digestRepo.FindPendingItems()
+	if err != nil {
+		return report, err
+	}
+
+	period := models.DigestPeriodStart(asOf)
+	for start := 0; start < len(items); {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		recipient := items[start].Recipient
+		var ids []uuid.UUID
+		end := start
+		for ; end < len(items) && items[end].Recipient == recipient; end++ {
+			ids = append(ids, items[end].NotificationID)
+		}
+		start = end
+		report.Recipients++
+
+		inserted, err := s.digestRepo.CreateDigest(&models.NotificationDigest{
+			Recipient:     recipient,
+			PeriodStart:   period,
+			Status:        models.NotificationStatusPending,
+			NextAttemptAt: asOf,
+		}, ids)
+		if err != nil {
+			return report, err
+		}
+		if inserted {
+			report.Built++
+		} else {
+			report.Deferred++
+		}
+	}
+
+	return report, nil
+}
+
+// DeliverDue sends one batch of the pending digests that are due, retrying
+// and dead-lettering them like reminders.
+func (s *NotificationDigestServiceImpl) DeliverDue(ctx context.Context, now time.Time) (*DeliveryReport, error) {
+	report := &DeliveryReport{}
+
+	digests, err := s.digestRepo.ClaimDue(now, deliveryLease, s.sender.config.BatchSize)
+	if err != nil {
+		return report, err
+	}
+	report.Claimed = len(digests)
+
+	for i := range digests {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		digest := &digests[i]
+		s.deliver(digest, now)
+
+		if err := s.digestRepo.SaveDelivery(digest, digest.Attempts); err != nil {
+			log.Printf("Failed to record delivery of notification digest %s: %v", digest.ID, err)
+			continue
+		}
+		if digest.Status == models.NotificationStatusDead {
+			log.Printf("Notification digest %s dead-lettered after %d attempts: %s", digest.ID, digest.Attempts, digest.ErrorMessage)
+		}
+		report.tally(digest.Status)
+	}
+
+	return report, nil
+}
+
+// deliver sends the digest and sets its status, error message and next
+// attempt accordingly. Reminders for certifications that stopped being
+// active since the digest was built are left out, and a digest with none
+// left is cancelled.
+func (s *NotificationDigestServiceImpl) deliver(digest *models.NotificationDigest, now time.Time) {
+	var certs []*models.Certification
+	for _, item := range digest.Items {
+		if item.Notification == nil {
+			continue
+		}
+		cert := item.Notification.Certification
+		if cert == nil || cert.Status != models.CertificationStatusActive {
+			continue
+		}
+		if !slices.ContainsFunc(certs, func(c *models.Certification) bool { return c.ID == cert.ID }) {
+			certs = append(certs, cert)
+		}
+	}
+	if len(certs) == 0 {
+		digest.Status = models.NotificationStatusCancelled
+		return
+	}
+
+	subject, data := digestEmail(certs, digest.PeriodStart, now)
+	err := s.sender.mailer.SendEmail(digest.Recipient, subject, digestTemplate, data)
+
+	status, retryIn := s.sender.settle(digest.Attempts, err != nil)
+	digest.Status = status
+	digest.ErrorMessage = ""
+	if err != nil {
+		digest.ErrorMessage = err.Error()
+	}
+	switch status {
+	case models.NotificationStatusSent:
+		digest.SentAt = &now
+	case models.NotificationStatusPending:
+		digest.NextAttemptAt = now.Add(retryIn)
+	}
+}
+
+// ListDigests returns digests, latest week first.
+func (s *NotificationDigestServiceImpl) ListDigests(req *ListNotificationDigestsRequest) (*NotificationDigestListResponse, error) {
+	filter := repositories.NotificationDigestFilter{
+		Pagination: req.toPagination(),
+		Status:     req.Status,
+		Recipient:  req.Recipient,
+	}
+	digests, total, err := s.digestRepo.List(filter)
+	if err != nil {
+		return nil, err
+	}
+	if digests == nil {
+		digests = []models.NotificationDigest{}
+	}
+	return &NotificationDigestListResponse{
+		Items:      digests,
+		Pagination: newPagination(filter.Pagination, total),
+	}, nil
+}
+
+// RequeueDigest gives a dead-lettered digest a fresh set of attempts,
+// starting right away.
+func (s *NotificationDigestServiceImpl) RequeueDigest(id uuid.UUID) (*models.NotificationDigest, error) {
+	digest, err := s.digestRepo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDigestNotFound
+		}
+		return nil, err
+	}
+	if digest.Status != models.NotificationStatusDead {
+		return nil, ErrNotificationNotDead
+	}
+
+	attempts := digest.Attempts
+	digest.Status = models.NotificationStatusPending
+	digest.Attempts = 0
+	digest.NextAttemptAt = time.Now()
+	if err := s.digestRepo.SaveDelivery(digest, attempts); err != nil {
+		if errors.Is(err, repositories.ErrStaleNotification) {
+			return nil, ErrNotificationNotDead
+		}
+		return nil, err
+	}
+	return digest, nil
+}
+
+// GetPreferences returns the user's notification settings.
+func (s *NotificationDigestServiceImpl) GetPreferences(userID uuid.UUID) (*NotificationPreferences, error) {
+	user, err := s.userRepo.FindActiveByID(userID.String())
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	return &NotificationPreferences{Delivery: user.NotificationDelivery}, nil
+}
+
+// UpdatePreferences sets how the user gets their reminders. It applies to
+// reminders scheduled from then on.
+func (s *NotificationDigestServiceImpl) UpdatePreferences(userID uuid.UUID, req *UpdateNotificationPreferencesRequest) (*NotificationPreferences, error) {
+	if _, err := s.userRepo.FindActiveByID(userID.String()); err != nil {
+		return nil, ErrUserNotFound
+	}
+	if err := s.userRepo.UpdateNotificationDelivery(userID.String(), req.Delivery); err != nil {
+		return nil, err
+	}
+	return &NotificationPreferences{Delivery: req.Delivery}, nil
+}
+
+// splitByDelivery divides a reminder's recipients into those emailed right
+// away and those who get it in their digest: by their own preference if they
+// have one, by the rule's delivery otherwise.
+func splitByDelivery(recipients models.StringList, delivery string, preferences map[string]string) (models.StringList, models.StringList) {
+	immediate, digest := models.StringList{}, models.StringList{}
+	for _, recipient := range recipients {
+		mode := delivery
+		if preference, ok := preferences[recipient]; ok {
+			mode = preference
+		}
+		if mode == models.DeliveryDigest {
+			digest = append(digest, recipient)
+		} else {
+			immediate = append(immediate, recipient)
+		}
+	}
+	return immediate, digest
+}
+
+// digestEmail builds the subject and template data of a digest as of now.
+func digestEmail(certs []*models.Certification, periodStart, now time.Time) (string, ExpirationDigestEmail) {
+	data := ExpirationDigestEmail{WeekOf: periodStart.Format("2006-01-02")}
+	for _, cert := range certs {
+		_, item := reminderEmail(cert, now)
+		data.Items = append(data.Items, item)
+	}
+	slices.SortStableFunc(data.Items, func(a, b ExpirationReminderEmail) int {
+		return strings.Compare(a.ExpirationDate, b.ExpirationDate)
+	})
+
+	subject := "1 certification expiring soon"
+	if len(data.Items) > 1 {
+		subject = fmt.Sprintf("%d certifications expiring soon", len(data.Items))
+	}
+	return subject, data
+}
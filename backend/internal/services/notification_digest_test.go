@@ -0,0 +1,128 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+)
+
+// addDigestRule adds a 60-day rule for the fixture's type that reminds the
+// holder and the admin in digests.
+func (f *notificationFixture) addDigestRule(t *testing.T) {
+	t.Helper()
+	days := 60
+	_, err := f.notificationRuleFixture.svc.CreateRule(&services.CreateNotificationRuleRequest{
+		CertificationTypeID:  &f.certType.ID,
+		DaysBeforeExpiration: &days,
+		RecipientRoles:       []string{"holder", "admin"},
+		Delivery:             models.DeliveryDigest,
+	}, uuid.New())
+	require.NoError(t, err)
+}
+
+func (f *notificationFixture) buildDigests(t *testing.T, asOf time.Time) *services.DigestBuildReport {
+	t.Helper()
+	report, err := f.digests.BuildDigests(context.Background(), asOf)
+	require.NoError(t, err)
+	return report
+}
+
+func (f *notificationFixture) deliverDigests(t *testing.T, now time.Time) *services.DeliveryReport {
+	t.Helper()
+	report, err := f.digests.DeliverDue(context.Background(), now)
+	require.NoError(t, err)
+	return report
+}
+
+func TestBuildDigests_OneDigestPerRecipientPerWeek(t *testing.T) {
+	f := newNotificationFixture(t)
+	f.addDigestRule(t)
+	dana := f.addPerson(t, "dana@example.com")
+	f.addCert(t, &dana.ID, nil, 40, models.CertificationStatusActive)
+	f.addCert(t, &dana.ID, nil, 50, models.CertificationStatusActive)
+	f.run(t, f.today)
+
+	report := f.buildDigests(t, f.today)
+
+	require.Equal(t, &services.DigestBuildReport{Recipients: 2, Built: 2}, report)
+	digests, err := f.digests.ListDigests(&services.ListNotificationDigestsRequest{Recipient: "dana@example.com"})
+	require.NoError(t, err)
+	require.Len(t, digests.Items, 1)
+	require.Len(t, digests.Items[0].Items, 2)
+	require.Equal(t, f.today.Truncate(24*time.Hour), digests.Items[0].PeriodStart)
+	require.Equal(t, &services.DigestBuildReport{}, f.buildDigests(t, f.today.Add(time.Hour)))
+
+	// A reminder due later in the week waits for next week's digest.
+	f.addCert(t, &dana.ID, nil, 55, models.CertificationStatusActive)
+	f.run(t, f.today.AddDate(0, 0, 2))
+	require.Equal(t, &services.DigestBuildReport{Recipients: 2, Deferred: 2}, f.buildDigests(t, f.today.AddDate(0, 0, 2)))
+	require.Equal(t, &services.DigestBuildReport{Recipients: 2, Built: 2}, f.buildDigests(t, f.today.AddDate(0, 0, 7)))
+}
+
+func TestDigestDeliverDue_SendsDigests(t *testing.T) {
+	f := newNotificationFixture(t)
+	f.addDigestRule(t)
+	dana := f.addPerson(t, "dana@example.com")
+	f.addCert(t, &dana.ID, nil, 50, models.CertificationStatusActive)
+	f.addCert(t, &dana.ID, nil, 40, models.CertificationStatusActive)
+	f.run(t, f.today)
+	f.buildDigests(t, f.today)
+	f.mailer.On("SendEmail", "dana@example.com", "2 certifications expiring soon", "expiration_digest.html", mock.MatchedBy(func(data services.ExpirationDigestEmail) bool {
+		return data.WeekOf == "2025-03-10" && len(data.Items) == 2 && data.Items[0].DaysLeft == 40 && data.Items[1].DaysLeft == 50
+	})).Return(nil).Once()
+	f.mailer.On("SendEmail", "admin@example.com", mock.Anything, "expiration_digest.html", mock.Anything).Return(errors.New("connection refused")).Once()
+
+	report := f.deliverDigests(t, f.today)
+
+	require.Equal(t, &services.DeliveryReport{Claimed: 2, Sent: 1, Retrying: 1}, report)
+	retrying, err := f.digests.ListDigests(&services.ListNotificationDigestsRequest{Status: models.NotificationStatusPending})
+	require.NoError(t, err)
+	require.Len(t, retrying.Items, 1)
+	require.Equal(t, "admin@example.com", retrying.Items[0].Recipient)
+	require.Equal(t, "connection refused", retrying.Items[0].ErrorMessage)
+	f.mailer.AssertExpectations(t)
+}
+
+func TestDigestDeliverDue_CancelsDigestsWithoutActiveCertifications(t *testing.T) {
+	f := newNotificationFixture(t)
+	f.addDigestRule(t)
+	dana := f.addPerson(t, "dana@example.com")
+	cert := f.addCert(t, &dana.ID, nil, 40, models.CertificationStatusActive)
+	f.run(t, f.today)
+	f.buildDigests(t, f.today)
+	require.NoError(t, f.certRepo.ChangeStatus(cert, &models.CertificationStatusHistory{
+		FromStatus: models.CertificationStatusActive,
+		ToStatus:   models.CertificationStatusRevoked,
+	}))
+
+	require.Equal(t, &services.DeliveryReport{Claimed: 2, Cancelled: 2}, f.deliverDigests(t, f.today))
+	f.mailer.AssertNotCalled(t, "SendEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestNotificationPreferences(t *testing.T) {
+	f := newNotificationFixture(t)
+	user := &models.User{Email: "sam@example.com", Role: "user", IsActive: true}
+	require.NoError(t, f.userRepo.CreateUser(user))
+
+	preferences, err := f.digests.GetPreferences(user.ID)
+	require.NoError(t, err)
+	require.Equal(t, "", preferences.Delivery)
+
+	preferences, err = f.digests.UpdatePreferences(user.ID, &services.UpdateNotificationPreferencesRequest{Delivery: models.DeliveryDigest})
+	require.NoError(t, err)
+	require.Equal(t, models.DeliveryDigest, preferences.Delivery)
+	stored, err := f.userRepo.FindDeliveryPreferences()
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"sam@example.com": models.DeliveryDigest}, stored)
+
+	_, err = f.digests.UpdatePreferences(uuid.New(), &services.UpdateNotificationPreferencesRequest{})
+	require.ErrorIs(t, err, services.ErrUserNotFound)
+}
@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"certitrack/internal/config"
+	"certitrack/internal/mailer"
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// escalationTemplate is the template escalation steps are rendered with.
+const escalationTemplate = "expiration_escalation.html"
+
+// NotificationEscalationService escalates certifications nobody renews after
+// their first reminder up the holder's chain of command, and delivers the
+// escalation emails through the outbox.
+type NotificationEscalationService interface {
+	EscalateUnrenewed(ctx context.Context, asOf time.Time) (*EscalationReport, error)
+	DeliverDue(ctx context.Context, now time.Time) (*DeliveryReport, error)
+	RequeueEscalations(notificationID uuid.UUID) ([]models.NotificationEscalation, error)
+}
+
+type NotificationEscalationServiceImpl struct {
+	repository repositories.NotificationRepository
+	personRepo repositories.PersonRepository
+	userRepo   repositories.UserRepository
+	sender     outboxSender
+	afterDays  int
+}
+
+var _ NotificationEscalationService = (*NotificationEscalationServiceImpl)(nil)
+
+// EscalationReport summarizes one run of the escalation check.
+type EscalationReport struct {
+	// Escalated counts escalation steps taken for certifications that were
+	// not renewed. Skipped counts steps that had nobody to go to and were
+	// passed on to the next level.
+	Escalated int `json:"escalated"`
+	Skipped   int `json:"escalationsSkipped"`
+}
+
+// ExpirationEscalationEmail is the data passed to the escalation template.
+type ExpirationEscalationEmail struct {
+	ExpirationReminderEmail
+	// Target is who the reminder was escalated to: 'supervisor',
+	// 'department_head' or 'admin'.
+	Target string
+	// RemindedOn is the date of the first reminder nobody acted on.
+	RemindedOn string
+}
+
+func NewNotificationEscalationService(
+	repository repositories.NotificationRepository,
+	personRepo repositories.PersonRepository,
+	userRepo repositories.UserRepository,
+	mailer mailer.Mailer,
+	cfg *config.Config,
+) *NotificationEscalationServiceImpl {
+	return &NotificationEscalationServiceImpl{
+		repository: repository,
+		personRepo: personRepo,
+		userRepo:   userRepo,
+		sender:     outboxSender{mailer: mailer, config: cfg.Outbox},
+		afterDays:  cfg.Escalation.AfterDays,
+	}
+}
+
+// EscalateUnrenewed takes the next step of the escalation chain for every
+// active certification whose first reminder went unanswered: the holder's
+// supervisor is notified the configured number of days after it, the
+// department head as many days later again, and the admins after that. A
+// step with nobody to go to, such as the supervisor of equipment, is
+// recorded as skipped and the next one is taken right away. Each step is
+// taken once, however often the check runs, and at most one per run.
+func (s *NotificationEscalationServiceImpl) EscalateUnrenewed(ctx context.Context, asOf time.Time) (*EscalationReport, error) {
+	report := &EscalationReport{}
+	after := s.afterDays
+	if after <= 0 {
+		return report, nil
+	}
+
+	chain := models.EscalationChain
+	candidates, err := s.repository.FindEscalationCandidates(asOf.AddDate(0, 0, -after), len(chain))
+	if err != nil {
+		return report, err
+	}
+	if len(candidates) == 0 {
+		return report, nil
+	}
+	admins, err := activeAdminEmails(s.userRepo)
+	if err != nil {
+		return report, err
+	}
+
+	for i := range candidates {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		notification := &candidates[i]
+
+		passedOn := false
+		for level := notification.EscalationLevel + 1; level <= len(chain); level++ {
+			if !passedOn && asOf.Before(notification.ScheduledFor.AddDate(0, 0, level*after)) {
+				break
+			}
+
+			target := chain[level-1]
+			recipients, err := s.escalationRecipients(notification.Certification, target, admins)
+			if err != nil {
+				return report, err
+			}
+			escalation := &models.NotificationEscalation{
+				NotificationID: notification.ID,
+				Level:          level,
+				Target:         target,
+				Recipients:     recipients,
+				DeliveredTo:    models.StringList{},
+				Status:         models.NotificationStatusPending,
+				NextAttemptAt:  asOf,
+			}
+			if len(recipients) == 0 {
+				escalation.Status = models.NotificationEscalationStatusSkipped
+			}
+
+			inserted, err := s.repository.Escalate(escalation)
+			if err != nil {
+				return report, err
+			}
+			if !inserted {
+				// Another check took this step in the meantime.
+				break
+			}
+			if escalation.Status == models.NotificationEscalationStatusSkipped {
+				report.Skipped++
+				passedOn = true
+				continue
+			}
+			report.Escalated++
+			break
+		}
+	}
+	return report, nil
+}
+
+// DeliverDue sends one batch of the escalation steps that are due, retrying
+// and dead-lettering them like reminders.
+func (s *NotificationEscalationServiceImpl) DeliverDue(ctx context.Context, now time.Time) (*DeliveryReport, error) {
+	report := &DeliveryReport{}
+
+	escalations, err := s.repository.ClaimDueEscalations(now, deliveryLease, s.sender.config.BatchSize)
+	if err != nil {
+		return report, err
+	}
+	report.Claimed = len(escalations)
+
+	for i := range escalations {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		escalation := &escalations[i]
+		s.deliver(escalation, now)
+
+		if err := s.repository.SaveEscalationDelivery(escalation, escalation.Attempts); err != nil {
+			log.Printf("Failed to record delivery of escalation %s: %v", escalation.ID, err)
+			continue
+		}
+		if escalation.Status == models.NotificationStatusDead {
+			log.Printf("Escalation %s dead-lettered after %d attempts: %s", escalation.ID, escalation.Attempts, escalation.ErrorMessage)
+		}
+		report.tally(escalation.Status)
+	}
+
+	return report, nil
+}
+
+// deliver sends the escalation step to its remaining recipients and sets its
+// status, error message and next attempt accordingly. It is cancelled once
+// the certification has been renewed or stopped being active.
+func (s *NotificationEscalationServiceImpl) deliver(escalation *models.NotificationEscalation, now time.Time) {
+	notification := escalation.Notification
+	if notification == nil || notification.Certification == nil || notification.Certification.Status != models.CertificationStatusActive {
+		escalation.Status = models.NotificationStatusCancelled
+		return
+	}
+
+	subject, data := escalationEmail(notification, escalation.Target, now)
+	delivered, failures := s.sender.sendEach(escalation.Undelivered(), subject, escalationTemplate, data)
+	escalation.DeliveredTo = append(escalation.DeliveredTo, delivered...)
+
+	status, retryIn := s.sender.settle(escalation.Attempts, len(failures) > 0)
+	escalation.Status = status
+	escalation.ErrorMessage = strings.Join(failures, "; ")
+	switch status {
+	case models.NotificationStatusSent:
+		escalation.SentAt = &now
+	case models.NotificationStatusPending:
+		escalation.NextAttemptAt = now.Add(retryIn)
+	}
+}
+
+// RequeueEscalations gives the dead-lettered escalation steps of a
+// notification a fresh set of attempts, starting right away. Recipients
+// they already reached are not sent them again.
+func (s *NotificationEscalationServiceImpl) RequeueEscalations(notificationID uuid.UUID) ([]models.NotificationEscalation, error) {
+	notification, err := s.repository.FindByID(notificationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotificationNotFound
+		}
+		return nil, err
+	}
+
+	var requeued []models.NotificationEscalation
+	for i := range notification.Escalations {
+		escalation := &notification.Escalations[i]
+		if escalation.Status != models.NotificationStatusDead {
+			continue
+		}
+		attempts := escalation.Attempts
+		escalation.Status = models.NotificationStatusPending
+		escalation.Attempts = 0
+		escalation.NextAttemptAt = time.Now()
+		if err := s.repository.SaveEscalationDelivery(escalation, attempts); err != nil {
+			if errors.Is(err, repositories.ErrStaleNotification) {
+				return nil, ErrNotificationNotDead
+			}
+			return nil, err
+		}
+		requeued = append(requeued, *escalation)
+	}
+
+	if len(requeued) == 0 {
+		return nil, ErrNotificationNotDead
+	}
+	return requeued, nil
+}
+
+// escalationRecipients resolves an escalation target to email addresses.
+// Equipment has no supervisor or department, so only the admins step has
+// anybody to go to.
+func (s *NotificationEscalationServiceImpl) escalationRecipients(cert *models.Certification, target string, admins []string) (models.StringList, error) {
+	result := models.StringList{}
+	add := func(emails ...string) {
+		for _, email := range emails {
+			if email != "" && !slices.Contains(result, email) {
+				result = append(result, email)
+			}
+		}
+	}
+
+	holder := cert.Person
+	switch {
+	case target == models.EscalationAdmin:
+		add(admins...)
+	case holder == nil:
+	case target == models.EscalationSupervisor && holder.SupervisorID != nil:
+		supervisor, err := s.personRepo.FindByID(*holder.SupervisorID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if err == nil && supervisor.IsActive {
+			add(supervisor.Email)
+		}
+	case target == models.EscalationDepartmentHead:
+		heads, err := s.personRepo.FindActiveDepartmentHeads(holder.Department)
+		if err != nil {
+			return nil, err
+		}
+		for _, head := range heads {
+			if head.ID != holder.ID {
+				add(head.Email)
+			}
+		}
+	}
+	return result, nil
+}
+
+// escalationEmail builds the subject and template data of an escalation of
+// the notification as of now.
+func escalationEmail(notification *models.Notification, target string, now time.Time) (string, ExpirationEscalationEmail) {
+	subject, reminder := reminderEmail(notification.Certification, now)
+	data := ExpirationEscalationEmail{
+		ExpirationReminderEmail: reminder,
+		Target:                  target,
+		RemindedOn:              notification.ScheduledFor.Format("2006-01-02"),
+	}
+	return "Not renewed: " + subject, data
+}
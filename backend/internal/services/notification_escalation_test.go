@@ -0,0 +1,185 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+)
+
+// escalateAfter turns on escalation every given number of days.
+func (f *notificationFixture) escalateAfter(days int) {
+	f.cfg.Escalation.AfterDays = days
+	f.newService()
+}
+
+// addTeam adds a holder in the warehouse with a supervisor and a department
+// head.
+func (f *notificationFixture) addTeam(t *testing.T) *models.Person {
+	t.Helper()
+	supervisor := f.addPerson(t, "supervisor@example.com")
+	head := &models.Person{FirstName: "Lee", LastName: "Park", Email: "head@example.com", Department: "Warehouse", IsDepartmentHead: true, IsActive: true}
+	require.NoError(t, f.personRepo.Create(head))
+	holder := &models.Person{FirstName: "Dana", LastName: "Reyes", Email: "dana@example.com", Department: "warehouse", SupervisorID: &supervisor.ID, IsActive: true}
+	require.NoError(t, f.personRepo.Create(holder))
+	return holder
+}
+
+// escalate schedules the reminders due as of asOf and then escalates, as the
+// expiration checker does.
+func (f *notificationFixture) escalate(t *testing.T, asOf time.Time) *services.EscalationReport {
+	t.Helper()
+	f.run(t, asOf)
+	report, err := f.escalations.EscalateUnrenewed(context.Background(), asOf)
+	require.NoError(t, err)
+	return report
+}
+
+func (f *notificationFixture) deliverEscalations(t *testing.T, now time.Time) *services.DeliveryReport {
+	t.Helper()
+	report, err := f.escalations.DeliverDue(context.Background(), now)
+	require.NoError(t, err)
+	return report
+}
+
+func TestEscalation_FollowsChain(t *testing.T) {
+	f := newNotificationFixture(t)
+	f.escalateAfter(7)
+	holder := f.addTeam(t)
+	// The 30-day reminder was due ten days ago.
+	f.addCert(t, &holder.ID, nil, 20, models.CertificationStatusActive)
+
+	report := f.escalate(t, f.today)
+	require.Equal(t, &services.EscalationReport{Escalated: 1}, report)
+	require.Equal(t, 0, f.escalate(t, f.today.Add(time.Hour)).Escalated)
+	require.Equal(t, 1, f.escalate(t, f.today.AddDate(0, 0, 4)).Escalated)
+	require.Equal(t, 1, f.escalate(t, f.today.AddDate(0, 0, 11)).Escalated)
+	require.Equal(t, 0, f.escalate(t, f.today.AddDate(0, 0, 18)).Escalated)
+
+	first := f.repo.All()[0]
+	require.Equal(t, 30, first.DaysBeforeExpiration)
+	notification, err := f.repo.FindByID(first.ID)
+	require.NoError(t, err)
+	require.Equal(t, 3, notification.EscalationLevel)
+	require.Len(t, notification.Escalations, 3)
+	for i, want := range []struct {
+		target     string
+		recipients models.StringList
+	}{
+		{models.EscalationSupervisor, models.StringList{"supervisor@example.com"}},
+		{models.EscalationDepartmentHead, models.StringList{"head@example.com"}},
+		{models.EscalationAdmin, models.StringList{"admin@example.com"}},
+	} {
+		require.Equal(t, i+1, notification.Escalations[i].Level)
+		require.Equal(t, want.target, notification.Escalations[i].Target)
+		require.Equal(t, want.recipients, notification.Escalations[i].Recipients)
+		require.Equal(t, models.NotificationStatusPending, notification.Escalations[i].Status)
+	}
+}
+
+func TestEscalation_DisabledByDefault(t *testing.T) {
+	f := newNotificationFixture(t)
+	holder := f.addTeam(t)
+	f.addCert(t, &holder.ID, nil, 20, models.CertificationStatusActive)
+
+	require.Equal(t, &services.EscalationReport{}, f.escalate(t, f.today))
+	notification, err := f.repo.FindByID(f.repo.All()[0].ID)
+	require.NoError(t, err)
+	require.Empty(t, notification.Escalations)
+}
+
+func TestEscalation_PassesOnMissingLevels(t *testing.T) {
+	f := newNotificationFixture(t)
+	f.escalateAfter(7)
+	equipmentID := uuid.New()
+	f.addCert(t, nil, &equipmentID, 20, models.CertificationStatusActive)
+
+	report := f.escalate(t, f.today)
+
+	require.Equal(t, &services.EscalationReport{Escalated: 1, Skipped: 2}, report)
+	notification, err := f.repo.FindByID(f.repo.All()[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, 3, notification.EscalationLevel)
+	require.Equal(t, models.NotificationEscalationStatusSkipped, notification.Escalations[0].Status)
+	require.Equal(t, models.NotificationEscalationStatusSkipped, notification.Escalations[1].Status)
+	require.Equal(t, models.StringList{"admin@example.com"}, notification.Escalations[2].Recipients)
+}
+
+func TestEscalation_StopsOnceRenewed(t *testing.T) {
+	f := newNotificationFixture(t)
+	f.escalateAfter(7)
+	holder := f.addTeam(t)
+	cert := f.addCert(t, &holder.ID, nil, 20, models.CertificationStatusActive)
+	require.Equal(t, 1, f.escalate(t, f.today).Escalated)
+
+	require.NoError(t, f.certRepo.ChangeStatus(cert, &models.CertificationStatusHistory{
+		FromStatus: models.CertificationStatusActive,
+		ToStatus:   models.CertificationStatusSuperseded,
+	}))
+
+	require.Equal(t, 0, f.escalate(t, f.today.AddDate(0, 0, 4)).Escalated)
+	// Neither the reminder nor the supervisor's escalation goes out.
+	require.Equal(t, &services.DeliveryReport{Claimed: 1, Cancelled: 1}, f.deliver(t, f.today.AddDate(0, 0, 4)))
+	require.Equal(t, &services.DeliveryReport{Claimed: 1, Cancelled: 1}, f.deliverEscalations(t, f.today.AddDate(0, 0, 4)))
+	f.mailer.AssertNotCalled(t, "SendEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestEscalation_DeliverDue(t *testing.T) {
+	f := newNotificationFixture(t)
+	holder := f.addTeam(t)
+	f.addCert(t, &holder.ID, nil, 20, models.CertificationStatusActive)
+	f.run(t, f.today)
+	f.mailer.On("SendEmail", "dana@example.com", mock.Anything, "default_expiration.html", mock.Anything).Return(nil).Once()
+	require.Equal(t, &services.DeliveryReport{Claimed: 1, Sent: 1}, f.deliver(t, f.today))
+
+	f.escalateAfter(7)
+	require.Equal(t, 1, f.escalate(t, f.today).Escalated)
+	f.mailer.On("SendEmail", "supervisor@example.com", "Not renewed: Forklift for Dana Reyes expires on 2025-03-30", "expiration_escalation.html", mock.MatchedBy(func(data services.ExpirationEscalationEmail) bool {
+		return data.Target == models.EscalationSupervisor && data.RemindedOn == "2025-02-28" && data.DaysLeft == 20
+	})).Return(nil).Once()
+
+	require.Equal(t, &services.DeliveryReport{Claimed: 1, Sent: 1}, f.deliverEscalations(t, f.today))
+	notification, err := f.repo.FindByID(f.repo.All()[0].ID)
+	require.NoError(t, err)
+	require.Equal(t, models.NotificationStatusSent, notification.Escalations[0].Status)
+	require.Equal(t, models.StringList{"supervisor@example.com"}, notification.Escalations[0].DeliveredTo)
+	f.mailer.AssertExpectations(t)
+}
+
+func TestEscalation_DeadLettersAndRequeues(t *testing.T) {
+	f := newNotificationFixture(t)
+	f.escalateAfter(7)
+	holder := f.addTeam(t)
+	f.addCert(t, &holder.ID, nil, 20, models.CertificationStatusActive)
+	f.escalate(t, f.today)
+	f.mailer.On("SendEmail", "supervisor@example.com", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("mailbox unavailable"))
+
+	now := f.today
+	for attempt := 1; attempt <= 3; attempt++ {
+		require.Equal(t, 1, f.deliverEscalations(t, now).Claimed, "attempt %d", attempt)
+		now = now.Add(time.Hour)
+	}
+	id := f.repo.All()[0].ID
+	dead, err := f.repo.FindByID(id)
+	require.NoError(t, err)
+	require.Equal(t, models.NotificationStatusDead, dead.Escalations[0].Status)
+	require.Equal(t, &services.DeliveryReport{}, f.deliverEscalations(t, now))
+
+	requeued, err := f.escalations.RequeueEscalations(id)
+	require.NoError(t, err)
+	require.Len(t, requeued, 1)
+	require.Equal(t, models.NotificationStatusPending, requeued[0].Status)
+	require.Equal(t, 0, requeued[0].Attempts)
+
+	_, err = f.escalations.RequeueEscalations(id)
+	require.ErrorIs(t, err, services.ErrNotificationNotDead)
+	_, err = f.escalations.RequeueEscalations(uuid.New())
+	require.ErrorIs(t, err, services.ErrNotificationNotFound)
+}
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"certitrack/internal/config"
+	"certitrack/internal/mailer"
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// deliveryLease is how long a claimed notification is left to the worker
+// that claimed it before another worker may retry it.
+const deliveryLease = 15 * time.Minute
+
+// NotificationOutboxService delivers scheduled reminders through an outbox:
+// every reminder is stored before it is sent, and failed deliveries are
+// retried until they are dead-lettered.
+type NotificationOutboxService interface {
+	DeliverDue(ctx context.Context, now time.Time) (*DeliveryReport, error)
+	ListNotifications(req *ListNotificationsRequest) (*NotificationListResponse, error)
+	RequeueNotification(id uuid.UUID) (*models.Notification, error)
+}
+
+type NotificationOutboxServiceImpl struct {
+	repository repositories.NotificationRepository
+	sender     outboxSender
+}
+
+var _ NotificationOutboxService = (*NotificationOutboxServiceImpl)(nil)
+
+// DeliveryReport summarizes one pass of the outbox worker.
+type DeliveryReport struct {
+	Claimed  int `json:"claimed"`
+	Sent     int `json:"sent"`
+	Retrying int `json:"retrying"`
+	// DeadLettered counts notifications that failed for the last time and
+	// wait for an admin to requeue them.
+	DeadLettered int `json:"deadLettered"`
+	// Cancelled counts reminders for certifications that were renewed,
+	// revoked or expired before the reminder went out.
+	Cancelled int `json:"cancelled"`
+}
+
+// Add adds the counts of another report to r.
+func (r *DeliveryReport) Add(other *DeliveryReport) {
+	r.Claimed += other.Claimed
+	r.Sent += other.Sent
+	r.Retrying += other.Retrying
+	r.DeadLettered += other.DeadLettered
+	r.Cancelled += other.Cancelled
+}
+
+func (r *DeliveryReport) tally(status string) {
+	switch status {
+	case models.NotificationStatusSent:
+		r.Sent++
+	case models.NotificationStatusCancelled:
+		r.Cancelled++
+	case models.NotificationStatusDead:
+		r.DeadLettered++
+	default:
+		r.Retrying++
+	}
+}
+
+type ListNotificationsRequest struct {
+	PageRequest
+	Status          string `form:"status" binding:"omitempty,oneof=pending sent dead cancelled digest"`
+	CertificationID string `form:"certificationId" binding:"omitempty,uuid"`
+}
+
+type NotificationListResponse struct {
+	Items      []models.Notification `json:"items"`
+	Pagination Pagination            `json:"pagination"`
+}
+
+// ExpirationReminderEmail is the data passed to expiration reminder
+// templates.
+type ExpirationReminderEmail struct {
+	CertificationType string
+	CertificateNumber string
+	Holder            string
+	ExpirationDate    string
+	DaysLeft          int
+}
+
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+	ErrNotificationNotDead  = errors.New("only dead-lettered notifications can be requeued")
+)
+
+func NewNotificationOutboxService(
+	repository repositories.NotificationRepository,
+	mailer mailer.Mailer,
+	cfg *config.Config,
+) *NotificationOutboxServiceImpl {
+	return &NotificationOutboxServiceImpl{
+		repository: repository,
+		sender:     outboxSender{mailer: mailer, config: cfg.Outbox},
+	}
+}
+
+// DeliverDue sends one batch of the pending notifications that are due. An
+// email that cannot be sent to every recipient is retried later, only to
+// the recipients that have not received it, and is dead-lettered once it
+// has used up its attempts.
+func (s *NotificationOutboxServiceImpl) DeliverDue(ctx context.Context, now time.Time) (*DeliveryReport, error) {
+	report := &DeliveryReport{}
+
+	claimed, err := s.repository.ClaimDue(now, deliveryLease, s.sender.config.BatchSize)
+	if err != nil {
+		return report, err
+	}
+	report.Claimed = len(claimed)
+
+	for i := range claimed {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		notification := &claimed[i]
+		s.deliver(notification, now)
+
+		if err := s.repository.SaveDelivery(notification, notification.Attempts); err != nil {
+			// The email may have gone out; the notification is retried
+			// once its lease runs out.
+			log.Printf("Failed to record delivery of notification %s: %v", notification.ID, err)
+			continue
+		}
+		if notification.Status == models.NotificationStatusDead {
+			log.Printf("Notification %s dead-lettered after %d attempts: %s", notification.ID, notification.Attempts, notification.ErrorMessage)
+		}
+		report.tally(notification.Status)
+	}
+
+	return report, nil
+}
+
+// deliver sends the notification to its remaining recipients and sets its
+// status, error message and next attempt accordingly.
+func (s *NotificationOutboxServiceImpl) deliver(notification *models.Notification, now time.Time) {
+	cert := notification.Certification
+	if cert == nil || cert.Status != models.CertificationStatusActive {
+		notification.Status = models.NotificationStatusCancelled
+		return
+	}
+
+	subject, data := reminderEmail(cert, now)
+	template := strings.TrimSuffix(notification.EmailTemplate, ".html") + ".html"
+	delivered, failures := s.sender.sendEach(notification.Undelivered(), subject, template, data)
+	notification.DeliveredTo = append(notification.DeliveredTo, delivered...)
+
+	status, retryIn := s.sender.settle(notification.Attempts, len(failures) > 0)
+	notification.Status = status
+	notification.ErrorMessage = strings.Join(failures, "; ")
+	switch status {
+	case models.NotificationStatusSent:
+		notification.SentAt = &now
+	case models.NotificationStatusPending:
+		notification.NextAttemptAt = now.Add(retryIn)
+	}
+}
+
+// ListNotifications returns notifications, most recently scheduled first.
+// Filtering on the dead status lists the dead letters.
+func (s *NotificationOutboxServiceImpl) ListNotifications(req *ListNotificationsRequest) (*NotificationListResponse, error) {
+	filter := repositories.NotificationFilter{
+		Pagination:      req.toPagination(),
+		Status:          req.Status,
+		CertificationID: queryID(req.CertificationID),
+	}
+	notifications, total, err := s.repository.List(filter)
+	if err != nil {
+		return nil, err
+	}
+	if notifications == nil {
+		notifications = []models.Notification{}
+	}
+	return &NotificationListResponse{
+		Items:      notifications,
+		Pagination: newPagination(filter.Pagination, total),
+	}, nil
+}
+
+// RequeueNotification gives a dead-lettered notification a fresh set of
+// attempts, starting right away. Recipients it already reached are not sent
+// it again.
+func (s *NotificationOutboxServiceImpl) RequeueNotification(id uuid.UUID) (*models.Notification, error) {
+	notification, err := s.repository.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotificationNotFound
+		}
+		return nil, err
+	}
+	if notification.Status != models.NotificationStatusDead {
+		return nil, ErrNotificationNotDead
+	}
+
+	attempts := notification.Attempts
+	notification.Status = models.NotificationStatusPending
+	notification.Attempts = 0
+	notification.NextAttemptAt = time.Now()
+	if err := s.repository.SaveDelivery(notification, attempts); err != nil {
+		if errors.Is(err, repositories.ErrStaleNotification) {
+			return nil, ErrNotificationNotDead
+		}
+		return nil, err
+	}
+	return notification, nil
+}
+
+// outboxSender sends outbox emails and decides after every attempt whether
+// an email was sent, is retried later or is dead-lettered. Reminders,
+// digests and escalations share it.
+type outboxSender struct {
+	mailer mailer.Mailer
+	config config.OutboxConfig
+}
+
+// sendEach sends the email to every recipient separately and returns the
+// recipients it was sent to and a description of each failure.
+func (s outboxSender) sendEach(recipients []string, subject, template string, data any) ([]string, []string) {
+	var delivered, failures []string
+	for _, to := range recipients {
+		if err := s.mailer.SendEmail(to, subject, template, data); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", to, err))
+			continue
+		}
+		delivered = append(delivered, to)
+	}
+	return delivered, failures
+}
+
+// settle returns the status of a notification or digest after the given
+// delivery attempt, and while it stays pending, how long until it is retried.
+func (s outboxSender) settle(attempt int, failed bool) (string, time.Duration) {
+	switch {
+	case !failed:
+		return models.NotificationStatusSent, 0
+	case attempt >= s.config.MaxAttempts:
+		return models.NotificationStatusDead, 0
+	default:
+		return models.NotificationStatusPending, s.retryDelay(attempt)
+	}
+}
+
+// retryDelay is the wait after the given failed attempt: the base delay
+// doubled for every earlier failure and capped at the maximum. A random
+// part of up to half of it keeps emails that failed together, say while
+// the mail server was down, from all being retried at the same moment.
+func (s outboxSender) retryDelay(attempt int) time.Duration {
+	delay := s.config.RetryBaseDelay
+	for i := 1; i < attempt && delay < s.config.RetryMaxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, s.config.RetryMaxDelay)
+	return delay - rand.N(delay/2+1)
+}
+
+// reminderEmail builds the subject and template data of an expiration
+// reminder as of now.
+func reminderEmail(cert *models.Certification, now time.Time) (string, ExpirationReminderEmail) {
+	data := ExpirationReminderEmail{
+		CertificateNumber: cert.CertificateNumber,
+		ExpirationDate:    cert.ExpirationDate.Format("2006-01-02"),
+		DaysLeft:          max(cert.DaysUntil(now), 0),
+	}
+	if cert.CertificationType != nil {
+		data.CertificationType = cert.CertificationType.Name
+	}
+	switch {
+	case cert.Person != nil:
+		data.Holder = cert.Person.FullName()
+	case cert.Equipment != nil:
+		data.Holder = cert.Equipment.Name
+	}
+
+	subject := fmt.Sprintf("%s expires on %s", data.CertificationType, data.ExpirationDate)
+	if data.Holder != "" {
+		subject = fmt.Sprintf("%s for %s expires on %s", data.CertificationType, data.Holder, data.ExpirationDate)
+	}
+	return subject, data
+}
@@ -0,0 +1,135 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+)
+
+// scheduleOne schedules the 7-day reminder of a certification expiring in
+// five days, to the holder and the admin.
+func (f *notificationFixture) scheduleOne(t *testing.T) *models.Notification {
+	t.Helper()
+	days := 7
+	_, err := f.notificationRuleFixture.svc.CreateRule(&services.CreateNotificationRuleRequest{
+		CertificationTypeID:  &f.certType.ID,
+		DaysBeforeExpiration: &days,
+		RecipientRoles:       []string{"holder", "admin"},
+	}, uuid.New())
+	require.NoError(t, err)
+	person := f.addPerson(t, "dana@example.com")
+	f.addCert(t, &person.ID, nil, 5, models.CertificationStatusActive)
+	require.Equal(t, 1, f.run(t, f.today).Scheduled)
+	return &f.repo.All()[0]
+}
+
+func (f *notificationFixture) deliver(t *testing.T, now time.Time) *services.DeliveryReport {
+	t.Helper()
+	report, err := f.outbox.DeliverDue(context.Background(), now)
+	require.NoError(t, err)
+	return report
+}
+
+func TestDeliverDue_SendsToEveryRecipient(t *testing.T) {
+	f := newNotificationFixture(t)
+	scheduled := f.scheduleOne(t)
+	for _, to := range []string{"dana@example.com", "admin@example.com"} {
+		f.mailer.On("SendEmail", to, mock.Anything, "default_expiration.html", mock.MatchedBy(func(data services.ExpirationReminderEmail) bool {
+			return data.CertificationType == "Forklift" && data.Holder == "Dana Reyes" && data.DaysLeft == 5
+		})).Return(nil).Once()
+	}
+
+	report := f.deliver(t, f.today)
+
+	require.Equal(t, &services.DeliveryReport{Claimed: 1, Sent: 1}, report)
+	sent, err := f.repo.FindByID(scheduled.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.NotificationStatusSent, sent.Status)
+	require.Equal(t, 1, sent.Attempts)
+	require.NotNil(t, sent.SentAt)
+	require.Empty(t, sent.ErrorMessage)
+
+	// A sent notification is never sent again.
+	require.Equal(t, &services.DeliveryReport{}, f.deliver(t, f.today.Add(time.Hour)))
+	f.mailer.AssertExpectations(t)
+}
+
+func TestDeliverDue_RetriesFailedRecipientsWithBackoff(t *testing.T) {
+	f := newNotificationFixture(t)
+	scheduled := f.scheduleOne(t)
+	f.mailer.On("SendEmail", "dana@example.com", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	f.mailer.On("SendEmail", "admin@example.com", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("connection refused")).Once()
+
+	require.Equal(t, &services.DeliveryReport{Claimed: 1, Retrying: 1}, f.deliver(t, f.today))
+	retrying, err := f.repo.FindByID(scheduled.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.NotificationStatusPending, retrying.Status)
+	require.Equal(t, models.StringList{"dana@example.com"}, retrying.DeliveredTo)
+	require.Contains(t, retrying.ErrorMessage, "admin@example.com: connection refused")
+	delay := retrying.NextAttemptAt.Sub(f.today)
+	require.True(t, delay >= 30*time.Second && delay <= time.Minute, delay)
+
+	// Not due again before the backoff has passed.
+	require.Equal(t, &services.DeliveryReport{}, f.deliver(t, f.today.Add(20*time.Second)))
+
+	// The retry only goes to the recipient that failed.
+	f.mailer.On("SendEmail", "admin@example.com", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	require.Equal(t, &services.DeliveryReport{Claimed: 1, Sent: 1}, f.deliver(t, f.today.Add(time.Minute)))
+	f.mailer.AssertExpectations(t)
+}
+
+func TestDeliverDue_DeadLettersAndRequeues(t *testing.T) {
+	f := newNotificationFixture(t)
+	scheduled := f.scheduleOne(t)
+	f.mailer.On("SendEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("mailbox unavailable"))
+
+	now := f.today
+	for attempt := 1; attempt <= 3; attempt++ {
+		report := f.deliver(t, now)
+		require.Equal(t, 1, report.Claimed, "attempt %d", attempt)
+		now = now.Add(time.Hour)
+	}
+	dead, err := f.repo.FindByID(scheduled.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.NotificationStatusDead, dead.Status)
+	require.Equal(t, 3, dead.Attempts)
+	require.Equal(t, &services.DeliveryReport{}, f.deliver(t, now))
+
+	listed, err := f.outbox.ListNotifications(&services.ListNotificationsRequest{Status: models.NotificationStatusDead})
+	require.NoError(t, err)
+	require.Len(t, listed.Items, 1)
+
+	requeued, err := f.outbox.RequeueNotification(scheduled.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.NotificationStatusPending, requeued.Status)
+	require.Equal(t, 0, requeued.Attempts)
+
+	_, err = f.outbox.RequeueNotification(scheduled.ID)
+	require.ErrorIs(t, err, services.ErrNotificationNotDead)
+	_, err = f.outbox.RequeueNotification(uuid.New())
+	require.ErrorIs(t, err, services.ErrNotificationNotFound)
+
+	require.Equal(t, 1, f.deliver(t, time.Now().Add(time.Second)).Claimed)
+}
+
+func TestDeliverDue_CancelsRemindersOfInactiveCertifications(t *testing.T) {
+	f := newNotificationFixture(t)
+	scheduled := f.scheduleOne(t)
+	cert, err := f.certRepo.FindByID(scheduled.CertificationID)
+	require.NoError(t, err)
+	require.NoError(t, f.certRepo.ChangeStatus(cert, &models.CertificationStatusHistory{
+		FromStatus: models.CertificationStatusActive,
+		ToStatus:   models.CertificationStatusSuperseded,
+	}))
+
+	require.Equal(t, &services.DeliveryReport{Claimed: 1, Cancelled: 1}, f.deliver(t, f.today))
+	f.mailer.AssertNotCalled(t, "SendEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,283 @@
+package services
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// NotificationRuleService manages expiration reminder rules and resolves
+// which of them apply to a certification.
+type NotificationRuleService interface {
+	CreateRule(req *CreateNotificationRuleRequest, actorID uuid.UUID) (*models.NotificationRule, error)
+	GetRule(id uuid.UUID) (*models.NotificationRule, error)
+	ListRules(req *ListNotificationRulesRequest) (*NotificationRuleListResponse, error)
+	UpdateRule(id uuid.UUID, req *UpdateNotificationRuleRequest) (*models.NotificationRule, error)
+	DeleteRule(id uuid.UUID) error
+	ResolveRules(certType *models.CertificationType) (*EffectiveNotificationRules, error)
+	ResolveForCertification(certID uuid.UUID) (*EffectiveNotificationRules, error)
+}
+
+type NotificationRuleServiceImpl struct {
+	repository repositories.NotificationRuleRepository
+	typeRepo   repositories.CertificationTypeRepository
+	certRepo   repositories.CertificationRepository
+}
+
+var _ NotificationRuleService = (*NotificationRuleServiceImpl)(nil)
+
+// CreateNotificationRuleRequest sets at most one of CertificationTypeID and
+// Category; with neither the rule is global. The scope cannot be changed
+// afterwards.
+type CreateNotificationRuleRequest struct {
+	CertificationTypeID  *uuid.UUID `json:"certification_type_id"`
+	Category             string     `json:"category" binding:"omitempty,oneof=safety professional equipment"`
+	DaysBeforeExpiration *int       `json:"days_before_expiration" binding:"required,min=0,max=730"`
+	EmailTemplate        string     `json:"email_template" binding:"max=100"`
+	RecipientRoles       []string   `json:"recipient_roles" binding:"omitempty,dive,oneof=holder admin"`
+	Delivery             string     `json:"delivery" binding:"omitempty,oneof=immediate digest"`
+	IsActive             *bool      `json:"is_active"`
+}
+
+type UpdateNotificationRuleRequest struct {
+	DaysBeforeExpiration *int      `json:"days_before_expiration" binding:"omitempty,min=0,max=730"`
+	EmailTemplate        *string   `json:"email_template" binding:"omitempty,max=100"`
+	RecipientRoles       *[]string `json:"recipient_roles" binding:"omitempty,dive,oneof=holder admin"`
+	Delivery             *string   `json:"delivery" binding:"omitempty,oneof=immediate digest"`
+	IsActive             *bool     `json:"is_active"`
+}
+
+type ListNotificationRulesRequest struct {
+	PageRequest
+	CertificationTypeID string `form:"certificationTypeId" binding:"omitempty,uuid"`
+	Category            string `form:"category"`
+	Scope               string `form:"scope" binding:"omitempty,oneof=type category global"`
+	IsActive            *bool  `form:"isActive"`
+}
+
+type NotificationRuleListResponse struct {
+	Items      []models.NotificationRule `json:"items"`
+	Pagination Pagination                `json:"pagination"`
+}
+
+// EffectiveNotificationRules is the rule set that applies to a certification
+// type and the level it was taken from. Scope is empty when no rule applies.
+type EffectiveNotificationRules struct {
+	CertificationType *models.CertificationType `json:"certificationType"`
+	Scope             string                    `json:"scope"`
+	Rules             []models.NotificationRule `json:"rules"`
+}
+
+var (
+	ErrNotificationRuleNotFound = errors.New("notification rule not found")
+	ErrNotificationRuleExists   = errors.New("notification rule with this scope and number of days already exists")
+	ErrInvalidNotificationScope = errors.New("notification rule cannot target both a certification type and a category")
+	ErrUnknownEmailTemplate     = errors.New("notification rule email template does not exist")
+)
+
+func NewNotificationRuleService(
+	repository repositories.NotificationRuleRepository,
+	typeRepo repositories.CertificationTypeRepository,
+	certRepo repositories.CertificationRepository,
+) *NotificationRuleServiceImpl {
+	return &NotificationRuleServiceImpl{
+		repository: repository,
+		typeRepo:   typeRepo,
+		certRepo:   certRepo,
+	}
+}
+
+func (s *NotificationRuleServiceImpl) CreateRule(req *CreateNotificationRuleRequest, actorID uuid.UUID) (*models.NotificationRule, error) {
+	if req.CertificationTypeID != nil && req.Category != "" {
+		return nil, ErrInvalidNotificationScope
+	}
+
+	var certType *models.CertificationType
+	if req.CertificationTypeID != nil {
+		var err error
+		certType, err = s.typeRepo.FindByID(*req.CertificationTypeID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrCertificationTypeNotFound
+			}
+			return nil, err
+		}
+		if !certType.IsActive {
+			return nil, ErrInactiveCertificationType
+		}
+	}
+
+	template, err := reminderTemplate(req.EmailTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := models.NotificationRule{
+		CertificationTypeID:  req.CertificationTypeID,
+		Category:             req.Category,
+		DaysBeforeExpiration: *req.DaysBeforeExpiration,
+		IsActive:             true,
+		EmailTemplate:        template,
+		RecipientRoles:       recipientRoles(req.RecipientRoles),
+		Delivery:             req.Delivery,
+		CreatedBy:            nullableID(actorID),
+	}
+	if rule.Delivery == "" {
+		rule.Delivery = models.DeliveryImmediate
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+	if s.repository.RuleExists(&rule) {
+		return nil, ErrNotificationRuleExists
+	}
+
+	if err := s.repository.Create(&rule); err != nil {
+		return nil, err
+	}
+
+	rule.CertificationType = certType
+	return &rule, nil
+}
+
+func (s *NotificationRuleServiceImpl) GetRule(id uuid.UUID) (*models.NotificationRule, error) {
+	rule, err := s.repository.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNotificationRuleNotFound
+		}
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (s *NotificationRuleServiceImpl) ListRules(req *ListNotificationRulesRequest) (*NotificationRuleListResponse, error) {
+	page := req.toPagination()
+	rules, total, err := s.repository.List(repositories.NotificationRuleFilter{
+		Pagination:          page,
+		CertificationTypeID: queryID(req.CertificationTypeID),
+		Category:            req.Category,
+		Scope:               req.Scope,
+		IsActive:            req.IsActive,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rules == nil {
+		rules = []models.NotificationRule{}
+	}
+
+	return &NotificationRuleListResponse{
+		Items:      rules,
+		Pagination: newPagination(page, total),
+	}, nil
+}
+
+func (s *NotificationRuleServiceImpl) UpdateRule(id uuid.UUID, req *UpdateNotificationRuleRequest) (*models.NotificationRule, error) {
+	rule, err := s.GetRule(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DaysBeforeExpiration != nil {
+		rule.DaysBeforeExpiration = *req.DaysBeforeExpiration
+		if s.repository.RuleExists(rule) {
+			return nil, ErrNotificationRuleExists
+		}
+	}
+	if req.EmailTemplate != nil {
+		template, err := reminderTemplate(*req.EmailTemplate)
+		if err != nil {
+			return nil, err
+		}
+		rule.EmailTemplate = template
+	}
+	if req.RecipientRoles != nil {
+		rule.RecipientRoles = recipientRoles(*req.RecipientRoles)
+	}
+	if req.Delivery != nil {
+		rule.Delivery = *req.Delivery
+	}
+	if req.IsActive != nil {
+		rule.IsActive = *req.IsActive
+	}
+
+	if err := s.repository.Update(rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (s *NotificationRuleServiceImpl) DeleteRule(id uuid.UUID) error {
+	if err := s.repository.Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrNotificationRuleNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// ResolveRules returns the rules that apply to certifications of the type:
+// type-specific rules over category rules over global rules.
+func (s *NotificationRuleServiceImpl) ResolveRules(certType *models.CertificationType) (*EffectiveNotificationRules, error) {
+	rules, err := s.repository.FindActiveFor(certType)
+	if err != nil {
+		return nil, err
+	}
+
+	scope, effective := models.EffectiveNotificationRules(rules, certType)
+	return &EffectiveNotificationRules{
+		CertificationType: certType,
+		Scope:             scope,
+		Rules:             effective,
+	}, nil
+}
+
+func (s *NotificationRuleServiceImpl) ResolveForCertification(certID uuid.UUID) (*EffectiveNotificationRules, error) {
+	cert, err := s.certRepo.FindByID(certID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCertificationNotFound
+		}
+		return nil, err
+	}
+	if cert.CertificationType == nil {
+		return nil, ErrCertificationTypeNotFound
+	}
+	return s.ResolveRules(cert.CertificationType)
+}
+
+// recipientRoles removes duplicates and defaults to the holder.
+func recipientRoles(roles []string) models.StringList {
+	result := models.StringList{}
+	for _, role := range roles {
+		if !slices.Contains(result, role) {
+			result = append(result, role)
+		}
+	}
+	if len(result) == 0 {
+		result = append(result, models.RecipientHolder)
+	}
+	return result
+}
+
+// reminderTemplate returns the reminder template a rule names, defaulting to
+// models.DefaultEmailTemplate, or ErrUnknownEmailTemplate when the mailer has
+// no such template.
+func reminderTemplate(name string) (string, error) {
+	name = strings.TrimSuffix(strings.TrimSpace(name), ".html")
+	if name == "" {
+		return models.DefaultEmailTemplate, nil
+	}
+	if !slices.Contains(models.ReminderEmailTemplates, name) {
+		return "", ErrUnknownEmailTemplate
+	}
+	return name, nil
+}
@@ -0,0 +1,197 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+	"certitrack/internal/services"
+)
+
+type notificationRuleFixture struct {
+	svc      services.NotificationRuleService
+	repo     *repositories.MockNotificationRuleRepository
+	typeRepo *repositories.MockCertificationTypeRepository
+	certRepo *repositories.MockCertificationRepository
+}
+
+func newNotificationRuleFixture() *notificationRuleFixture {
+	f := &notificationRuleFixture{
+		typeRepo: repositories.NewMockCertificationTypeRepository(),
+		certRepo: repositories.NewMockCertificationRepository(),
+	}
+	f.repo = repositories.NewMockNotificationRuleRepository(f.typeRepo)
+	f.svc = services.NewNotificationRuleService(f.repo, f.typeRepo, f.certRepo)
+	return f
+}
+
+func (f *notificationRuleFixture) addType(t *testing.T, name, category string) *models.CertificationType {
+	t.Helper()
+	certType := &models.CertificationType{Name: name, Category: category, IsActive: true}
+	require.NoError(t, f.typeRepo.Create(certType))
+	return certType
+}
+
+func (f *notificationRuleFixture) addRule(t *testing.T, typeID *uuid.UUID, category string, days int) *models.NotificationRule {
+	t.Helper()
+	rule, err := f.svc.CreateRule(&services.CreateNotificationRuleRequest{
+		CertificationTypeID:  typeID,
+		Category:             category,
+		DaysBeforeExpiration: &days,
+	}, uuid.New())
+	require.NoError(t, err)
+	return rule
+}
+
+func ruleDays(rules []models.NotificationRule) []int {
+	days := make([]int, len(rules))
+	for i, r := range rules {
+		days[i] = r.DaysBeforeExpiration
+	}
+	return days
+}
+
+func TestCreateNotificationRule_Defaults(t *testing.T) {
+	f := newNotificationRuleFixture()
+
+	rule := f.addRule(t, nil, "", 30)
+	require.Equal(t, models.NotificationScopeGlobal, rule.Scope())
+	require.True(t, rule.IsActive)
+	require.Equal(t, models.DefaultEmailTemplate, rule.EmailTemplate)
+	require.Equal(t, models.StringList{models.RecipientHolder}, rule.RecipientRoles)
+}
+
+func TestCreateNotificationRule_Validation(t *testing.T) {
+	f := newNotificationRuleFixture()
+	certType := f.addType(t, "First Aid", models.CertificationCategorySafety)
+	inactive := &models.CertificationType{Name: "Retired", Category: models.CertificationCategorySafety}
+	require.NoError(t, f.typeRepo.Create(inactive))
+	f.addRule(t, &certType.ID, "", 45)
+	missing := uuid.New()
+	days := 45
+
+	for name, tc := range map[string]struct {
+		req  services.CreateNotificationRuleRequest
+		want error
+	}{
+		"both scopes":   {services.CreateNotificationRuleRequest{CertificationTypeID: &certType.ID, Category: "safety"}, services.ErrInvalidNotificationScope},
+		"unknown type":  {services.CreateNotificationRuleRequest{CertificationTypeID: &missing}, services.ErrCertificationTypeNotFound},
+		"inactive type": {services.CreateNotificationRuleRequest{CertificationTypeID: &inactive.ID}, services.ErrInactiveCertificationType},
+		"duplicate":     {services.CreateNotificationRuleRequest{CertificationTypeID: &certType.ID}, services.ErrNotificationRuleExists},
+		"no template":   {services.CreateNotificationRuleRequest{EmailTemplate: "default_expiraton"}, services.ErrUnknownEmailTemplate},
+	} {
+		tc.req.DaysBeforeExpiration = &days
+		_, err := f.svc.CreateRule(&tc.req, uuid.New())
+		require.ErrorIs(t, err, tc.want, name)
+	}
+
+	// The same number of days at another level is not a duplicate.
+	f.addRule(t, nil, models.CertificationCategorySafety, 45)
+	f.addRule(t, nil, "", 45)
+}
+
+func TestUpdateNotificationRule(t *testing.T) {
+	f := newNotificationRuleFixture()
+	f.addRule(t, nil, "", 30)
+	rule := f.addRule(t, nil, "", 15)
+
+	taken := 30
+	_, err := f.svc.UpdateRule(rule.ID, &services.UpdateNotificationRuleRequest{DaysBeforeExpiration: &taken})
+	require.ErrorIs(t, err, services.ErrNotificationRuleExists)
+
+	inactive := false
+	roles := []string{models.RecipientAdmin, models.RecipientHolder, models.RecipientAdmin}
+	updated, err := f.svc.UpdateRule(rule.ID, &services.UpdateNotificationRuleRequest{IsActive: &inactive, RecipientRoles: &roles})
+	require.NoError(t, err)
+	require.False(t, updated.IsActive)
+	require.Equal(t, models.StringList{models.RecipientAdmin, models.RecipientHolder}, updated.RecipientRoles)
+
+	missing, withExtension, reset := "welcome", "default_expiration.html", ""
+	_, err = f.svc.UpdateRule(rule.ID, &services.UpdateNotificationRuleRequest{EmailTemplate: &missing})
+	require.ErrorIs(t, err, services.ErrUnknownEmailTemplate)
+	updated, err = f.svc.UpdateRule(rule.ID, &services.UpdateNotificationRuleRequest{EmailTemplate: &withExtension})
+	require.NoError(t, err)
+	require.Equal(t, models.DefaultEmailTemplate, updated.EmailTemplate)
+	updated, err = f.svc.UpdateRule(rule.ID, &services.UpdateNotificationRuleRequest{EmailTemplate: &reset})
+	require.NoError(t, err)
+	require.Equal(t, models.DefaultEmailTemplate, updated.EmailTemplate)
+
+	_, err = f.svc.UpdateRule(uuid.New(), &services.UpdateNotificationRuleRequest{})
+	require.ErrorIs(t, err, services.ErrNotificationRuleNotFound)
+}
+
+func TestResolveRules_Precedence(t *testing.T) {
+	f := newNotificationRuleFixture()
+	firstAid := f.addType(t, "First Aid", models.CertificationCategorySafety)
+	harness := f.addType(t, "Harness", models.CertificationCategorySafety)
+	pmp := f.addType(t, "PMP", models.CertificationCategoryProfessional)
+	for _, days := range []int{1, 7, 15, 30} {
+		f.addRule(t, nil, "", days)
+	}
+	for _, days := range []int{60, 30, 14, 7, 1} {
+		f.addRule(t, nil, models.CertificationCategorySafety, days)
+	}
+	f.addRule(t, &firstAid.ID, "", 90)
+	f.addRule(t, &firstAid.ID, "", 10)
+
+	effective, err := f.svc.ResolveRules(firstAid)
+	require.NoError(t, err)
+	require.Equal(t, models.NotificationScopeType, effective.Scope)
+	require.Equal(t, []int{90, 10}, ruleDays(effective.Rules))
+
+	effective, err = f.svc.ResolveRules(harness)
+	require.NoError(t, err)
+	require.Equal(t, models.NotificationScopeCategory, effective.Scope)
+	require.Equal(t, []int{60, 30, 14, 7, 1}, ruleDays(effective.Rules))
+
+	effective, err = f.svc.ResolveRules(pmp)
+	require.NoError(t, err)
+	require.Equal(t, models.NotificationScopeGlobal, effective.Scope)
+	require.Equal(t, []int{30, 15, 7, 1}, ruleDays(effective.Rules))
+}
+
+func TestResolveRules_InactiveRulesFallThrough(t *testing.T) {
+	f := newNotificationRuleFixture()
+	firstAid := f.addType(t, "First Aid", models.CertificationCategorySafety)
+	f.addRule(t, nil, "", 30)
+	typeRule := f.addRule(t, &firstAid.ID, "", 90)
+
+	inactive := false
+	_, err := f.svc.UpdateRule(typeRule.ID, &services.UpdateNotificationRuleRequest{IsActive: &inactive})
+	require.NoError(t, err)
+
+	effective, err := f.svc.ResolveRules(firstAid)
+	require.NoError(t, err)
+	require.Equal(t, models.NotificationScopeGlobal, effective.Scope)
+	require.Equal(t, []int{30}, ruleDays(effective.Rules))
+
+	empty, err := newNotificationRuleFixture().svc.ResolveRules(firstAid)
+	require.NoError(t, err)
+	require.Empty(t, empty.Scope)
+	require.Empty(t, empty.Rules)
+}
+
+func TestResolveForCertification(t *testing.T) {
+	f := newNotificationRuleFixture()
+	firstAid := f.addType(t, "First Aid", models.CertificationCategorySafety)
+	f.addRule(t, nil, models.CertificationCategorySafety, 60)
+	cert := &models.Certification{
+		CertificationTypeID: firstAid.ID,
+		CertificationType:   firstAid,
+		ExpirationDate:      time.Now().AddDate(1, 0, 0),
+		Status:              models.CertificationStatusActive,
+	}
+	require.NoError(t, f.certRepo.Create(cert))
+
+	effective, err := f.svc.ResolveForCertification(cert.ID)
+	require.NoError(t, err)
+	require.Equal(t, models.NotificationScopeCategory, effective.Scope)
+	require.Equal(t, firstAid.ID, effective.CertificationType.ID)
+
+	_, err = f.svc.ResolveForCertification(uuid.New())
+	require.ErrorIs(t, err, services.ErrCertificationNotFound)
+}
@@ -0,0 +1,268 @@
+package services_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"certitrack/internal/config"
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+	"certitrack/internal/services"
+	"certitrack/testutils/mocks"
+)
+
+type notificationFixture struct {
+	*notificationRuleFixture
+	svc         services.NotificationService
+	outbox      services.NotificationOutboxService
+	digests     services.NotificationDigestService
+	escalations services.NotificationEscalationService
+	repo        *repositories.MockNotificationRepository
+	digestRepo  *repositories.MockNotificationDigestRepository
+	personRepo  *repositories.MockPersonRepository
+	userRepo    *repositories.MockUserRepository
+	mailer      *mocks.MockMailer
+	cfg         *config.Config
+	certType    *models.CertificationType
+	today       time.Time
+}
+
+// newNotificationFixture starts with the seeded global rules of 30, 15, 7
+// and 1 days and one admin user. Deliveries are attempted three times,
+// waiting one minute and then two, up to an hour.
+func newNotificationFixture(t *testing.T) *notificationFixture {
+	f := &notificationFixture{
+		notificationRuleFixture: newNotificationRuleFixture(),
+		repo:                    repositories.NewMockNotificationRepository(),
+		personRepo:              repositories.NewMockPersonRepository(),
+		userRepo:                repositories.NewMockUserRepository(),
+		mailer:                  new(mocks.MockMailer),
+		today:                   time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC),
+	}
+	f.certRepo.WithPeople(f.personRepo)
+	f.repo.WithCertifications(f.certRepo)
+	f.digestRepo = repositories.NewMockNotificationDigestRepository(f.repo)
+	f.cfg = &config.Config{Outbox: config.OutboxConfig{
+		BatchSize:      10,
+		MaxAttempts:    3,
+		RetryBaseDelay: time.Minute,
+		RetryMaxDelay:  time.Hour,
+	}}
+	f.newService()
+	f.certType = f.addType(t, "Forklift", models.CertificationCategorySafety)
+	for _, days := range []int{30, 15, 7, 1} {
+		f.addRule(t, nil, "", days)
+	}
+	require.NoError(t, f.userRepo.CreateUser(&models.User{Email: "admin@example.com", Role: "admin", IsActive: true}))
+	require.NoError(t, f.userRepo.CreateUser(&models.User{Email: "user@example.com", Role: "user", IsActive: true}))
+	return f
+}
+
+func (f *notificationFixture) newService() {
+	f.svc = services.NewNotificationService(f.repo, f.certRepo, f.typeRepo, f.userRepo, f.notificationRuleFixture.svc)
+	f.outbox = services.NewNotificationOutboxService(f.repo, f.mailer, f.cfg)
+	f.digests = services.NewNotificationDigestService(f.digestRepo, f.userRepo, f.mailer, f.cfg)
+	f.escalations = services.NewNotificationEscalationService(f.repo, f.personRepo, f.userRepo, f.mailer, f.cfg)
+}
+
+func (f *notificationFixture) addPerson(t *testing.T, email string) *models.Person {
+	t.Helper()
+	person := &models.Person{FirstName: "Dana", LastName: "Reyes", Email: email, IsActive: true}
+	require.NoError(t, f.personRepo.Create(person))
+	return person
+}
+
+func (f *notificationFixture) addCert(t *testing.T, personID, equipmentID *uuid.UUID, daysLeft int, status string) *models.Certification {
+	t.Helper()
+	expiration := time.Date(f.today.Year(), f.today.Month(), f.today.Day()+daysLeft, 0, 0, 0, 0, time.UTC)
+	// The type and holder are stored along, as the real repository
+	// preloads them when a notification is claimed.
+	cert := &models.Certification{
+		CertificationTypeID: f.certType.ID,
+		CertificationType:   f.certType,
+		PersonID:            personID,
+		EquipmentID:         equipmentID,
+		IssueDate:           expiration.AddDate(-1, 0, 0),
+		ExpirationDate:      expiration,
+		Status:              status,
+	}
+	if personID != nil {
+		person, err := f.personRepo.FindByID(*personID)
+		require.NoError(t, err)
+		cert.Person = person
+	}
+	require.NoError(t, f.certRepo.Create(cert))
+	return cert
+}
+
+func (f *notificationFixture) run(t *testing.T, asOf time.Time) *services.ReminderScheduleReport {
+	t.Helper()
+	report, err := f.svc.ScheduleExpirationReminders(context.Background(), asOf)
+	require.NoError(t, err)
+	return report
+}
+
+func TestScheduleExpirationReminders_SchedulesLatestCrossedThreshold(t *testing.T) {
+	f := newNotificationFixture(t)
+	person := f.addPerson(t, "dana@example.com")
+	cert := f.addCert(t, &person.ID, nil, 10, models.CertificationStatusActive)
+
+	report := f.run(t, f.today)
+
+	require.Equal(t, &services.ReminderScheduleReport{Checked: 1, Scheduled: 1}, report)
+	scheduled := f.repo.All()
+	require.Len(t, scheduled, 1)
+	require.Equal(t, cert.ID, scheduled[0].CertificationID)
+	require.Equal(t, 15, scheduled[0].DaysBeforeExpiration)
+	require.Equal(t, models.StringList{"dana@example.com"}, scheduled[0].Recipients)
+	require.Equal(t, models.DefaultEmailTemplate, scheduled[0].EmailTemplate)
+	require.Equal(t, models.NotificationStatusPending, scheduled[0].Status)
+	require.Equal(t, cert.ExpirationDate.AddDate(0, 0, -15), scheduled[0].ScheduledFor)
+
+	// Four days later the 7-day threshold has been crossed.
+	report = f.run(t, f.today.AddDate(0, 0, 4))
+	require.Equal(t, 1, report.Scheduled)
+	scheduled = f.repo.All()
+	require.Len(t, scheduled, 2)
+	require.Equal(t, 7, scheduled[1].DaysBeforeExpiration)
+}
+
+func TestScheduleExpirationReminders_Idempotent(t *testing.T) {
+	f := newNotificationFixture(t)
+	person := f.addPerson(t, "dana@example.com")
+	f.addCert(t, &person.ID, nil, 30, models.CertificationStatusActive)
+
+	require.Equal(t, 1, f.run(t, f.today).Scheduled)
+	report := f.run(t, f.today.Add(time.Hour))
+
+	require.Equal(t, &services.ReminderScheduleReport{Checked: 1, AlreadyScheduled: 1}, report)
+	require.Len(t, f.repo.All(), 1)
+}
+
+func TestScheduleExpirationReminders_ConcurrentRuns(t *testing.T) {
+	f := newNotificationFixture(t)
+	person := f.addPerson(t, "dana@example.com")
+	for i := 0; i < 5; i++ {
+		f.addCert(t, &person.ID, nil, 1+i, models.CertificationStatusActive)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := f.svc.ScheduleExpirationReminders(context.Background(), f.today)
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, f.repo.All(), 5)
+}
+
+func TestScheduleExpirationReminders_ChangedThresholdIsScheduledAgain(t *testing.T) {
+	f := newNotificationFixture(t)
+	person := f.addPerson(t, "dana@example.com")
+	f.addCert(t, &person.ID, nil, 3, models.CertificationStatusActive)
+	require.Equal(t, 1, f.run(t, f.today).Scheduled)
+
+	rules, err := f.notificationRuleFixture.svc.ListRules(&services.ListNotificationRulesRequest{})
+	require.NoError(t, err)
+	for _, rule := range rules.Items {
+		if rule.DaysBeforeExpiration == 7 {
+			days := 5
+			_, err := f.notificationRuleFixture.svc.UpdateRule(rule.ID, &services.UpdateNotificationRuleRequest{DaysBeforeExpiration: &days})
+			require.NoError(t, err)
+		}
+	}
+
+	require.Equal(t, 1, f.run(t, f.today).Scheduled)
+	require.Len(t, f.repo.All(), 2)
+}
+
+func TestScheduleExpirationReminders_SkipsCertificationsWithoutDueReminder(t *testing.T) {
+	f := newNotificationFixture(t)
+	person := f.addPerson(t, "dana@example.com")
+	f.addCert(t, &person.ID, nil, 45, models.CertificationStatusActive)
+	f.addCert(t, &person.ID, nil, -1, models.CertificationStatusActive)
+	f.addCert(t, &person.ID, nil, 5, models.CertificationStatusRevoked)
+	inactive := f.addPerson(t, "gone@example.com")
+	inactive.IsActive = false
+	require.NoError(t, f.personRepo.Update(inactive))
+	f.addCert(t, &inactive.ID, nil, 5, models.CertificationStatusActive)
+
+	report := f.run(t, f.today)
+
+	require.Equal(t, &services.ReminderScheduleReport{Checked: 1}, report)
+	require.Empty(t, f.repo.All())
+}
+
+func TestScheduleExpirationReminders_Recipients(t *testing.T) {
+	f := newNotificationFixture(t)
+	withEmail := f.addPerson(t, "dana@example.com")
+	withoutEmail := f.addPerson(t, "")
+	equipmentID := uuid.New()
+	safety := f.addType(t, "Confined Space", models.CertificationCategorySafety)
+	safetyDays := 60
+	_, err := f.notificationRuleFixture.svc.CreateRule(&services.CreateNotificationRuleRequest{
+		CertificationTypeID:  &safety.ID,
+		DaysBeforeExpiration: &safetyDays,
+		RecipientRoles:       []string{"holder", "admin"},
+	}, uuid.New())
+	require.NoError(t, err)
+
+	f.addCert(t, nil, &equipmentID, 10, models.CertificationStatusActive)
+	f.addCert(t, &withoutEmail.ID, nil, 10, models.CertificationStatusActive)
+	f.certType = safety
+	both := f.addCert(t, &withEmail.ID, nil, 50, models.CertificationStatusActive)
+
+	report := f.run(t, f.today)
+
+	require.Equal(t, &services.ReminderScheduleReport{Checked: 3, Scheduled: 2, NoRecipients: 1}, report)
+	recipients := make(map[uuid.UUID]models.StringList)
+	for _, n := range f.repo.All() {
+		recipients[n.CertificationID] = n.Recipients
+	}
+	require.Equal(t, models.StringList{"dana@example.com", "admin@example.com"}, recipients[both.ID])
+	for id, list := range recipients {
+		if id != both.ID {
+			require.Equal(t, models.StringList{"admin@example.com"}, list)
+		}
+	}
+}
+
+func TestScheduleExpirationReminders_DigestDelivery(t *testing.T) {
+	f := newNotificationFixture(t)
+	f.addDigestRule(t)
+	dana := f.addPerson(t, "dana@example.com")
+	sam := f.addPerson(t, "sam@example.com")
+	require.NoError(t, f.userRepo.CreateUser(&models.User{Email: "sam@example.com", Role: "user", IsActive: true, NotificationDelivery: models.DeliveryImmediate}))
+	digestOnly := f.addCert(t, &dana.ID, nil, 40, models.CertificationStatusActive)
+	mixed := f.addCert(t, &sam.ID, nil, 40, models.CertificationStatusActive)
+	// Next week's digest would come too late for this one.
+	soon := f.addCert(t, &dana.ID, nil, 6, models.CertificationStatusActive)
+
+	require.Equal(t, 3, f.run(t, f.today).Scheduled)
+
+	byCert := make(map[uuid.UUID]models.Notification)
+	for _, n := range f.repo.All() {
+		byCert[n.CertificationID] = n
+	}
+	require.Equal(t, models.NotificationStatusDigest, byCert[digestOnly.ID].Status)
+	require.Empty(t, byCert[digestOnly.ID].Recipients)
+	require.Equal(t, models.StringList{"dana@example.com", "admin@example.com"}, byCert[digestOnly.ID].DigestRecipients)
+
+	// Sam's own preference overrides the rule.
+	require.Equal(t, models.NotificationStatusPending, byCert[mixed.ID].Status)
+	require.Equal(t, models.StringList{"sam@example.com"}, byCert[mixed.ID].Recipients)
+	require.Equal(t, models.StringList{"admin@example.com"}, byCert[mixed.ID].DigestRecipients)
+
+	require.Equal(t, models.NotificationStatusPending, byCert[soon.ID].Status)
+	require.Equal(t, models.StringList{"dana@example.com", "admin@example.com"}, byCert[soon.ID].Recipients)
+	require.Empty(t, byCert[soon.ID].DigestRecipients)
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OperatorAuthorizationService answers who may operate a piece of equipment,
+// and what a person may operate, from the operator types of equipment classes
+// and the certifications people currently hold.
+type OperatorAuthorizationService interface {
+	ListAuthorizedOperators(equipmentID uuid.UUID, req *PageRequest) (*AuthorizedOperatorListResponse, error)
+	ListAuthorizedEquipment(personID uuid.UUID, req *PageRequest) (*AuthorizedEquipmentListResponse, error)
+}
+
+type OperatorAuthorizationServiceImpl struct {
+	equipmentRepo repositories.EquipmentRepository
+	classRepo     repositories.EquipmentClassRepository
+	personRepo    repositories.PersonRepository
+	certRepo      repositories.CertificationRepository
+}
+
+var _ OperatorAuthorizationService = (*OperatorAuthorizationServiceImpl)(nil)
+
+// AuthorizedOperator is a person holding a valid certification of every
+// operator type. ValidUntil is the earliest of their expiration dates, after
+// which the authorization lapses unless renewed.
+type AuthorizedOperator struct {
+	Person         *models.Person         `json:"person"`
+	Certifications []models.Certification `json:"certifications"`
+	ValidUntil     time.Time              `json:"validUntil"`
+}
+
+type AuthorizedOperatorListResponse struct {
+	// OperatorTypes are the active certification types an operator needs.
+	// Equipment without any has no authorized operators.
+	OperatorTypes []models.CertificationType `json:"operatorTypes"`
+	Items         []AuthorizedOperator       `json:"items"`
+	Pagination    Pagination                 `json:"pagination"`
+}
+
+// AuthorizedEquipment is a piece of equipment a person may operate, with the
+// certifications that authorize them.
+type AuthorizedEquipment struct {
+	Equipment      *models.Equipment      `json:"equipment"`
+	Certifications []models.Certification `json:"certifications"`
+	ValidUntil     time.Time              `json:"validUntil"`
+}
+
+type AuthorizedEquipmentListResponse struct {
+	Items      []AuthorizedEquipment `json:"items"`
+	Pagination Pagination            `json:"pagination"`
+}
+
+func NewOperatorAuthorizationService(
+	equipmentRepo repositories.EquipmentRepository,
+	classRepo repositories.EquipmentClassRepository,
+	personRepo repositories.PersonRepository,
+	certRepo repositories.CertificationRepository,
+) *OperatorAuthorizationServiceImpl {
+	return &OperatorAuthorizationServiceImpl{
+		equipmentRepo: equipmentRepo,
+		classRepo:     classRepo,
+		personRepo:    personRepo,
+		certRepo:      certRepo,
+	}
+}
+
+// ListAuthorizedOperators returns the active people currently certified to
+// operate the equipment, ordered by name.
+func (s *OperatorAuthorizationServiceImpl) ListAuthorizedOperators(equipmentID uuid.UUID, req *PageRequest) (*AuthorizedOperatorListResponse, error) {
+	equipment, err := s.equipmentRepo.FindByID(equipmentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEquipmentNotFound
+		}
+		return nil, err
+	}
+
+	operatorTypes := []models.CertificationType{}
+	var typeIDs []uuid.UUID
+	if equipment.Class != nil {
+		for _, certType := range equipment.Class.OperatorTypes {
+			if certType.IsActive {
+				operatorTypes = append(operatorTypes, certType)
+				typeIDs = append(typeIDs, certType.ID)
+			}
+		}
+	}
+
+	certs, err := s.certRepo.FindActiveHeldByPeople(typeIDs)
+	if err != nil {
+		return nil, err
+	}
+	people := make(map[uuid.UUID]*models.Person)
+	certsByPerson := make(map[uuid.UUID][]models.Certification)
+	for _, c := range certs {
+		people[*c.PersonID] = c.Person
+		certsByPerson[*c.PersonID] = append(certsByPerson[*c.PersonID], c)
+	}
+
+	now := time.Now()
+	operators := []AuthorizedOperator{}
+	for personID, held := range certsByPerson {
+		authorizing, ok := equipment.Class.AuthorizeOperator(held, now)
+		if !ok {
+			continue
+		}
+		operators = append(operators, AuthorizedOperator{
+			Person:         people[personID],
+			Certifications: authorizing,
+			ValidUntil:     earliestExpiration(authorizing),
+		})
+	}
+	sort.Slice(operators, func(i, j int) bool {
+		a, b := operators[i].Person, operators[j].Person
+		if a.LastName != b.LastName {
+			return a.LastName < b.LastName
+		}
+		if a.FirstName != b.FirstName {
+			return a.FirstName < b.FirstName
+		}
+		return a.ID.String() < b.ID.String()
+	})
+
+	items, pagination := pageOf(operators, *req)
+	return &AuthorizedOperatorListResponse{
+		OperatorTypes: operatorTypes,
+		Items:         items,
+		Pagination:    pagination,
+	}, nil
+}
+
+// ListAuthorizedEquipment returns the active equipment the person is currently
+// certified to operate, ordered by asset number. Inactive people may operate
+// nothing.
+func (s *OperatorAuthorizationServiceImpl) ListAuthorizedEquipment(personID uuid.UUID, req *PageRequest) (*AuthorizedEquipmentListResponse, error) {
+	person, err := s.personRepo.FindByID(personID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPersonNotFound
+		}
+		return nil, err
+	}
+
+	authorized := []AuthorizedEquipment{}
+	if person.IsActive {
+		authorized, err = s.authorizedEquipment(personID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	items, pagination := pageOf(authorized, *req)
+	return &AuthorizedEquipmentListResponse{
+		Items:      items,
+		Pagination: pagination,
+	}, nil
+}
+
+func (s *OperatorAuthorizationServiceImpl) authorizedEquipment(personID uuid.UUID) ([]AuthorizedEquipment, error) {
+	classes, err := s.classRepo.FindWithOperatorTypes()
+	if err != nil {
+		return nil, err
+	}
+	certs, err := s.certRepo.FindForPeople([]uuid.UUID{personID})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	byClass := make(map[uuid.UUID][]models.Certification)
+	var classIDs []uuid.UUID
+	for i := range classes {
+		authorizing, ok := classes[i].AuthorizeOperator(certs, now)
+		if !ok {
+			continue
+		}
+		byClass[classes[i].ID] = authorizing
+		classIDs = append(classIDs, classes[i].ID)
+	}
+
+	equipment, err := s.equipmentRepo.FindActiveInClasses(classIDs)
+	if err != nil {
+		return nil, err
+	}
+	authorized := []AuthorizedEquipment{}
+	for i := range equipment {
+		authorizing := byClass[*equipment[i].ClassID]
+		authorized = append(authorized, AuthorizedEquipment{
+			Equipment:      &equipment[i],
+			Certifications: authorizing,
+			ValidUntil:     earliestExpiration(authorizing),
+		})
+	}
+	return authorized, nil
+}
+
+func earliestExpiration(certs []models.Certification) time.Time {
+	var earliest time.Time
+	for i, c := range certs {
+		if i == 0 || c.ExpirationDate.Before(earliest) {
+			earliest = c.ExpirationDate
+		}
+	}
+	return earliest
+}
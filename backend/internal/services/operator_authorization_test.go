@@ -0,0 +1,140 @@
+package services_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+)
+
+func (f *equipmentClassFixture) addOperatorClass(t *testing.T, name string, types ...*models.CertificationType) *models.EquipmentClass {
+	t.Helper()
+	ids := make([]uuid.UUID, 0, len(types))
+	for _, certType := range types {
+		ids = append(ids, certType.ID)
+	}
+	class, err := f.classes.CreateClass(&services.CreateEquipmentClassRequest{Name: name, OperatorTypeIDs: ids}, uuid.New())
+	require.NoError(t, err)
+	return class
+}
+
+func (f *equipmentClassFixture) addOperator(t *testing.T, last string, active bool) *models.Person {
+	t.Helper()
+	person := &models.Person{FirstName: "Sam", LastName: last, IsActive: active}
+	require.NoError(t, f.personRepo.Create(person))
+	return person
+}
+
+func (f *equipmentClassFixture) certify(t *testing.T, person *models.Person, certType *models.CertificationType, status string, expiresInDays int) {
+	t.Helper()
+	expiration := time.Now().UTC().AddDate(0, 0, expiresInDays)
+	require.NoError(t, f.certRepo.Create(&models.Certification{
+		CertificationTypeID: certType.ID,
+		PersonID:            &person.ID,
+		IssueDate:           expiration.AddDate(-1, 0, 0),
+		ExpirationDate:      expiration,
+		Status:              status,
+	}))
+}
+
+func TestListAuthorizedOperators(t *testing.T) {
+	f := newEquipmentClassFixture()
+	licence := f.addType(t, "Forklift Licence")
+	medical := f.addType(t, "Operator Medical")
+	class := f.addOperatorClass(t, "Forklifts", licence, medical)
+	forklift := f.addEquipment(t, "FL-1", class)
+
+	both := f.addOperator(t, "Baker", true)
+	f.certify(t, both, licence, models.CertificationStatusActive, 300)
+	f.certify(t, both, medical, models.CertificationStatusActive, 40)
+	licenceOnly := f.addOperator(t, "Clark", true)
+	f.certify(t, licenceOnly, licence, models.CertificationStatusActive, 300)
+	lapsed := f.addOperator(t, "Davis", true)
+	f.certify(t, lapsed, licence, models.CertificationStatusActive, 300)
+	f.certify(t, lapsed, medical, models.CertificationStatusActive, -1)
+	revoked := f.addOperator(t, "Evans", true)
+	f.certify(t, revoked, licence, models.CertificationStatusRevoked, 300)
+	f.certify(t, revoked, medical, models.CertificationStatusActive, 300)
+	inactive := f.addOperator(t, "Adams", false)
+	f.certify(t, inactive, licence, models.CertificationStatusActive, 300)
+	f.certify(t, inactive, medical, models.CertificationStatusActive, 300)
+
+	resp, err := f.authorization.ListAuthorizedOperators(forklift.ID, &services.PageRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.OperatorTypes, 2)
+	require.Len(t, resp.Items, 1)
+	require.Equal(t, both.ID, resp.Items[0].Person.ID)
+	require.Len(t, resp.Items[0].Certifications, 2)
+	require.Equal(t, time.Now().UTC().AddDate(0, 0, 40).Format(services.DateLayout), resp.Items[0].ValidUntil.Format(services.DateLayout))
+}
+
+func TestListAuthorizedOperators_NoOperatorTypes(t *testing.T) {
+	f := newEquipmentClassFixture()
+	equipment := f.addEquipment(t, "EQ-1", nil)
+	person := f.addOperator(t, "Baker", true)
+	f.certify(t, person, f.addType(t, "Forklift Licence"), models.CertificationStatusActive, 300)
+
+	resp, err := f.authorization.ListAuthorizedOperators(equipment.ID, &services.PageRequest{})
+	require.NoError(t, err)
+	require.Empty(t, resp.OperatorTypes)
+	require.Empty(t, resp.Items)
+
+	_, err = f.authorization.ListAuthorizedOperators(uuid.New(), &services.PageRequest{})
+	require.ErrorIs(t, err, services.ErrEquipmentNotFound)
+}
+
+func TestListAuthorizedEquipment(t *testing.T) {
+	f := newEquipmentClassFixture()
+	licence := f.addType(t, "Forklift Licence")
+	crane := f.addType(t, "Crane Operator")
+	forklifts := f.addOperatorClass(t, "Forklifts", licence)
+	cranes := f.addOperatorClass(t, "Cranes", crane)
+	f.addEquipment(t, "FL-2", forklifts)
+	f.addEquipment(t, "FL-1", forklifts)
+	f.addEquipment(t, "CR-1", cranes)
+	retired := f.addEquipment(t, "FL-3", forklifts)
+	require.NoError(t, f.equipment.DeactivateEquipment(retired.ID, uuid.New()))
+
+	person := f.addOperator(t, "Baker", true)
+	f.certify(t, person, licence, models.CertificationStatusActive, 100)
+	f.certify(t, person, crane, models.CertificationStatusPending, 100)
+
+	resp, err := f.authorization.ListAuthorizedEquipment(person.ID, &services.PageRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), resp.Pagination.Total)
+	require.Equal(t, "FL-1", resp.Items[0].Equipment.AssetNumber)
+	require.Equal(t, "FL-2", resp.Items[1].Equipment.AssetNumber)
+
+	person.IsActive = false
+	require.NoError(t, f.personRepo.Update(person))
+	resp, err = f.authorization.ListAuthorizedEquipment(person.ID, &services.PageRequest{})
+	require.NoError(t, err)
+	require.Empty(t, resp.Items)
+
+	_, err = f.authorization.ListAuthorizedEquipment(uuid.New(), &services.PageRequest{})
+	require.ErrorIs(t, err, services.ErrPersonNotFound)
+}
+
+func TestListAuthorizedEquipment_IgnoresInactiveOperatorTypes(t *testing.T) {
+	f := newEquipmentClassFixture()
+	licence := f.addType(t, "Forklift Licence")
+	retiredType := f.addType(t, "Legacy Permit")
+	class := f.addOperatorClass(t, "Forklifts", licence, retiredType)
+	f.addEquipment(t, "FL-1", class)
+	person := f.addOperator(t, "Baker", true)
+	f.certify(t, person, licence, models.CertificationStatusActive, 100)
+
+	resp, err := f.authorization.ListAuthorizedEquipment(person.ID, &services.PageRequest{})
+	require.NoError(t, err)
+	require.Empty(t, resp.Items)
+
+	retiredType.IsActive = false
+	require.NoError(t, f.typeRepo.Update(retiredType))
+	resp, err = f.authorization.ListAuthorizedEquipment(person.ID, &services.PageRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 1)
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"certitrack/internal/repositories"
+
+	"github.com/google/uuid"
+)
+
+type Pagination struct {
+	Page       int   `json:"page"`
+	Limit      int   `json:"limit"`
+	Total      int64 `json:"total"`
+	TotalPages int   `json:"totalPages"`
+}
+
+type PageRequest struct {
+	Page  int `form:"page" binding:"omitempty,min=1"`
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100"`
+}
+
+func (r PageRequest) toPagination() repositories.Pagination {
+	return repositories.Pagination{Page: r.Page, Limit: r.Limit}.Normalize()
+}
+
+// queryID parses an ID filter bound from a query string, where gin cannot
+// bind a uuid.UUID. Binding validates the format, so a bad value means no
+// filter.
+func queryID(value string) *uuid.UUID {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
+func newPagination(p repositories.Pagination, total int64) Pagination {
+	p = p.Normalize()
+	totalPages := int((total + int64(p.Limit) - 1) / int64(p.Limit))
+	return Pagination{
+		Page:       p.Page,
+		Limit:      p.Limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+}
+
+// pageOf returns the requested page of results computed in memory.
+func pageOf[T any](items []T, r PageRequest) ([]T, Pagination) {
+	p := r.toPagination()
+	start := min(p.Offset(), len(items))
+	end := min(start+p.Limit, len(items))
+	return items[start:end], newPagination(p, int64(len(items)))
+}
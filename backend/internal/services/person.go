@@ -0,0 +1,261 @@
+package services
+
+import (
+	"errors"
+
+	"certitrack/internal/models"
+	"certitrack/internal/repositories"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PersonService interface {
+	CreatePerson(req *CreatePersonRequest, actorID uuid.UUID) (*models.Person, error)
+	GetPerson(id uuid.UUID) (*models.Person, error)
+	ListPeople(req *ListPeopleRequest) (*PersonListResponse, error)
+	UpdatePerson(id uuid.UUID, req *UpdatePersonRequest, actorID uuid.UUID) (*models.Person, error)
+	DeactivatePerson(id uuid.UUID, actorID uuid.UUID) error
+}
+
+type PersonServiceImpl struct {
+	repository repositories.PersonRepository
+}
+
+var _ PersonService = (*PersonServiceImpl)(nil)
+
+type CreatePersonRequest struct {
+	EmployeeID string `json:"employee_id" binding:"omitempty,max=50"`
+	FirstName  string `json:"first_name" binding:"required,min=1,max=100"`
+	LastName   string `json:"last_name" binding:"required,min=1,max=100"`
+	Email      string `json:"email" binding:"omitempty,email,max=255"`
+	Phone      string `json:"phone" binding:"omitempty,max=20"`
+	Department string `json:"department" binding:"omitempty,max=100"`
+	Position   string `json:"position" binding:"omitempty,max=100"`
+	HireDate   string `json:"hire_date" binding:"omitempty,datetime=2006-01-02"`
+	// SupervisorID and IsDepartmentHead set who the person's unrenewed
+	// certifications are escalated to.
+	SupervisorID     *uuid.UUID `json:"supervisor_id"`
+	IsDepartmentHead bool       `json:"is_department_head"`
+}
+
+type UpdatePersonRequest struct {
+	EmployeeID *string `json:"employee_id" binding:"omitempty,max=50"`
+	FirstName  *string `json:"first_name" binding:"omitempty,min=1,max=100"`
+	LastName   *string `json:"last_name" binding:"omitempty,min=1,max=100"`
+	Email      *string `json:"email" binding:"omitempty,email,max=255"`
+	Phone      *string `json:"phone" binding:"omitempty,max=20"`
+	Department *string `json:"department" binding:"omitempty,max=100"`
+	Position   *string `json:"position" binding:"omitempty,max=100"`
+	HireDate   *string `json:"hire_date" binding:"omitempty,datetime=2006-01-02"`
+	// SupervisorID is cleared with an empty string.
+	SupervisorID     *string `json:"supervisor_id" binding:"omitempty,max=36"`
+	IsDepartmentHead *bool   `json:"is_department_head"`
+	IsActive         *bool   `json:"is_active"`
+}
+
+type ListPeopleRequest struct {
+	PageRequest
+	Search     string `form:"search"`
+	Department string `form:"department"`
+	IsActive   *bool  `form:"isActive"`
+}
+
+type PersonListResponse struct {
+	Items      []models.Person `json:"items"`
+	Pagination Pagination      `json:"pagination"`
+}
+
+var (
+	ErrPersonNotFound    = errors.New("person not found")
+	ErrEmployeeIDExists  = errors.New("person with this employee ID already exists")
+	ErrInvalidPersonData = errors.New("invalid person data")
+	ErrInvalidSupervisor = errors.New("supervisor must be another existing person")
+)
+
+func NewPersonService(repository repositories.PersonRepository) *PersonServiceImpl {
+	return &PersonServiceImpl{
+		repository: repository,
+	}
+}
+
+func (s *PersonServiceImpl) CreatePerson(req *CreatePersonRequest, actorID uuid.UUID) (*models.Person, error) {
+	if req.EmployeeID != "" && s.repository.EmployeeIDExists(req.EmployeeID, uuid.Nil) {
+		return nil, ErrEmployeeIDExists
+	}
+
+	hireDate, err := parseDate(req.HireDate)
+	if err != nil {
+		return nil, ErrInvalidPersonData
+	}
+
+	person := models.Person{
+		FirstName:        req.FirstName,
+		LastName:         req.LastName,
+		Email:            req.Email,
+		Phone:            req.Phone,
+		Department:       req.Department,
+		Position:         req.Position,
+		HireDate:         hireDate,
+		IsDepartmentHead: req.IsDepartmentHead,
+		IsActive:         true,
+		CreatedBy:        nullableID(actorID),
+		UpdatedBy:        nullableID(actorID),
+	}
+	if req.EmployeeID != "" {
+		person.EmployeeID = &req.EmployeeID
+	}
+	if req.SupervisorID != nil {
+		if err := s.checkSupervisor(*req.SupervisorID, uuid.Nil); err != nil {
+			return nil, err
+		}
+		person.SupervisorID = req.SupervisorID
+	}
+
+	if err := s.repository.Create(&person); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrEmployeeIDExists
+		}
+		return nil, err
+	}
+
+	return &person, nil
+}
+
+func (s *PersonServiceImpl) GetPerson(id uuid.UUID) (*models.Person, error) {
+	person, err := s.repository.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPersonNotFound
+		}
+		return nil, err
+	}
+	return person, nil
+}
+
+func (s *PersonServiceImpl) ListPeople(req *ListPeopleRequest) (*PersonListResponse, error) {
+	page := req.toPagination()
+	people, total, err := s.repository.List(repositories.PersonFilter{
+		Pagination: page,
+		Search:     req.Search,
+		Department: req.Department,
+		IsActive:   req.IsActive,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &PersonListResponse{
+		Items:      people,
+		Pagination: newPagination(page, total),
+	}, nil
+}
+
+func (s *PersonServiceImpl) UpdatePerson(id uuid.UUID, req *UpdatePersonRequest, actorID uuid.UUID) (*models.Person, error) {
+	person, err := s.GetPerson(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.EmployeeID != nil {
+		if *req.EmployeeID == "" {
+			person.EmployeeID = nil
+		} else {
+			if s.repository.EmployeeIDExists(*req.EmployeeID, person.ID) {
+				return nil, ErrEmployeeIDExists
+			}
+			person.EmployeeID = req.EmployeeID
+		}
+	}
+	if req.FirstName != nil {
+		person.FirstName = *req.FirstName
+	}
+	if req.LastName != nil {
+		person.LastName = *req.LastName
+	}
+	if req.Email != nil {
+		person.Email = *req.Email
+	}
+	if req.Phone != nil {
+		person.Phone = *req.Phone
+	}
+	if req.Department != nil {
+		person.Department = *req.Department
+	}
+	if req.Position != nil {
+		person.Position = *req.Position
+	}
+	if req.HireDate != nil {
+		hireDate, err := parseDate(*req.HireDate)
+		if err != nil {
+			return nil, ErrInvalidPersonData
+		}
+		person.HireDate = hireDate
+	}
+	if req.SupervisorID != nil {
+		if *req.SupervisorID == "" {
+			person.SupervisorID = nil
+		} else {
+			supervisorID, err := uuid.Parse(*req.SupervisorID)
+			if err != nil {
+				return nil, ErrInvalidSupervisor
+			}
+			if err := s.checkSupervisor(supervisorID, person.ID); err != nil {
+				return nil, err
+			}
+			person.SupervisorID = &supervisorID
+		}
+	}
+	if req.IsDepartmentHead != nil {
+		person.IsDepartmentHead = *req.IsDepartmentHead
+	}
+	if req.IsActive != nil {
+		person.IsActive = *req.IsActive
+	}
+	person.UpdatedBy = nullableID(actorID)
+
+	if err := s.repository.Update(person); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrEmployeeIDExists
+		}
+		return nil, err
+	}
+
+	return person, nil
+}
+
+// DeactivatePerson performs a soft delete: people are never removed so that
+// their certification history stays intact.
+func (s *PersonServiceImpl) DeactivatePerson(id uuid.UUID, actorID uuid.UUID) error {
+	person, err := s.GetPerson(id)
+	if err != nil {
+		return err
+	}
+
+	person.IsActive = false
+	person.UpdatedBy = nullableID(actorID)
+
+	return s.repository.Update(person)
+}
+
+func nullableID(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
+
+// checkSupervisor makes sure the supervisor exists and is not the person
+// themselves.
+func (s *PersonServiceImpl) checkSupervisor(supervisorID, personID uuid.UUID) error {
+	if supervisorID == personID {
+		return ErrInvalidSupervisor
+	}
+	if _, err := s.repository.FindByID(supervisorID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidSupervisor
+		}
+		return err
+	}
+	return nil
+}
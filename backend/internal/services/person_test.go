@@ -0,0 +1,148 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"certitrack/internal/repositories"
+	"certitrack/internal/services"
+)
+
+func newPersonService() (services.PersonService, *repositories.MockPersonRepository) {
+	repo := repositories.NewMockPersonRepository()
+	return services.NewPersonService(repo), repo
+}
+
+func TestCreatePerson_Success(t *testing.T) {
+	svc, _ := newPersonService()
+	actorID := uuid.New()
+
+	person, err := svc.CreatePerson(&services.CreatePersonRequest{
+		EmployeeID: "EMP001",
+		FirstName:  "John",
+		LastName:   "Doe",
+		Department: "Engineering",
+		HireDate:   "2023-01-15",
+	}, actorID)
+
+	require.NoError(t, err)
+	require.NotEqual(t, uuid.Nil, person.ID)
+	require.Equal(t, "EMP001", *person.EmployeeID)
+	require.True(t, person.IsActive)
+	require.Equal(t, actorID, *person.CreatedBy)
+	require.Equal(t, "2023-01-15", person.HireDate.Format(services.DateLayout))
+}
+
+func TestCreatePerson_DuplicateEmployeeID(t *testing.T) {
+	svc, _ := newPersonService()
+	req := &services.CreatePersonRequest{EmployeeID: "EMP001", FirstName: "John", LastName: "Doe"}
+
+	_, err := svc.CreatePerson(req, uuid.New())
+	require.NoError(t, err)
+
+	_, err = svc.CreatePerson(req, uuid.New())
+	require.ErrorIs(t, err, services.ErrEmployeeIDExists)
+}
+
+func TestCreatePerson_InvalidHireDate(t *testing.T) {
+	svc, _ := newPersonService()
+
+	_, err := svc.CreatePerson(&services.CreatePersonRequest{
+		FirstName: "John",
+		LastName:  "Doe",
+		HireDate:  "15/01/2023",
+	}, uuid.New())
+	require.ErrorIs(t, err, services.ErrInvalidPersonData)
+}
+
+func TestGetPerson_NotFound(t *testing.T) {
+	svc, _ := newPersonService()
+
+	_, err := svc.GetPerson(uuid.New())
+	require.ErrorIs(t, err, services.ErrPersonNotFound)
+}
+
+func TestUpdatePerson_ChangesFieldsAndActor(t *testing.T) {
+	svc, _ := newPersonService()
+	person, err := svc.CreatePerson(&services.CreatePersonRequest{FirstName: "John", LastName: "Doe"}, uuid.New())
+	require.NoError(t, err)
+
+	editor := uuid.New()
+	position := "Electrician"
+	updated, err := svc.UpdatePerson(person.ID, &services.UpdatePersonRequest{Position: &position}, editor)
+	require.NoError(t, err)
+	require.Equal(t, "Electrician", updated.Position)
+	require.Equal(t, "John", updated.FirstName)
+	require.Equal(t, editor, *updated.UpdatedBy)
+}
+
+func TestUpdatePerson_EmployeeIDTakenByOther(t *testing.T) {
+	svc, _ := newPersonService()
+	_, err := svc.CreatePerson(&services.CreatePersonRequest{EmployeeID: "EMP001", FirstName: "A", LastName: "A"}, uuid.Nil)
+	require.NoError(t, err)
+	other, err := svc.CreatePerson(&services.CreatePersonRequest{EmployeeID: "EMP002", FirstName: "B", LastName: "B"}, uuid.Nil)
+	require.NoError(t, err)
+
+	taken := "EMP001"
+	_, err = svc.UpdatePerson(other.ID, &services.UpdatePersonRequest{EmployeeID: &taken}, uuid.Nil)
+	require.ErrorIs(t, err, services.ErrEmployeeIDExists)
+
+	same := "EMP002"
+	_, err = svc.UpdatePerson(other.ID, &services.UpdatePersonRequest{EmployeeID: &same}, uuid.Nil)
+	require.NoError(t, err)
+}
+
+func TestDeactivatePerson_SoftDeletes(t *testing.T) {
+	svc, repo := newPersonService()
+	person, err := svc.CreatePerson(&services.CreatePersonRequest{FirstName: "John", LastName: "Doe"}, uuid.Nil)
+	require.NoError(t, err)
+
+	require.NoError(t, svc.DeactivatePerson(person.ID, uuid.New()))
+
+	stored, err := repo.FindByID(person.ID)
+	require.NoError(t, err)
+	require.False(t, stored.IsActive)
+}
+
+func TestListPeople_FiltersAndPaginates(t *testing.T) {
+	svc, _ := newPersonService()
+	for _, dept := range []string{"HR", "Engineering", "Engineering"} {
+		_, err := svc.CreatePerson(&services.CreatePersonRequest{FirstName: "P", LastName: dept, Department: dept}, uuid.Nil)
+		require.NoError(t, err)
+	}
+
+	resp, err := svc.ListPeople(&services.ListPeopleRequest{
+		PageRequest: services.PageRequest{Page: 1, Limit: 1},
+		Department:  "Engineering",
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Items, 1)
+	require.Equal(t, int64(2), resp.Pagination.Total)
+	require.Equal(t, 2, resp.Pagination.TotalPages)
+}
+
+func TestPersonSupervisor(t *testing.T) {
+	svc, _ := newPersonService()
+	supervisor, err := svc.CreatePerson(&services.CreatePersonRequest{FirstName: "Sam", LastName: "Ortiz", IsDepartmentHead: true}, uuid.New())
+	require.NoError(t, err)
+	require.True(t, supervisor.IsDepartmentHead)
+
+	person, err := svc.CreatePerson(&services.CreatePersonRequest{FirstName: "Dana", LastName: "Reyes", SupervisorID: &supervisor.ID}, uuid.New())
+	require.NoError(t, err)
+	require.Equal(t, supervisor.ID, *person.SupervisorID)
+
+	missing := uuid.New()
+	_, err = svc.CreatePerson(&services.CreatePersonRequest{FirstName: "Lee", LastName: "Park", SupervisorID: &missing}, uuid.New())
+	require.ErrorIs(t, err, services.ErrInvalidSupervisor)
+
+	self := person.ID.String()
+	_, err = svc.UpdatePerson(person.ID, &services.UpdatePersonRequest{SupervisorID: &self}, uuid.New())
+	require.ErrorIs(t, err, services.ErrInvalidSupervisor)
+
+	cleared := ""
+	updated, err := svc.UpdatePerson(person.ID, &services.UpdatePersonRequest{SupervisorID: &cleared}, uuid.New())
+	require.NoError(t, err)
+	require.Nil(t, updated.SupervisorID)
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+	"log"
+
+	"certitrack/internal/config"
+)
+
+// BlobStore persists opaque objects addressed by slash-separated keys.
+type BlobStore interface {
+	// Save writes at most limit bytes from r under key and returns the
+	// number of bytes stored, or ErrFileTooLarge if r holds more.
+	Save(key string, r io.Reader, limit int64) (int64, error)
+	// Open returns the object content or ErrObjectNotFound.
+	Open(key string) (io.ReadCloser, error)
+	// Delete removes the object. Deleting a missing object is not an error.
+	Delete(key string) error
+	// Exists reports whether an object is stored under key.
+	Exists(key string) (bool, error)
+}
+
+var (
+	_ BlobStore = (*LocalStore)(nil)
+	_ BlobStore = (*S3Store)(nil)
+)
+
+// NewBlobStore returns the backend selected by StorageConfig.Backend,
+// encrypting objects when the keyring has a master key. Without one it
+// refuses to start unless the environment permits plaintext storage.
+func NewBlobStore(cfg *config.Config, keys KeyStore, ring *Keyring) (BlobStore, error) {
+	if !ring.Enabled() && !cfg.AllowsPlaintextStorage() {
+		return nil, fmt.Errorf("ENCRYPTION_KEY is required in the %q environment", cfg.App.Env)
+	}
+	store, err := newBackend(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if !ring.Enabled() {
+		log.Println("Encryption at rest disabled (ENCRYPTION_KEY not set)")
+		return store, nil
+	}
+	return NewEncryptedStore(store, keys, ring), nil
+}
+
+func newBackend(cfg *config.Config) (BlobStore, error) {
+	switch cfg.Storage.Backend {
+	case "", "local":
+		return NewLocalStore(cfg)
+	case "s3":
+		return NewS3Store(cfg.Storage.S3)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Storage.Backend)
+	}
+}
@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ErrChecksumMismatch is returned when stored content no longer matches the
+// SHA-256 recorded for it.
+var ErrChecksumMismatch = errors.New("stored object does not match its checksum")
+
+// ContentKey returns the content-addressed key for a SHA-256 hex digest.
+// Objects are fanned out by the first two hex digits to keep directories
+// small on the local backend.
+func ContentKey(sha string) string {
+	return fmt.Sprintf("sha256/%s/%s", sha[:2], sha)
+}
+
+// Checksum streams the object and returns its SHA-256 hex digest.
+func Checksum(store BlobStore, key string) (string, error) {
+	r, err := store.Open(key)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// OpenVerified returns the object content only after checking it against
+// sha, so a corrupted file is never served as if it were intact. The content
+// is spooled to a temporary file that is removed on Close; for encrypted
+// stores the spooled copy stays encrypted. An empty sha skips the check for
+// objects stored before hashes were recorded.
+func OpenVerified(store BlobStore, key, sha string) (io.ReadCloser, error) {
+	if es, ok := store.(*EncryptedStore); ok {
+		return es.openVerified(key, sha)
+	}
+	return openVerified(store, key, sha, nil)
+}
+
+// openVerified spools the stored bytes and hashes the content decode yields
+// from them. A nil decode means the stored bytes are the content.
+func openVerified(store BlobStore, key, sha string, decode func(io.Reader) io.Reader) (io.ReadCloser, error) {
+	if decode == nil {
+		decode = func(r io.Reader) io.Reader { return r }
+	}
+
+	r, err := store.Open(key)
+	if err != nil {
+		return nil, err
+	}
+	if sha == "" {
+		return readCloser{decode(r), r}, nil
+	}
+	defer r.Close()
+
+	f, err := os.CreateTemp("", "certitrack-verify-*")
+	if err != nil {
+		return nil, err
+	}
+	tmp := &tempFile{f}
+
+	h := sha256.New()
+	if _, err := io.Copy(h, decode(io.TeeReader(r, tmp))); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if hex.EncodeToString(h.Sum(nil)) != sha {
+		tmp.Close()
+		return nil, ErrChecksumMismatch
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	return readCloser{decode(tmp), tmp}, nil
+}
+
+// tempFile removes itself when closed.
+type tempFile struct {
+	*os.File
+}
+
+func (f *tempFile) Close() error {
+	err := f.File.Close()
+	os.Remove(f.Name())
+	return err
+}
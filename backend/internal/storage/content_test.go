@@ -0,0 +1,45 @@
+package storage_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"certitrack/internal/storage"
+
+	"github.com/stretchr/testify/require"
+)
+
+// sha256("%PDF-1.4")
+const pdfSHA = "e16fa5d9b51928755db85b917f0297babaf22c7a47e97d9212adab56e61ba04e"
+
+func TestContentKey(t *testing.T) {
+	require.Equal(t, "sha256/e1/"+pdfSHA, storage.ContentKey(pdfSHA))
+}
+
+func TestOpenVerified(t *testing.T) {
+	store, err := storage.NewLocalStore(newTestConfig(t))
+	require.NoError(t, err)
+	_, err = store.Save("doc.pdf", strings.NewReader("%PDF-1.4"), 1024)
+	require.NoError(t, err)
+
+	sha, err := storage.Checksum(store, "doc.pdf")
+	require.NoError(t, err)
+	require.Equal(t, pdfSHA, sha)
+
+	r, err := storage.OpenVerified(store, "doc.pdf", sha)
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, "%PDF-1.4", string(content))
+
+	// Simulate the file being altered on disk.
+	_, err = store.Save("doc.pdf", strings.NewReader("%PDF-1.5"), 1024)
+	require.NoError(t, err)
+	_, err = storage.OpenVerified(store, "doc.pdf", sha)
+	require.ErrorIs(t, err, storage.ErrChecksumMismatch)
+
+	_, err = storage.OpenVerified(store, "missing.pdf", sha)
+	require.ErrorIs(t, err, storage.ErrObjectNotFound)
+}
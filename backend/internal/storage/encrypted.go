@@ -0,0 +1,381 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"certitrack/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// KeyStore persists the wrapped data key of every encrypted object.
+type KeyStore interface {
+	FindByObjectKey(objectKey string) (*models.EncryptionKey, error)
+	CreateIfAbsent(key *models.EncryptionKey) error
+	DeleteByObjectKey(objectKey string) error
+	ListNotWrappedWith(masterKeyID, after string, limit int) ([]models.EncryptionKey, error)
+	Rewrap(objectKey, fromMasterKeyID, toMasterKeyID string, wrapped []byte) error
+}
+
+// Encrypted objects start with a magic number and a random nonce prefix,
+// followed by segments of up to segmentSize plaintext bytes, each sealed with
+// AES-GCM under the object's data key. A segment's nonce is the prefix plus
+// its index, and the last segment is sealed with different additional data,
+// so reordered, dropped or truncated segments fail to decrypt.
+const (
+	segmentSize     = 64 << 10
+	tagSize         = 16
+	noncePrefixSize = 8
+	headerSize      = len(encryptionMagic) + noncePrefixSize
+)
+
+const encryptionMagic = "CTE1"
+
+var (
+	segmentAAD = []byte{0}
+	finalAAD   = []byte{1}
+)
+
+// errNoDataKey means the object was stored before encryption was enabled.
+var errNoDataKey = errors.New("object has no data key")
+
+// EncryptedStore encrypts objects before they reach the wrapped store, so
+// neither the disk nor the bucket ever holds plaintext. Each object gets its
+// own data key, wrapped by the keyring and kept in the KeyStore. Objects
+// without a data key were stored before encryption was enabled and are read
+// as they are.
+type EncryptedStore struct {
+	inner BlobStore
+	keys  KeyStore
+	ring  *Keyring
+}
+
+var _ BlobStore = (*EncryptedStore)(nil)
+
+func NewEncryptedStore(inner BlobStore, keys KeyStore, ring *Keyring) *EncryptedStore {
+	return &EncryptedStore{inner: inner, keys: keys, ring: ring}
+}
+
+// Save encrypts r as it is streamed to the wrapped store; at most one
+// segment is held in memory. The returned size is the plaintext size.
+func (s *EncryptedStore) Save(key string, r io.Reader, limit int64) (int64, error) {
+	aead, created, err := s.dataKeyForSave(key)
+	if err != nil {
+		return 0, err
+	}
+	n, err := s.save(key, aead, r, limit)
+	if err != nil && created {
+		return 0, s.discardDataKey(key, err)
+	}
+	return n, err
+}
+
+func (s *EncryptedStore) save(key string, aead cipher.AEAD, r io.Reader, limit int64) (int64, error) {
+	enc, err := newEncryptReader(aead, r)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := s.inner.Save(key, enc, encryptedSize(limit)); err != nil {
+		return 0, err
+	}
+	return enc.size, nil
+}
+
+func (s *EncryptedStore) Open(key string) (io.ReadCloser, error) {
+	aead, err := s.findDataKey(key)
+	if errors.Is(err, errNoDataKey) {
+		return s.inner.Open(key)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := s.inner.Open(key)
+	if err != nil {
+		return nil, err
+	}
+	return readCloser{newDecryptReader(aead, r), r}, nil
+}
+
+// Delete removes the object before its data key, so a failed delete never
+// leaves an object that can no longer be decrypted.
+func (s *EncryptedStore) Delete(key string) error {
+	if err := s.inner.Delete(key); err != nil {
+		return err
+	}
+	return s.keys.DeleteByObjectKey(key)
+}
+
+func (s *EncryptedStore) Exists(key string) (bool, error) {
+	return s.inner.Exists(key)
+}
+
+// openVerified is OpenVerified for encrypted objects. Only ciphertext is
+// spooled to disk; the content is decrypted once to check its hash and again
+// as the caller reads it.
+func (s *EncryptedStore) openVerified(key, sha string) (io.ReadCloser, error) {
+	aead, err := s.findDataKey(key)
+	if errors.Is(err, errNoDataKey) {
+		return openVerified(s.inner, key, sha, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return openVerified(s.inner, key, sha, func(r io.Reader) io.Reader {
+		return newDecryptReader(aead, r)
+	})
+}
+
+func (s *EncryptedStore) findDataKey(objectKey string) (cipher.AEAD, error) {
+	row, err := s.keys.FindByObjectKey(objectKey)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errNoDataKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := s.ring.Unwrap(objectKey, row.MasterKeyID, row.WrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	return newGCM(dataKey)
+}
+
+// dataKeyForSave returns the object's data key, creating one for a new
+// object, and reports whether it created it. Overwriting an object keeps its
+// key, so a reader never pairs new content with an old key.
+func (s *EncryptedStore) dataKeyForSave(objectKey string) (cipher.AEAD, bool, error) {
+	aead, err := s.findDataKey(objectKey)
+	if !errors.Is(err, errNoDataKey) {
+		return aead, false, err
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, false, err
+	}
+	wrapped, err := s.ring.Wrap(objectKey, dataKey)
+	if err != nil {
+		return nil, false, err
+	}
+	err = s.keys.CreateIfAbsent(&models.EncryptionKey{
+		ObjectKey:   objectKey,
+		MasterKeyID: s.ring.CurrentID(),
+		WrappedKey:  wrapped,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Read the key back in case a concurrent save stored one first.
+	row, err := s.keys.FindByObjectKey(objectKey)
+	if err != nil {
+		return nil, false, err
+	}
+	if !bytes.Equal(row.WrappedKey, wrapped) {
+		aead, err := s.findDataKey(objectKey)
+		return aead, false, err
+	}
+	aead, err = newGCM(dataKey)
+	return aead, true, err
+}
+
+// discardDataKey removes the data key created for a save that failed, so
+// key rotation does not keep re-wrapping a key with nothing encrypted under
+// it. The key stays if the object exists after all, which means a
+// concurrent save of the same object stored it with that key.
+func (s *EncryptedStore) discardDataKey(objectKey string, saveErr error) error {
+	exists, err := s.inner.Exists(objectKey)
+	if err != nil || exists {
+		return saveErr
+	}
+	if err := s.keys.DeleteByObjectKey(objectKey); err != nil {
+		return errors.Join(saveErr, err)
+	}
+	return saveErr
+}
+
+// encryptedSize returns the stored size of n plaintext bytes.
+func encryptedSize(n int64) int64 {
+	return int64(headerSize) + n + tagSize*(n/segmentSize+1)
+}
+
+func segmentNonce(prefix []byte, index uint32) []byte {
+	nonce := make([]byte, noncePrefixSize+4)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[noncePrefixSize:], index)
+	return nonce
+}
+
+// encryptReader yields the encrypted form of src.
+type encryptReader struct {
+	aead   cipher.AEAD
+	src    *bufio.Reader
+	prefix []byte
+	index  uint32
+	plain  []byte
+	sealed []byte
+	out    []byte
+	done   bool
+	err    error
+	size   int64 // plaintext bytes consumed
+}
+
+func newEncryptReader(aead cipher.AEAD, src io.Reader) (*encryptReader, error) {
+	prefix := make([]byte, noncePrefixSize)
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, err
+	}
+	return &encryptReader{
+		aead:   aead,
+		src:    bufio.NewReader(src),
+		prefix: prefix,
+		plain:  make([]byte, segmentSize),
+		sealed: make([]byte, 0, segmentSize+tagSize),
+		out:    append([]byte(encryptionMagic), prefix...),
+	}, nil
+}
+
+func (r *encryptReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.done {
+			return 0, io.EOF
+		}
+		r.sealNext()
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+func (r *encryptReader) sealNext() {
+	n, err := io.ReadFull(r.src, r.plain)
+	final := false
+	switch {
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		final = true
+	case err != nil:
+		r.err = err
+		return
+	default:
+		if _, err := r.src.Peek(1); errors.Is(err, io.EOF) {
+			final = true
+		} else if err != nil {
+			r.err = err
+			return
+		}
+	}
+
+	aad := segmentAAD
+	if final {
+		aad = finalAAD
+	}
+	r.out = r.aead.Seal(r.sealed[:0], segmentNonce(r.prefix, r.index), r.plain[:n], aad)
+	r.size += int64(n)
+	r.index++
+	r.done = final
+}
+
+// decryptReader yields the plaintext of an encrypted object. Any sign of
+// tampering or truncation is reported as ErrChecksumMismatch.
+type decryptReader struct {
+	aead   cipher.AEAD
+	src    *bufio.Reader
+	prefix []byte
+	index  uint32
+	sealed []byte
+	out    []byte
+	done   bool
+	err    error
+}
+
+func newDecryptReader(aead cipher.AEAD, src io.Reader) *decryptReader {
+	return &decryptReader{
+		aead:   aead,
+		src:    bufio.NewReader(src),
+		sealed: make([]byte, segmentSize+tagSize),
+	}
+}
+
+func (r *decryptReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.done {
+			return 0, io.EOF
+		}
+		r.openNext()
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+func (r *decryptReader) openNext() {
+	if r.prefix == nil {
+		header := make([]byte, headerSize)
+		if _, err := io.ReadFull(r.src, header); err != nil {
+			r.err = truncated(err)
+			return
+		}
+		if string(header[:len(encryptionMagic)]) != encryptionMagic {
+			r.err = ErrChecksumMismatch
+			return
+		}
+		r.prefix = header[len(encryptionMagic):]
+	}
+
+	n, err := io.ReadFull(r.src, r.sealed)
+	final := false
+	switch {
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		final = true
+	case err != nil:
+		// A clean EOF here means the final segment is missing.
+		r.err = truncated(err)
+		return
+	default:
+		if _, err := r.src.Peek(1); errors.Is(err, io.EOF) {
+			final = true
+		} else if err != nil {
+			r.err = err
+			return
+		}
+	}
+
+	aad := segmentAAD
+	if final {
+		aad = finalAAD
+	}
+	plain, err := r.aead.Open(r.sealed[:0], segmentNonce(r.prefix, r.index), r.sealed[:n], aad)
+	if err != nil {
+		r.err = ErrChecksumMismatch
+		return
+	}
+	r.out = plain
+	r.index++
+	r.done = final
+}
+
+func truncated(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return ErrChecksumMismatch
+	}
+	return err
+}
+
+// readCloser reads from one reader and closes another.
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
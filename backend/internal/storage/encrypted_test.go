@@ -0,0 +1,280 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"certitrack/internal/config"
+	"certitrack/internal/repositories"
+	"certitrack/internal/storage"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newMasterKey(t *testing.T) string {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+func newKeyring(t *testing.T, id, key string, retired ...string) *storage.Keyring {
+	ring, err := storage.NewKeyring(&config.Config{Encryption: config.EncryptionConfig{
+		KeyID:       id,
+		Key:         key,
+		RetiredKeys: retired,
+	}})
+	require.NoError(t, err)
+	return ring
+}
+
+type encryptedFixture struct {
+	inner *storage.LocalStore
+	keys  *repositories.MockEncryptionKeyRepository
+	store *storage.EncryptedStore
+}
+
+func newEncryptedFixture(t *testing.T, ring *storage.Keyring) *encryptedFixture {
+	inner, err := storage.NewLocalStore(newTestConfig(t))
+	require.NoError(t, err)
+	keys := repositories.NewMockEncryptionKeyRepository()
+	return &encryptedFixture{
+		inner: inner,
+		keys:  keys,
+		store: storage.NewEncryptedStore(inner, keys, ring),
+	}
+}
+
+func readObject(t *testing.T, store storage.BlobStore, key string) ([]byte, error) {
+	t.Helper()
+	r, err := store.Open(key)
+	require.NoError(t, err)
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func randomBytes(t *testing.T, n int) []byte {
+	b := make([]byte, n)
+	_, err := rand.Read(b)
+	require.NoError(t, err)
+	return b
+}
+
+func TestEncryptedStore_RoundTrip(t *testing.T) {
+	f := newEncryptedFixture(t, newKeyring(t, "k1", newMasterKey(t)))
+
+	for name, size := range map[string]int{
+		"empty":         0,
+		"small":         10,
+		"one segment":   64 << 10,
+		"many segments": 200_000,
+	} {
+		t.Run(name, func(t *testing.T) {
+			plain := randomBytes(t, size)
+			key := "sha256/" + strings.ReplaceAll(name, " ", "-")
+
+			n, err := f.store.Save(key, bytes.NewReader(plain), 1<<20)
+			require.NoError(t, err)
+			require.Equal(t, int64(size), n)
+
+			raw, err := readObject(t, f.inner, key)
+			require.NoError(t, err)
+			require.True(t, bytes.HasPrefix(raw, []byte("CTE1")))
+			if size > 0 {
+				require.False(t, bytes.Contains(raw, plain), "plaintext must not reach the backend")
+			}
+
+			got, err := readObject(t, f.store, key)
+			require.NoError(t, err)
+			require.Equal(t, plain, got)
+
+			row, err := f.keys.FindByObjectKey(key)
+			require.NoError(t, err)
+			require.Equal(t, "k1", row.MasterKeyID)
+		})
+	}
+}
+
+func TestEncryptedStore_EnforcesPlaintextLimit(t *testing.T) {
+	f := newEncryptedFixture(t, newKeyring(t, "k1", newMasterKey(t)))
+
+	_, err := f.store.Save("exact.pdf", bytes.NewReader(randomBytes(t, 100)), 100)
+	require.NoError(t, err)
+
+	_, err = f.store.Save("big.pdf", bytes.NewReader(randomBytes(t, 101)), 100)
+	require.ErrorIs(t, err, storage.ErrFileTooLarge)
+	exists, err := f.inner.Exists("big.pdf")
+	require.NoError(t, err)
+	require.False(t, exists)
+	_, err = f.keys.FindByObjectKey("big.pdf")
+	require.Error(t, err, "a rejected upload must not leave a data key behind")
+}
+
+// failingStore fails every save after reading part of the content.
+type failingStore struct {
+	storage.BlobStore
+}
+
+var errDiskFull = errors.New("disk full")
+
+func (s failingStore) Save(key string, r io.Reader, limit int64) (int64, error) {
+	_, _ = io.CopyN(io.Discard, r, 16)
+	return 0, errDiskFull
+}
+
+func TestEncryptedStore_FailedSaveDiscardsDataKey(t *testing.T) {
+	ring := newKeyring(t, "k1", newMasterKey(t))
+	f := newEncryptedFixture(t, ring)
+	store := storage.NewEncryptedStore(failingStore{f.inner}, f.keys, ring)
+
+	_, err := store.Save("doc.pdf", strings.NewReader("%PDF-1.4"), 1024)
+	require.ErrorIs(t, err, errDiskFull)
+	_, err = f.keys.FindByObjectKey("doc.pdf")
+	require.Error(t, err)
+
+	// Overwriting keeps the existing key even when the save fails.
+	_, err = f.store.Save("doc.pdf", strings.NewReader("%PDF-1.4"), 1024)
+	require.NoError(t, err)
+	_, err = store.Save("doc.pdf", strings.NewReader("%PDF-1.5"), 1024)
+	require.ErrorIs(t, err, errDiskFull)
+	got, err := readObject(t, f.store, "doc.pdf")
+	require.NoError(t, err)
+	require.Equal(t, "%PDF-1.4", string(got))
+}
+
+func TestEncryptedStore_DetectsTampering(t *testing.T) {
+	f := newEncryptedFixture(t, newKeyring(t, "k1", newMasterKey(t)))
+	plain := randomBytes(t, 128<<10) // exactly two segments
+	_, err := f.store.Save("doc.pdf", bytes.NewReader(plain), 1<<20)
+	require.NoError(t, err)
+	raw, err := readObject(t, f.inner, "doc.pdf")
+	require.NoError(t, err)
+
+	flipped := append([]byte(nil), raw...)
+	flipped[len(flipped)/2] ^= 1
+	_, err = f.inner.Save("doc.pdf", bytes.NewReader(flipped), 1<<20)
+	require.NoError(t, err)
+	_, err = readObject(t, f.store, "doc.pdf")
+	require.ErrorIs(t, err, storage.ErrChecksumMismatch)
+
+	// Dropping the final segment leaves a valid-looking prefix.
+	truncated := raw[:len(raw)-(64<<10+16)]
+	_, err = f.inner.Save("doc.pdf", bytes.NewReader(truncated), 1<<20)
+	require.NoError(t, err)
+	_, err = readObject(t, f.store, "doc.pdf")
+	require.ErrorIs(t, err, storage.ErrChecksumMismatch)
+}
+
+func TestEncryptedStore_OpenVerified(t *testing.T) {
+	f := newEncryptedFixture(t, newKeyring(t, "k1", newMasterKey(t)))
+	_, err := f.store.Save("doc.pdf", strings.NewReader("%PDF-1.4"), 1024)
+	require.NoError(t, err)
+
+	sha, err := storage.Checksum(f.store, "doc.pdf")
+	require.NoError(t, err)
+	require.Equal(t, pdfSHA, sha)
+
+	r, err := storage.OpenVerified(f.store, "doc.pdf", sha)
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, "%PDF-1.4", string(content))
+
+	_, err = f.store.Save("doc.pdf", strings.NewReader("%PDF-1.5"), 1024)
+	require.NoError(t, err)
+	_, err = storage.OpenVerified(f.store, "doc.pdf", sha)
+	require.ErrorIs(t, err, storage.ErrChecksumMismatch)
+}
+
+func TestEncryptedStore_ReadsObjectsStoredBeforeEncryption(t *testing.T) {
+	f := newEncryptedFixture(t, newKeyring(t, "k1", newMasterKey(t)))
+	_, err := f.inner.Save("legacy.pdf", strings.NewReader("%PDF-1.4"), 1024)
+	require.NoError(t, err)
+
+	got, err := readObject(t, f.store, "legacy.pdf")
+	require.NoError(t, err)
+	require.Equal(t, "%PDF-1.4", string(got))
+}
+
+func TestEncryptedStore_DeleteRemovesDataKey(t *testing.T) {
+	f := newEncryptedFixture(t, newKeyring(t, "k1", newMasterKey(t)))
+	_, err := f.store.Save("doc.pdf", strings.NewReader("%PDF-1.4"), 1024)
+	require.NoError(t, err)
+
+	require.NoError(t, f.store.Delete("doc.pdf"))
+
+	_, err = f.keys.FindByObjectKey("doc.pdf")
+	require.Error(t, err)
+	_, err = f.store.Open("doc.pdf")
+	require.ErrorIs(t, err, storage.ErrObjectNotFound)
+}
+
+func TestRotateKeys_RewrapsWithoutRewritingObjects(t *testing.T) {
+	oldKey, newKey := newMasterKey(t), newMasterKey(t)
+	f := newEncryptedFixture(t, newKeyring(t, "k1", oldKey))
+	plain := randomBytes(t, 100_000)
+	_, err := f.store.Save("a.pdf", bytes.NewReader(plain), 1<<20)
+	require.NoError(t, err)
+	_, err = f.store.Save("b.pdf", bytes.NewReader(plain), 1<<20)
+	require.NoError(t, err)
+	before, err := readObject(t, f.inner, "a.pdf")
+	require.NoError(t, err)
+
+	rotated := newKeyring(t, "k2", newKey, "k1:"+oldKey)
+	report, err := storage.RotateKeys(context.Background(), f.keys, rotated)
+	require.NoError(t, err)
+	require.Equal(t, 2, report.Rewrapped)
+	require.Empty(t, report.Failed)
+
+	after, err := readObject(t, f.inner, "a.pdf")
+	require.NoError(t, err)
+	require.Equal(t, before, after, "objects are not re-encrypted")
+
+	// The old master key is no longer needed.
+	store := storage.NewEncryptedStore(f.inner, f.keys, newKeyring(t, "k2", newKey))
+	got, err := readObject(t, store, "a.pdf")
+	require.NoError(t, err)
+	require.Equal(t, plain, got)
+
+	report, err = storage.RotateKeys(context.Background(), f.keys, rotated)
+	require.NoError(t, err)
+	require.Zero(t, report.Rewrapped)
+}
+
+func TestRotateKeys_ReportsUnknownMasterKeys(t *testing.T) {
+	f := newEncryptedFixture(t, newKeyring(t, "k1", newMasterKey(t)))
+	_, err := f.store.Save("a.pdf", strings.NewReader("%PDF-1.4"), 1024)
+	require.NoError(t, err)
+
+	report, err := storage.RotateKeys(context.Background(), f.keys, newKeyring(t, "k2", newMasterKey(t)))
+
+	require.NoError(t, err)
+	require.Zero(t, report.Rewrapped)
+	require.Equal(t, []string{"a.pdf"}, report.Failed)
+}
+
+func TestNewKeyring_RejectsInvalidKeys(t *testing.T) {
+	for name, enc := range map[string]config.EncryptionConfig{
+		"short key":       {KeyID: "k1", Key: base64.StdEncoding.EncodeToString([]byte("short"))},
+		"bad base64":      {KeyID: "k1", Key: "not base64!"},
+		"retired no id":   {KeyID: "k1", Key: newMasterKey(t), RetiredKeys: []string{newMasterKey(t)}},
+		"duplicate id":    {KeyID: "k1", Key: newMasterKey(t), RetiredKeys: []string{"k1:" + newMasterKey(t)}},
+		"retired bad key": {KeyID: "k1", Key: newMasterKey(t), RetiredKeys: []string{"k0:abc"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := storage.NewKeyring(&config.Config{Encryption: enc})
+			require.Error(t, err)
+		})
+	}
+
+	ring, err := storage.NewKeyring(&config.Config{})
+	require.NoError(t, err)
+	require.False(t, ring.Enabled())
+}
@@ -0,0 +1,177 @@
+package storage
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"certitrack/internal/config"
+)
+
+var (
+	ErrEncryptionDisabled = errors.New("encryption at rest is not configured")
+	ErrUnknownMasterKey   = errors.New("data key is wrapped with an unknown master key")
+)
+
+// dataKeySize is the length of the per-object AES-256 keys.
+const dataKeySize = 32
+
+// Keyring holds the master keys that wrap per-object data keys. New data
+// keys are always wrapped with the current key; retired keys can still
+// unwrap until every data key has been rewrapped.
+type Keyring struct {
+	currentID string
+	keys      map[string]cipher.AEAD
+}
+
+// NewKeyring builds the keyring from EncryptionConfig. Without a configured
+// key the keyring is disabled and documents are stored in plaintext.
+func NewKeyring(cfg *config.Config) (*Keyring, error) {
+	ring := &Keyring{keys: make(map[string]cipher.AEAD)}
+	if cfg.Encryption.Key == "" {
+		return ring, nil
+	}
+
+	if err := ring.add(cfg.Encryption.KeyID, cfg.Encryption.Key); err != nil {
+		return nil, fmt.Errorf("ENCRYPTION_KEY: %w", err)
+	}
+	ring.currentID = cfg.Encryption.KeyID
+
+	for _, entry := range cfg.Encryption.RetiredKeys {
+		id, key, ok := strings.Cut(entry, ":")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("ENCRYPTION_RETIRED_KEYS: entries must be id:key")
+		}
+		if _, exists := ring.keys[id]; exists {
+			return nil, fmt.Errorf("ENCRYPTION_RETIRED_KEYS: duplicate key id %q", id)
+		}
+		if err := ring.add(id, key); err != nil {
+			return nil, fmt.Errorf("ENCRYPTION_RETIRED_KEYS: key %q: %w", id, err)
+		}
+	}
+	return ring, nil
+}
+
+func (k *Keyring) add(id, encoded string) error {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("invalid base64: %w", err)
+	}
+	if len(raw) != dataKeySize {
+		return fmt.Errorf("key must be %d bytes, got %d", dataKeySize, len(raw))
+	}
+	aead, err := newGCM(raw)
+	if err != nil {
+		return err
+	}
+	k.keys[id] = aead
+	return nil
+}
+
+// Enabled reports whether a master key is configured.
+func (k *Keyring) Enabled() bool {
+	return k.currentID != ""
+}
+
+// CurrentID returns the ID of the key new data keys are wrapped with.
+func (k *Keyring) CurrentID() string {
+	return k.currentID
+}
+
+// Wrap encrypts a data key with the current master key. The object key is
+// bound as additional data, so a wrapped key copied to another object's row
+// does not unwrap.
+func (k *Keyring) Wrap(objectKey string, dataKey []byte) ([]byte, error) {
+	aead, ok := k.keys[k.currentID]
+	if !ok {
+		return nil, ErrEncryptionDisabled
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, dataKey, []byte(objectKey)), nil
+}
+
+// Unwrap decrypts a data key wrapped with the master key masterKeyID.
+func (k *Keyring) Unwrap(objectKey, masterKeyID string, wrapped []byte) ([]byte, error) {
+	aead, ok := k.keys[masterKeyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMasterKey, masterKeyID)
+	}
+	if len(wrapped) < aead.NonceSize() {
+		return nil, ErrChecksumMismatch
+	}
+	nonce, sealed := wrapped[:aead.NonceSize()], wrapped[aead.NonceSize():]
+	dataKey, err := aead.Open(nil, nonce, sealed, []byte(objectKey))
+	if err != nil {
+		return nil, ErrChecksumMismatch
+	}
+	return dataKey, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// rotationBatchSize bounds the keys loaded per query during rotation.
+const rotationBatchSize = 100
+
+// RotationReport summarises a pass that rewraps data keys with the current
+// master key.
+type RotationReport struct {
+	Rewrapped int      `json:"rewrapped"`
+	Failed    []string `json:"failed"` // object keys whose data key could not be unwrapped
+}
+
+// RotateKeys rewraps every data key that is not wrapped with the current
+// master key. Only the small wrapped keys change; stored objects are not
+// read or rewritten. Keys that cannot be unwrapped, typically because their
+// master key was dropped from ENCRYPTION_RETIRED_KEYS too early, are
+// reported and left as they are.
+func RotateKeys(ctx context.Context, keys KeyStore, ring *Keyring) (*RotationReport, error) {
+	if !ring.Enabled() {
+		return nil, ErrEncryptionDisabled
+	}
+
+	report := &RotationReport{Failed: []string{}}
+	after := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		batch, err := keys.ListNotWrappedWith(ring.CurrentID(), after, rotationBatchSize)
+		if err != nil {
+			return report, err
+		}
+		if len(batch) == 0 {
+			return report, nil
+		}
+
+		for _, k := range batch {
+			after = k.ObjectKey
+			dataKey, err := ring.Unwrap(k.ObjectKey, k.MasterKeyID, k.WrappedKey)
+			if err != nil {
+				report.Failed = append(report.Failed, k.ObjectKey)
+				continue
+			}
+			wrapped, err := ring.Wrap(k.ObjectKey, dataKey)
+			if err != nil {
+				return report, err
+			}
+			if err := keys.Rewrap(k.ObjectKey, k.MasterKeyID, ring.CurrentID(), wrapped); err != nil {
+				return report, err
+			}
+			report.Rewrapped++
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// Package storage persists uploaded files and enforces the upload limits
+// configured in config.StorageConfig.
+package storage
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"certitrack/internal/config"
+)
+
+var (
+	ErrFileTooLarge        = errors.New("file exceeds the maximum allowed size")
+	ErrExtensionNotAllowed = errors.New("file extension is not allowed")
+	ErrEmptyFile           = errors.New("file is empty")
+)
+
+// Limits holds the upload restrictions derived from StorageConfig.
+type Limits struct {
+	MaxSize     int64
+	AllowedExts map[string]bool
+}
+
+func NewLimits(cfg *config.Config) *Limits {
+	allowed := make(map[string]bool, len(cfg.Storage.AllowedExts))
+	for _, ext := range cfg.Storage.AllowedExts {
+		allowed[strings.ToLower(ext)] = true
+	}
+	return &Limits{
+		MaxSize:     int64(cfg.Storage.MaxSizeMB) << 20,
+		AllowedExts: allowed,
+	}
+}
+
+// Check validates the file name and declared size against the limits.
+func (l *Limits) Check(fileName string, size int64) error {
+	if !l.AllowedExts[Ext(fileName)] {
+		return ErrExtensionNotAllowed
+	}
+	if size <= 0 {
+		return ErrEmptyFile
+	}
+	if size > l.MaxSize {
+		return ErrFileTooLarge
+	}
+	return nil
+}
+
+// Ext returns the lower-cased extension of fileName, including the dot.
+func Ext(fileName string) string {
+	return strings.ToLower(filepath.Ext(fileName))
+}
@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
+)
+
+var (
+	ErrUnrecognizedContent = errors.New("file content is not a supported document type")
+	ErrContentMismatch     = errors.New("file content does not match its extension")
+)
+
+const (
+	mimePDF  = "application/pdf"
+	mimePNG  = "image/png"
+	mimeJPEG = "image/jpeg"
+	mimeGIF  = "image/gif"
+	mimeDOCX = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+)
+
+// sniffHeaderSize is enough to hold every signature in magicNumbers.
+const sniffHeaderSize = 8
+
+var magicNumbers = []struct {
+	prefix   []byte
+	mimeType string
+}{
+	{[]byte("%PDF-"), mimePDF},
+	{[]byte("\x89PNG\r\n\x1a\n"), mimePNG},
+	{[]byte("\xff\xd8\xff"), mimeJPEG},
+	{[]byte("GIF87a"), mimeGIF},
+	{[]byte("GIF89a"), mimeGIF},
+}
+
+var zipMagic = []byte("PK\x03\x04")
+
+// extMimeTypes lists the extensions each detectable type may be uploaded as.
+var extMimeTypes = map[string]string{
+	".pdf":  mimePDF,
+	".png":  mimePNG,
+	".jpg":  mimeJPEG,
+	".jpeg": mimeJPEG,
+	".gif":  mimeGIF,
+	".docx": mimeDOCX,
+}
+
+// Content is an uploaded file. Random access is needed to inspect zip
+// containers without consuming the stream; multipart files provide it.
+type Content interface {
+	io.Reader
+	io.ReaderAt
+}
+
+// DetectContentType identifies the document type from the file's bytes
+// rather than its name. Only the types in extMimeTypes are recognised.
+func DetectContentType(r io.ReaderAt, size int64) (string, error) {
+	header := make([]byte, sniffHeaderSize)
+	n, err := r.ReadAt(header, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", err
+	}
+	header = header[:n]
+
+	for _, m := range magicNumbers {
+		if bytes.HasPrefix(header, m.prefix) {
+			return m.mimeType, nil
+		}
+	}
+	if bytes.HasPrefix(header, zipMagic) && isDOCX(r, size) {
+		return mimeDOCX, nil
+	}
+	return "", ErrUnrecognizedContent
+}
+
+// Sniff detects the content type and checks that it is the type implied by
+// fileName's extension, so a renamed executable cannot pass as a PDF.
+func Sniff(fileName string, r io.ReaderAt, size int64) (string, error) {
+	mimeType, err := DetectContentType(r, size)
+	if err != nil {
+		return "", err
+	}
+	if extMimeTypes[Ext(fileName)] != mimeType {
+		return "", ErrContentMismatch
+	}
+	return mimeType, nil
+}
+
+// isDOCX reports whether the zip archive is a WordprocessingML package: it
+// must declare its content types and contain the main document part.
+func isDOCX(r io.ReaderAt, size int64) bool {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return false
+	}
+
+	var hasContentTypes, hasDocument bool
+	for _, f := range zr.File {
+		switch f.Name {
+		case "[Content_Types].xml":
+			hasContentTypes = true
+		case "word/document.xml":
+			hasDocument = true
+		}
+	}
+	return hasContentTypes && hasDocument
+}
@@ -0,0 +1,67 @@
+package storage_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"certitrack/internal/storage"
+
+	"github.com/stretchr/testify/require"
+)
+
+func zipArchive(t *testing.T, names ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range names {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte("<xml/>"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestDetectContentType(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+		want    string
+		wantErr error
+	}{
+		{"pdf", []byte("%PDF-1.7\n"), "application/pdf", nil},
+		{"png", []byte("\x89PNG\r\n\x1a\n\x00\x00"), "image/png", nil},
+		{"jpeg", []byte("\xff\xd8\xff\xe0\x00\x10JFIF"), "image/jpeg", nil},
+		{"gif", []byte("GIF89a\x01\x00"), "image/gif", nil},
+		{"docx", zipArchive(t, "[Content_Types].xml", "_rels/.rels", "word/document.xml"),
+			"application/vnd.openxmlformats-officedocument.wordprocessingml.document", nil},
+		{"plain zip", zipArchive(t, "readme.txt"), "", storage.ErrUnrecognizedContent},
+		{"xlsx", zipArchive(t, "[Content_Types].xml", "xl/workbook.xml"), "", storage.ErrUnrecognizedContent},
+		{"executable", []byte("MZ\x90\x00\x03\x00"), "", storage.ErrUnrecognizedContent},
+		{"too short", []byte("%P"), "", storage.ErrUnrecognizedContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := storage.DetectContentType(bytes.NewReader(tt.content), int64(len(tt.content)))
+			require.ErrorIs(t, err, tt.wantErr)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSniff_RequiresMatchingExtension(t *testing.T) {
+	jpeg := bytes.NewReader([]byte("\xff\xd8\xff\xe0"))
+
+	mimeType, err := storage.Sniff("photo.JPEG", jpeg, 4)
+	require.NoError(t, err)
+	require.Equal(t, "image/jpeg", mimeType)
+
+	_, err = storage.Sniff("photo.pdf", jpeg, 4)
+	require.ErrorIs(t, err, storage.ErrContentMismatch)
+
+	_, err = storage.Sniff("certificate.pdf", bytes.NewReader([]byte("MZ\x90\x00")), 4)
+	require.ErrorIs(t, err, storage.ErrUnrecognizedContent)
+}
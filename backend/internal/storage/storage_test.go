@@ -0,0 +1,79 @@
+package storage_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"certitrack/internal/config"
+	"certitrack/internal/storage"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConfig(t *testing.T) *config.Config {
+	return &config.Config{Storage: config.StorageConfig{
+		Root:        t.TempDir(),
+		MaxSizeMB:   1,
+		AllowedExts: []string{".pdf", ".PNG"},
+	}}
+}
+
+func TestLimits_Check(t *testing.T) {
+	limits := storage.NewLimits(newTestConfig(t))
+
+	require.NoError(t, limits.Check("cert.PDF", 1024))
+	require.NoError(t, limits.Check("scan.png", 1024))
+	require.ErrorIs(t, limits.Check("script.exe", 1024), storage.ErrExtensionNotAllowed)
+	require.ErrorIs(t, limits.Check("cert.pdf", 0), storage.ErrEmptyFile)
+	require.ErrorIs(t, limits.Check("cert.pdf", 2<<20), storage.ErrFileTooLarge)
+}
+
+func TestLocalStore_SaveOpenDelete(t *testing.T) {
+	store, err := storage.NewLocalStore(newTestConfig(t))
+	require.NoError(t, err)
+
+	size, err := store.Save("certifications/a/b.pdf", strings.NewReader("%PDF-1.4"), 1024)
+	require.NoError(t, err)
+	require.Equal(t, int64(8), size)
+
+	r, err := store.Open("certifications/a/b.pdf")
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, "%PDF-1.4", string(content))
+
+	exists, err := store.Exists("certifications/a/b.pdf")
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	require.NoError(t, store.Delete("certifications/a/b.pdf"))
+	require.NoError(t, store.Delete("certifications/a/b.pdf"))
+	_, err = store.Open("certifications/a/b.pdf")
+	require.ErrorIs(t, err, storage.ErrObjectNotFound)
+	exists, err = store.Exists("certifications/a/b.pdf")
+	require.NoError(t, err)
+	require.False(t, exists)
+}
+
+func TestLocalStore_SaveEnforcesLimit(t *testing.T) {
+	store, err := storage.NewLocalStore(newTestConfig(t))
+	require.NoError(t, err)
+
+	_, err = store.Save("big.pdf", strings.NewReader("0123456789"), 5)
+	require.ErrorIs(t, err, storage.ErrFileTooLarge)
+
+	_, err = store.Open("big.pdf")
+	require.ErrorIs(t, err, storage.ErrObjectNotFound)
+}
+
+func TestLocalStore_RejectsEscapingKeys(t *testing.T) {
+	store, err := storage.NewLocalStore(newTestConfig(t))
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "/etc/passwd", "../outside.pdf", "a/../../outside.pdf"} {
+		_, err := store.Save(key, strings.NewReader("x"), 10)
+		require.ErrorIs(t, err, storage.ErrInvalidKey, key)
+	}
+}
@@ -0,0 +1,72 @@
+package thumbnail
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"sync"
+)
+
+// PlaceholderContentType is the MIME type of placeholder images.
+const PlaceholderContentType = "image/png"
+
+var placeholderColors = map[string]color.RGBA{
+	"application/pdf": {R: 0xd3, G: 0x2f, B: 0x2f, A: 0xff},
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document": {R: 0x1e, G: 0x5b, B: 0xb8, A: 0xff},
+}
+
+var genericColor = color.RGBA{R: 0x9e, G: 0x9e, B: 0x9e, A: 0xff}
+
+var (
+	placeholderMu    sync.Mutex
+	placeholderCache = map[color.RGBA][]byte{}
+)
+
+// Placeholder returns a PNG document icon tinted for mimeType. It is shown
+// for types that cannot be rendered and for images whose thumbnail is not
+// ready yet.
+func Placeholder(mimeType string) []byte {
+	c, ok := placeholderColors[mimeType]
+	if !ok {
+		c = genericColor
+	}
+
+	placeholderMu.Lock()
+	defer placeholderMu.Unlock()
+	if data, ok := placeholderCache[c]; ok {
+		return data
+	}
+	data := renderPlaceholder(c)
+	placeholderCache[c] = data
+	return data
+}
+
+// renderPlaceholder draws a page with a folded corner and a coloured band.
+func renderPlaceholder(band color.RGBA) []byte {
+	const size, fold = 128, 28
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+
+	page := image.Rect(24, 8, 104, 120)
+	outline := color.RGBA{R: 0xbd, G: 0xbd, B: 0xbd, A: 0xff}
+	draw.Draw(img, page, image.NewUniform(outline), image.Point{}, draw.Src)
+	draw.Draw(img, page.Inset(2), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(page.Min.X, 76, page.Max.X, 100), image.NewUniform(band), image.Point{}, draw.Src)
+
+	// Cut the top-right corner diagonally and shade the fold.
+	for y := 0; y < fold; y++ {
+		for x := 0; x < fold; x++ {
+			var c color.Color = outline
+			if x >= y {
+				c = color.Transparent
+			}
+			img.Set(page.Max.X-fold+x, page.Min.Y+y, c)
+		}
+	}
+
+	var buf bytes.Buffer
+	// Encoding an in-memory RGBA image cannot fail.
+	_ = png.Encode(&buf, img)
+	return buf.Bytes()
+}
@@ -0,0 +1,126 @@
+// Package thumbnail renders small previews of uploaded images and serves
+// placeholders for documents that cannot be rendered.
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"io"
+
+	// Register the decoders for the formats Supports accepts.
+	_ "image/gif"
+	_ "image/png"
+)
+
+// maxSourcePixels bounds the decoded size of a source image so a small file
+// that declares huge dimensions cannot exhaust memory.
+const maxSourcePixels = 40_000_000
+
+var ErrImageTooLarge = errors.New("image dimensions exceed the thumbnail limit")
+
+// ContentType is the MIME type of generated thumbnails.
+const ContentType = "image/jpeg"
+
+var supported = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// Supports reports whether thumbnails can be generated for mimeType.
+func Supports(mimeType string) bool {
+	return supported[mimeType]
+}
+
+// Key returns the storage key of the thumbnail for content with the given
+// SHA-256, so documents sharing a file also share its thumbnail.
+func Key(contentHash string) string {
+	return fmt.Sprintf("thumbnails/%s/%s.jpg", contentHash[:2], contentHash)
+}
+
+// Generate decodes an image and encodes a JPEG that fits within size x size
+// pixels, preserving the aspect ratio. Transparent areas are flattened onto
+// white. Images already within the bounds are not enlarged.
+func Generate(r io.Reader, size int) ([]byte, error) {
+	var header bytes.Buffer
+	cfg, _, err := image.DecodeConfig(io.TeeReader(r, &header))
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Width*cfg.Height > maxSourcePixels {
+		return nil, ErrImageTooLarge
+	}
+
+	src, _, err := image.Decode(io.MultiReader(&header, r))
+	if err != nil {
+		return nil, err
+	}
+
+	// Flatten onto white in RGBA so scaling only deals with one layout.
+	bounds := src.Bounds()
+	flat := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), src, bounds.Min, draw.Over)
+
+	w, h := fit(bounds.Dx(), bounds.Dy(), size)
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, downscale(flat, w, h), &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// fit returns the largest dimensions within size x size with the same
+// aspect ratio as w x h, never larger than the original.
+func fit(w, h, size int) (int, int) {
+	if w <= size && h <= size {
+		return w, h
+	}
+	if w >= h {
+		return size, max(1, h*size/w)
+	}
+	return max(1, w*size/h), size
+}
+
+// downscale resizes src to w x h by averaging the source pixels that fall
+// into each destination pixel (a box filter), which avoids the aliasing of
+// nearest-neighbour sampling when shrinking.
+func downscale(src *image.RGBA, w, h int) *image.RGBA {
+	sw, sh := src.Bounds().Dx(), src.Bounds().Dy()
+	if sw == w && sh == h {
+		return src
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		y0, y1 := y*sh/h, max((y+1)*sh/h, y*sh/h+1)
+		for x := 0; x < w; x++ {
+			x0, x1 := x*sw/w, max((x+1)*sw/w, x*sw/w+1)
+
+			var r, g, b, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+4]
+					r += uint32(p[0])
+					g += uint32(p[1])
+					b += uint32(p[2])
+					a += uint32(p[3])
+					n++
+				}
+			}
+
+			i := dst.PixOffset(x, y)
+			dst.Pix[i+0] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
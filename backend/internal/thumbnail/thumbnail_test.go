@@ -0,0 +1,146 @@
+package thumbnail_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"certitrack/internal/config"
+	"certitrack/internal/storage"
+	"certitrack/internal/thumbnail"
+
+	"github.com/stretchr/testify/require"
+)
+
+func encodePNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 0x80, A: 0xff})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func decodedSize(t *testing.T, data []byte) (int, int) {
+	t.Helper()
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	return img.Bounds().Dx(), img.Bounds().Dy()
+}
+
+func TestGenerate_FitsWithinSizeKeepingAspectRatio(t *testing.T) {
+	data, err := thumbnail.Generate(bytes.NewReader(encodePNG(t, 800, 400)), 256)
+	require.NoError(t, err)
+
+	w, h := decodedSize(t, data)
+	require.Equal(t, 256, w)
+	require.Equal(t, 128, h)
+}
+
+func TestGenerate_DoesNotEnlargeSmallImages(t *testing.T) {
+	data, err := thumbnail.Generate(bytes.NewReader(encodePNG(t, 40, 90)), 256)
+	require.NoError(t, err)
+
+	w, h := decodedSize(t, data)
+	require.Equal(t, 40, w)
+	require.Equal(t, 90, h)
+}
+
+func TestGenerate_FlattensTransparentGIF(t *testing.T) {
+	palette := color.Palette{color.Transparent, color.Black}
+	img := image.NewPaletted(image.Rect(0, 0, 300, 300), palette)
+	var buf bytes.Buffer
+	require.NoError(t, gif.Encode(&buf, img, nil))
+
+	data, err := thumbnail.Generate(&buf, 100)
+	require.NoError(t, err)
+
+	out, err := jpeg.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	r, g, b, _ := out.At(50, 50).RGBA()
+	require.Greater(t, r>>8, uint32(0xf0), "transparent pixels become white")
+	require.Greater(t, g>>8, uint32(0xf0))
+	require.Greater(t, b>>8, uint32(0xf0))
+}
+
+func TestGenerate_RejectsOversizedDimensions(t *testing.T) {
+	// A PNG header declaring 100000x100000 pixels; the body is never read.
+	data := encodePNG(t, 1, 1)
+	binary.BigEndian.PutUint32(data[16:], 100000)
+	binary.BigEndian.PutUint32(data[20:], 100000)
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+
+	_, err := thumbnail.Generate(bytes.NewReader(data), 256)
+	require.ErrorIs(t, err, thumbnail.ErrImageTooLarge)
+}
+
+func TestPlaceholder(t *testing.T) {
+	pdf := thumbnail.Placeholder("application/pdf")
+	_, err := png.Decode(bytes.NewReader(pdf))
+	require.NoError(t, err)
+	require.NotEqual(t, pdf, thumbnail.Placeholder("image/png"))
+	require.Equal(t, pdf, thumbnail.Placeholder("application/pdf"))
+}
+
+func newWorker(t *testing.T, workers int) (*thumbnail.Worker, storage.BlobStore) {
+	t.Helper()
+	cfg := &config.Config{
+		Storage:    config.StorageConfig{Root: t.TempDir()},
+		Thumbnails: config.ThumbnailConfig{Size: 64, Workers: workers},
+	}
+	store, err := storage.NewLocalStore(cfg)
+	require.NoError(t, err)
+	return thumbnail.NewWorker(store, cfg), store
+}
+
+func storeContent(t *testing.T, store storage.BlobStore, hash string, data []byte) {
+	t.Helper()
+	_, err := store.Save(storage.ContentKey(hash), bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+}
+
+func TestWorker_Process(t *testing.T) {
+	worker, store := newWorker(t, 0)
+	hash := strings.Repeat("ab", 32)
+	storeContent(t, store, hash, encodePNG(t, 200, 100))
+
+	require.NoError(t, worker.Process(hash, "image/png"))
+
+	exists, err := store.Exists(thumbnail.Key(hash))
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	// Unsupported types are skipped without touching storage.
+	require.NoError(t, worker.Process(strings.Repeat("cd", 32), "application/pdf"))
+}
+
+func TestWorker_StartProcessesQueue(t *testing.T) {
+	worker, store := newWorker(t, 1)
+	hash := strings.Repeat("ef", 32)
+	storeContent(t, store, hash, encodePNG(t, 100, 100))
+
+	worker.Start()
+	worker.Enqueue(hash, "image/png")
+
+	require.Eventually(t, func() bool {
+		exists, err := store.Exists(thumbnail.Key(hash))
+		return err == nil && exists
+	}, 2*time.Second, 10*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, worker.Stop(ctx))
+}
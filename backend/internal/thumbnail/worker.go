@@ -0,0 +1,130 @@
+package thumbnail
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"sync"
+
+	"certitrack/internal/config"
+	"certitrack/internal/storage"
+)
+
+// queueSize bounds pending work. When it is full new requests are dropped;
+// the thumbnail endpoint queues them again the next time they are asked for.
+const queueSize = 256
+
+type request struct {
+	contentHash string
+	mimeType    string
+}
+
+// Worker generates thumbnails in the background so uploads do not wait for
+// image decoding.
+type Worker struct {
+	store   storage.BlobStore
+	size    int
+	workers int
+	queue   chan request
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewWorker(store storage.BlobStore, cfg *config.Config) *Worker {
+	return &Worker{
+		store:   store,
+		size:    cfg.Thumbnails.Size,
+		workers: cfg.Thumbnails.Workers,
+		queue:   make(chan request, queueSize),
+	}
+}
+
+// Start launches the worker goroutines. A non-positive worker count disables
+// generation; placeholders are served instead.
+func (w *Worker) Start() {
+	if w.workers <= 0 {
+		log.Println("Thumbnail worker disabled (THUMBNAIL_WORKERS <= 0)")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w.cancel = cancel
+	for i := 0; i < w.workers; i++ {
+		w.wg.Add(1)
+		go func() {
+			defer w.wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case req := <-w.queue:
+					if err := w.Process(req.contentHash, req.mimeType); err != nil {
+						log.Printf("Failed to generate thumbnail for %s: %v", req.contentHash, err)
+					}
+				}
+			}
+		}()
+	}
+}
+
+// Stop stops the workers after their current thumbnail and waits for them or
+// for ctx to expire, whichever comes first. Queued requests are discarded.
+func (w *Worker) Stop(ctx context.Context) error {
+	if w.cancel == nil {
+		return nil
+	}
+	w.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Enqueue requests a thumbnail for the stored content without blocking.
+// Unsupported types are ignored.
+func (w *Worker) Enqueue(contentHash, mimeType string) {
+	if contentHash == "" || !Supports(mimeType) {
+		return
+	}
+	select {
+	case w.queue <- request{contentHash: contentHash, mimeType: mimeType}:
+	default:
+		log.Printf("Thumbnail queue full, dropping %s", contentHash)
+	}
+}
+
+// Process generates and stores the thumbnail for the content unless it
+// already exists.
+func (w *Worker) Process(contentHash, mimeType string) error {
+	if !Supports(mimeType) {
+		return nil
+	}
+	key := Key(contentHash)
+	exists, err := w.store.Exists(key)
+	if err != nil || exists {
+		return err
+	}
+
+	src, err := w.store.Open(storage.ContentKey(contentHash))
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	data, err := Generate(src, w.size)
+	if err != nil {
+		return err
+	}
+	_, err = w.store.Save(key, bytes.NewReader(data), int64(len(data)))
+	return err
+}
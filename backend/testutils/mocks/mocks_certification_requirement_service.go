@@ -0,0 +1,34 @@
+package mocks
+
+import (
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCertificationRequirementService struct {
+	mock.Mock
+}
+
+func (m *MockCertificationRequirementService) CreateRequirement(req *services.CreateCertificationRequirementRequest, actorID uuid.UUID) (*models.CertificationRequirement, error) {
+	args := m.Called(req, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CertificationRequirement), args.Error(1)
+}
+
+func (m *MockCertificationRequirementService) ListRequirements(req *services.ListCertificationRequirementsRequest) (*services.CertificationRequirementListResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.CertificationRequirementListResponse), args.Error(1)
+}
+
+func (m *MockCertificationRequirementService) DeleteRequirement(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
@@ -0,0 +1,114 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCertificationService struct {
+	mock.Mock
+}
+
+func (m *MockCertificationService) CreateCertification(req *services.CreateCertificationRequest, actorID uuid.UUID) (*models.Certification, error) {
+	args := m.Called(req, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Certification), args.Error(1)
+}
+
+func (m *MockCertificationService) GetCertification(id uuid.UUID) (*models.Certification, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Certification), args.Error(1)
+}
+
+func (m *MockCertificationService) ListCertifications(req *services.ListCertificationsRequest) (*services.CertificationListResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.CertificationListResponse), args.Error(1)
+}
+
+func (m *MockCertificationService) ListPersonCertifications(personID uuid.UUID, req *services.ListCertificationsRequest) (*services.CertificationListResponse, error) {
+	args := m.Called(personID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.CertificationListResponse), args.Error(1)
+}
+
+func (m *MockCertificationService) ListEquipmentCertifications(equipmentID uuid.UUID, req *services.ListCertificationsRequest) (*services.CertificationListResponse, error) {
+	args := m.Called(equipmentID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.CertificationListResponse), args.Error(1)
+}
+
+func (m *MockCertificationService) UpdateCertification(id uuid.UUID, req *services.UpdateCertificationRequest, actorID uuid.UUID) (*models.Certification, error) {
+	args := m.Called(id, req, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Certification), args.Error(1)
+}
+
+func (m *MockCertificationService) DeleteCertification(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockCertificationService) RevokeCertification(id uuid.UUID, req *services.RevokeCertificationRequest, actorID uuid.UUID) (*models.Certification, error) {
+	args := m.Called(id, req, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Certification), args.Error(1)
+}
+
+func (m *MockCertificationService) ActivateCertification(id uuid.UUID, req *services.ActivateCertificationRequest, actorID uuid.UUID) (*models.Certification, error) {
+	args := m.Called(id, req, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Certification), args.Error(1)
+}
+
+func (m *MockCertificationService) GetStatusHistory(id uuid.UUID) ([]models.CertificationStatusHistory, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CertificationStatusHistory), args.Error(1)
+}
+
+func (m *MockCertificationService) ExpireOverdueCertifications(ctx context.Context, asOf time.Time) (int, error) {
+	args := m.Called(ctx, asOf)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockCertificationService) RenewCertification(id uuid.UUID, req *services.RenewCertificationRequest, actorID uuid.UUID) (*models.Certification, error) {
+	args := m.Called(id, req, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Certification), args.Error(1)
+}
+
+func (m *MockCertificationService) GetRenewalHistory(id uuid.UUID) ([]models.Certification, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Certification), args.Error(1)
+}
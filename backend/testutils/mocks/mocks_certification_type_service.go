@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCertificationTypeService struct {
+	mock.Mock
+}
+
+func (m *MockCertificationTypeService) CreateType(req *services.CreateCertificationTypeRequest, actorID uuid.UUID) (*models.CertificationType, error) {
+	args := m.Called(req, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CertificationType), args.Error(1)
+}
+
+func (m *MockCertificationTypeService) GetType(id uuid.UUID) (*models.CertificationType, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CertificationType), args.Error(1)
+}
+
+func (m *MockCertificationTypeService) ListTypes(req *services.ListCertificationTypesRequest) (*services.CertificationTypeListResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.CertificationTypeListResponse), args.Error(1)
+}
+
+func (m *MockCertificationTypeService) UpdateType(id uuid.UUID, req *services.UpdateCertificationTypeRequest) (*models.CertificationType, error) {
+	args := m.Called(id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CertificationType), args.Error(1)
+}
+
+func (m *MockCertificationTypeService) DeleteType(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"certitrack/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockComplianceService struct {
+	mock.Mock
+}
+
+func (m *MockComplianceService) GetPersonCompliance(personID uuid.UUID) (*services.PersonCompliance, error) {
+	args := m.Called(personID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.PersonCompliance), args.Error(1)
+}
+
+func (m *MockComplianceService) ListGaps(req *services.ListComplianceGapsRequest) (*services.ComplianceGapListResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.ComplianceGapListResponse), args.Error(1)
+}
@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"certitrack/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDocumentAccessService struct {
+	mock.Mock
+}
+
+func (m *MockDocumentAccessService) ListDocumentAccess(docID uuid.UUID, req *services.PageRequest) (*services.DocumentAccessListResponse, error) {
+	args := m.Called(docID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.DocumentAccessListResponse), args.Error(1)
+}
+
+func (m *MockDocumentAccessService) ListUserAccess(userID uuid.UUID, req *services.PageRequest) (*services.DocumentAccessListResponse, error) {
+	args := m.Called(userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.DocumentAccessListResponse), args.Error(1)
+}
@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"io"
+	"net/url"
+
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDocumentLinkService struct {
+	mock.Mock
+}
+
+func (m *MockDocumentLinkService) CreateLink(docID uuid.UUID, req *services.CreateDocumentLinkRequest, actorID uuid.UUID, sessionID string) (*services.DocumentLink, error) {
+	args := m.Called(docID, req, actorID, sessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.DocumentLink), args.Error(1)
+}
+
+func (m *MockDocumentLinkService) OpenLink(docID uuid.UUID, query url.Values, access *services.AccessInfo) (*models.CertificationDocument, io.ReadCloser, error) {
+	args := m.Called(docID, query, access)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*models.CertificationDocument), args.Get(1).(io.ReadCloser), args.Error(2)
+}
@@ -0,0 +1,90 @@
+package mocks
+
+import (
+	"context"
+	"io"
+
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDocumentService struct {
+	mock.Mock
+}
+
+func (m *MockDocumentService) UploadDocument(certID uuid.UUID, input *services.UploadDocumentInput, actorID uuid.UUID) (*models.CertificationDocument, error) {
+	args := m.Called(certID, input, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CertificationDocument), args.Error(1)
+}
+
+func (m *MockDocumentService) ListDocuments(certID uuid.UUID) ([]models.CertificationDocument, error) {
+	args := m.Called(certID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CertificationDocument), args.Error(1)
+}
+
+func (m *MockDocumentService) OpenDocument(certID, docID uuid.UUID, access *services.AccessInfo) (*models.CertificationDocument, io.ReadCloser, error) {
+	args := m.Called(certID, docID, access)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*models.CertificationDocument), args.Get(1).(io.ReadCloser), args.Error(2)
+}
+
+func (m *MockDocumentService) DeleteDocument(certID, docID uuid.UUID) error {
+	args := m.Called(certID, docID)
+	return args.Error(0)
+}
+
+func (m *MockDocumentService) ReplaceDocument(certID, docID uuid.UUID, input *services.UploadDocumentInput, actorID uuid.UUID) (*models.CertificationDocument, error) {
+	args := m.Called(certID, docID, input, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CertificationDocument), args.Error(1)
+}
+
+func (m *MockDocumentService) ListVersions(certID, docID uuid.UUID) ([]models.CertificationDocumentVersion, error) {
+	args := m.Called(certID, docID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CertificationDocumentVersion), args.Error(1)
+}
+
+func (m *MockDocumentService) OpenVersion(certID, docID uuid.UUID, version int, access *services.AccessInfo) (*models.CertificationDocumentVersion, io.ReadCloser, error) {
+	args := m.Called(certID, docID, version, access)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*models.CertificationDocumentVersion), args.Get(1).(io.ReadCloser), args.Error(2)
+}
+
+func (m *MockDocumentService) DeleteVersion(certID, docID uuid.UUID, version int) error {
+	args := m.Called(certID, docID, version)
+	return args.Error(0)
+}
+
+func (m *MockDocumentService) VerifyStorage(ctx context.Context) (*services.StorageVerifyReport, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.StorageVerifyReport), args.Error(1)
+}
+
+func (m *MockDocumentService) GetThumbnail(docID uuid.UUID, access *services.AccessInfo) (*services.DocumentThumbnail, error) {
+	args := m.Called(docID, access)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.DocumentThumbnail), args.Error(1)
+}
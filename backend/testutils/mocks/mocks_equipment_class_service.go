@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEquipmentClassService struct {
+	mock.Mock
+}
+
+func (m *MockEquipmentClassService) CreateClass(req *services.CreateEquipmentClassRequest, actorID uuid.UUID) (*models.EquipmentClass, error) {
+	args := m.Called(req, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EquipmentClass), args.Error(1)
+}
+
+func (m *MockEquipmentClassService) GetClass(id uuid.UUID) (*models.EquipmentClass, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EquipmentClass), args.Error(1)
+}
+
+func (m *MockEquipmentClassService) ListClasses(req *services.PageRequest) (*services.EquipmentClassListResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.EquipmentClassListResponse), args.Error(1)
+}
+
+func (m *MockEquipmentClassService) UpdateClass(id uuid.UUID, req *services.UpdateEquipmentClassRequest) (*models.EquipmentClass, error) {
+	args := m.Called(id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.EquipmentClass), args.Error(1)
+}
+
+func (m *MockEquipmentClassService) DeleteClass(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
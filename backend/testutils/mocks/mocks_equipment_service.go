@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEquipmentService struct {
+	mock.Mock
+}
+
+func (m *MockEquipmentService) CreateEquipment(req *services.CreateEquipmentRequest, actorID uuid.UUID) (*models.Equipment, error) {
+	args := m.Called(req, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Equipment), args.Error(1)
+}
+
+func (m *MockEquipmentService) GetEquipment(id uuid.UUID) (*models.Equipment, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Equipment), args.Error(1)
+}
+
+func (m *MockEquipmentService) ListEquipment(req *services.ListEquipmentRequest) (*services.EquipmentListResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.EquipmentListResponse), args.Error(1)
+}
+
+func (m *MockEquipmentService) UpdateEquipment(id uuid.UUID, req *services.UpdateEquipmentRequest, actorID uuid.UUID) (*models.Equipment, error) {
+	args := m.Called(id, req, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Equipment), args.Error(1)
+}
+
+func (m *MockEquipmentService) DeactivateEquipment(id uuid.UUID, actorID uuid.UUID) error {
+	args := m.Called(id, actorID)
+	return args.Error(0)
+}
@@ -0,0 +1,64 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockNotificationDigestService struct {
+	mock.Mock
+}
+
+func (m *MockNotificationDigestService) BuildDigests(ctx context.Context, asOf time.Time) (*services.DigestBuildReport, error) {
+	args := m.Called(ctx, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.DigestBuildReport), args.Error(1)
+}
+
+func (m *MockNotificationDigestService) DeliverDue(ctx context.Context, now time.Time) (*services.DeliveryReport, error) {
+	args := m.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.DeliveryReport), args.Error(1)
+}
+
+func (m *MockNotificationDigestService) ListDigests(req *services.ListNotificationDigestsRequest) (*services.NotificationDigestListResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.NotificationDigestListResponse), args.Error(1)
+}
+
+func (m *MockNotificationDigestService) RequeueDigest(id uuid.UUID) (*models.NotificationDigest, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.NotificationDigest), args.Error(1)
+}
+
+func (m *MockNotificationDigestService) GetPreferences(userID uuid.UUID) (*services.NotificationPreferences, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.NotificationPreferences), args.Error(1)
+}
+
+func (m *MockNotificationDigestService) UpdatePreferences(userID uuid.UUID, req *services.UpdateNotificationPreferencesRequest) (*services.NotificationPreferences, error) {
+	args := m.Called(userID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.NotificationPreferences), args.Error(1)
+}
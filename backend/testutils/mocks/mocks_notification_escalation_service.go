@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockNotificationEscalationService struct {
+	mock.Mock
+}
+
+func (m *MockNotificationEscalationService) EscalateUnrenewed(ctx context.Context, asOf time.Time) (*services.EscalationReport, error) {
+	args := m.Called(ctx, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.EscalationReport), args.Error(1)
+}
+
+func (m *MockNotificationEscalationService) DeliverDue(ctx context.Context, now time.Time) (*services.DeliveryReport, error) {
+	args := m.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.DeliveryReport), args.Error(1)
+}
+
+func (m *MockNotificationEscalationService) RequeueEscalations(notificationID uuid.UUID) ([]models.NotificationEscalation, error) {
+	args := m.Called(notificationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.NotificationEscalation), args.Error(1)
+}
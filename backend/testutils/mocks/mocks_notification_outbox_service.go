@@ -0,0 +1,40 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockNotificationOutboxService struct {
+	mock.Mock
+}
+
+func (m *MockNotificationOutboxService) DeliverDue(ctx context.Context, now time.Time) (*services.DeliveryReport, error) {
+	args := m.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.DeliveryReport), args.Error(1)
+}
+
+func (m *MockNotificationOutboxService) ListNotifications(req *services.ListNotificationsRequest) (*services.NotificationListResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.NotificationListResponse), args.Error(1)
+}
+
+func (m *MockNotificationOutboxService) RequeueNotification(id uuid.UUID) (*models.Notification, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Notification), args.Error(1)
+}
@@ -0,0 +1,66 @@
+package mocks
+
+import (
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockNotificationRuleService struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRuleService) CreateRule(req *services.CreateNotificationRuleRequest, actorID uuid.UUID) (*models.NotificationRule, error) {
+	args := m.Called(req, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.NotificationRule), args.Error(1)
+}
+
+func (m *MockNotificationRuleService) GetRule(id uuid.UUID) (*models.NotificationRule, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.NotificationRule), args.Error(1)
+}
+
+func (m *MockNotificationRuleService) ListRules(req *services.ListNotificationRulesRequest) (*services.NotificationRuleListResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.NotificationRuleListResponse), args.Error(1)
+}
+
+func (m *MockNotificationRuleService) UpdateRule(id uuid.UUID, req *services.UpdateNotificationRuleRequest) (*models.NotificationRule, error) {
+	args := m.Called(id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.NotificationRule), args.Error(1)
+}
+
+func (m *MockNotificationRuleService) DeleteRule(id uuid.UUID) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockNotificationRuleService) ResolveRules(certType *models.CertificationType) (*services.EffectiveNotificationRules, error) {
+	args := m.Called(certType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.EffectiveNotificationRules), args.Error(1)
+}
+
+func (m *MockNotificationRuleService) ResolveForCertification(certID uuid.UUID) (*services.EffectiveNotificationRules, error) {
+	args := m.Called(certID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.EffectiveNotificationRules), args.Error(1)
+}
@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"certitrack/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOperatorAuthorizationService struct {
+	mock.Mock
+}
+
+func (m *MockOperatorAuthorizationService) ListAuthorizedOperators(equipmentID uuid.UUID, req *services.PageRequest) (*services.AuthorizedOperatorListResponse, error) {
+	args := m.Called(equipmentID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.AuthorizedOperatorListResponse), args.Error(1)
+}
+
+func (m *MockOperatorAuthorizationService) ListAuthorizedEquipment(personID uuid.UUID, req *services.PageRequest) (*services.AuthorizedEquipmentListResponse, error) {
+	args := m.Called(personID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.AuthorizedEquipmentListResponse), args.Error(1)
+}
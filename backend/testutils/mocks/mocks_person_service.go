@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"certitrack/internal/models"
+	"certitrack/internal/services"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPersonService struct {
+	mock.Mock
+}
+
+func (m *MockPersonService) CreatePerson(req *services.CreatePersonRequest, actorID uuid.UUID) (*models.Person, error) {
+	args := m.Called(req, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Person), args.Error(1)
+}
+
+func (m *MockPersonService) GetPerson(id uuid.UUID) (*models.Person, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Person), args.Error(1)
+}
+
+func (m *MockPersonService) ListPeople(req *services.ListPeopleRequest) (*services.PersonListResponse, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.PersonListResponse), args.Error(1)
+}
+
+func (m *MockPersonService) UpdatePerson(id uuid.UUID, req *services.UpdatePersonRequest, actorID uuid.UUID) (*models.Person, error) {
+	args := m.Called(id, req, actorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Person), args.Error(1)
+}
+
+func (m *MockPersonService) DeactivatePerson(id uuid.UUID, actorID uuid.UUID) error {
+	args := m.Called(id, actorID)
+	return args.Error(0)
+}
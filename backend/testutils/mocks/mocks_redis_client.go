@@ -25,6 +25,11 @@ func (m *MockRedisClient) Exists(ctx context.Context, keys ...string) *redis.Int
 	return callArgs.Get(0).(*redis.IntCmd)
 }
 
+func (m *MockRedisClient) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd {
+	args := m.Called(ctx, key, value, expiration)
+	return args.Get(0).(*redis.BoolCmd)
+}
+
 func stringSliceToInterfaceSlice(strSlice []string) []interface{} {
 	ifaceSlice := make([]interface{}, len(strSlice))
 	for i, v := range strSlice {
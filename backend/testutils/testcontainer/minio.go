@@ -0,0 +1,84 @@
+package testcontainer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"certitrack/internal/config"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	minioAccessKey = "minioadmin"
+	minioSecretKey = "minioadmin"
+)
+
+type MinioContainer struct {
+	Container testcontainers.Container
+	Config    config.S3Config
+}
+
+// SetupMinio starts a MinIO server and returns an S3Config pointing at it.
+// The bucket is not created; callers decide whether they need it.
+func SetupMinio(ctx context.Context, bucket string) (*MinioContainer, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        "minio/minio:latest",
+		ExposedPorts: []string{"9000/tcp"},
+		Cmd:          []string{"server", "/data"},
+		Env: map[string]string{
+			"MINIO_ROOT_USER":     minioAccessKey,
+			"MINIO_ROOT_PASSWORD": minioSecretKey,
+		},
+		WaitingFor: wait.ForHTTP("/minio/health/live").
+			WithPort("9000/tcp").
+			WithStartupTimeout(30 * time.Second),
+		HostConfigModifier: func(hostConfig *container.HostConfig) {
+			hostConfig.AutoRemove = false
+		},
+	}
+
+	minioC, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating container: %w", err)
+	}
+
+	host, err := minioC.Host(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container host: %w", err)
+	}
+	port, err := minioC.MappedPort(ctx, "9000")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container port: %w", err)
+	}
+
+	return &MinioContainer{
+		Container: minioC,
+		Config: config.S3Config{
+			Endpoint:        fmt.Sprintf("http://%s:%s", host, port.Port()),
+			Region:          "us-east-1",
+			Bucket:          bucket,
+			AccessKeyID:     minioAccessKey,
+			SecretAccessKey: minioSecretKey,
+			ForcePathStyle:  true,
+		},
+	}, nil
+}
+
+func (mc *MinioContainer) Teardown(ctx context.Context) error {
+	if mc.Container != nil {
+		if err := mc.Container.Terminate(ctx); err != nil {
+			log.Printf("error to stop container: %v", err)
+			return err
+		}
+	}
+
+	return nil
+}